@@ -1,7 +1,12 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+
+	"github.com/wechatpy/wechatgo"
 )
 
 // HTTPClient HTTP客户端接口
@@ -11,6 +16,22 @@ type HTTPClient interface {
 	GetRaw(url string) (*http.Response, error)
 }
 
+// Uploader 流式文件上传接口
+type Uploader interface {
+	UploadStream(url string, params map[string]string, file *UploadFile) (map[string]interface{}, error)
+}
+
+// RawRequester 原始 HTTP 请求接口，用于下载等非 JSON 响应的接口
+type RawRequester interface {
+	RawRequest(ctx context.Context, method, url string, params map[string]string, body io.Reader) (*http.Response, error)
+}
+
+// ContextRequester 支持 context 的请求接口，ctx 会传递到限流器和 HTTP 请求
+type ContextRequester interface {
+	GetContext(ctx context.Context, url string, params map[string]string) (map[string]interface{}, error)
+	PostContext(ctx context.Context, url string, data interface{}) (map[string]interface{}, error)
+}
+
 // BaseAPI API 基类
 type BaseAPI struct {
 	client interface {
@@ -52,7 +73,56 @@ func (api *BaseAPI) Post(url string, data interface{}) (map[string]interface{},
 	return api.client.Post(url, data)
 }
 
+// GetContext 发送 GET 请求，客户端不支持 context 时只在请求前检查 ctx
+func (api *BaseAPI) GetContext(ctx context.Context, url string, params map[string]string) (map[string]interface{}, error) {
+	if c, ok := api.client.(ContextRequester); ok {
+		return c.GetContext(ctx, url, params)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return api.client.Get(url, params)
+}
+
+// PostContext 发送 POST 请求，客户端不支持 context 时只在请求前检查 ctx
+func (api *BaseAPI) PostContext(ctx context.Context, url string, data interface{}) (map[string]interface{}, error) {
+	if c, ok := api.client.(ContextRequester); ok {
+		return c.PostContext(ctx, url, data)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return api.client.Post(url, data)
+}
+
 // GetAccessToken 获取 access token
 func (api *BaseAPI) GetAccessToken() (string, error) {
 	return api.client.GetAccessToken()
 }
+
+// decodeResponse 读取 JSON 响应并检查 errcode
+func decodeResponse(resp *http.Response) (map[string]interface{}, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	if err := wechatgo.CheckResponse(result, resp, body); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DecodeResult 将接口返回的 map 转换为结构体
+func DecodeResult(result map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
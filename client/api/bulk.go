@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultBulkConcurrency 批量操作默认的并发数
+const defaultBulkConcurrency = 4
+
+// BulkOption 批量操作选项
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	concurrency int
+}
+
+// WithConcurrency 设置批量操作同时执行的分片数，小于 1 时按 1 处理
+// 请求速率由客户端的限流器控制，参见 client.WithRateLimit
+func WithConcurrency(n int) BulkOption {
+	return func(o *bulkOptions) {
+		o.concurrency = n
+	}
+}
+
+// ChunkError 批量操作中单个分片的错误
+type ChunkError struct {
+	Index int      // 分片序号，从 0 开始
+	Items []string // 分片包含的 openid
+	Err   error
+}
+
+// Error 实现 error 接口
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (%d items): %v", e.Index, len(e.Items), e.Err)
+}
+
+// Unwrap 返回分片的原始错误
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError 批量操作的部分失败，Chunks 按分片序号排列
+// 未列出的分片均已成功，ctx 取消后未执行的分片也会以 ctx 的错误列出
+type BulkError struct {
+	Total  int // 分片总数
+	Chunks []*ChunkError
+}
+
+// Error 实现 error 接口
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%d of %d chunks failed, first error: %v", len(e.Chunks), e.Total, e.Chunks[0])
+}
+
+// Unwrap 返回各分片的错误，便于使用 errors.Is/As 判断
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		errs[i] = chunk
+	}
+	return errs
+}
+
+// splitChunks 将 items 按 size 切分为多个分片
+func splitChunks(items []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		chunks = append(chunks, items[start:end])
+	}
+	return chunks
+}
+
+// runChunks 将 items 切分后交给 worker 执行 fn
+// 所有分片执行完毕后返回，存在失败的分片时返回 *BulkError
+func runChunks(ctx context.Context, items []string, size int, opts []BulkOption, fn func(index int, chunk []string) error) error {
+	chunks := splitChunks(items, size)
+	errs := runParallel(ctx, len(chunks), opts, func(i int) error {
+		return fn(i, chunks[i])
+	})
+
+	var failed []*ChunkError
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &ChunkError{Index: i, Items: chunks[i], Err: err})
+		}
+	}
+	if len(failed) > 0 {
+		return &BulkError{Total: len(chunks), Chunks: failed}
+	}
+	return nil
+}
+
+// runParallel 使用固定数量的 worker 执行 n 个任务，返回每个任务的错误
+// ctx 结束后尚未开始的任务不再执行，其错误为 ctx 的错误
+func runParallel(ctx context.Context, n int, opts []BulkOption, fn func(i int) error) []error {
+	o := &bulkOptions{concurrency: defaultBulkConcurrency}
+	for _, opt := range opts {
+		opt(o)
+	}
+	workers := min(max(o.concurrency, 1), n)
+
+	errs := make([]error, n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
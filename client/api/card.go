@@ -0,0 +1,568 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CardType 卡券类型
+type CardType string
+
+const (
+	CardTypeGroupon       CardType = "GROUPON"        // 团购券
+	CardTypeCash          CardType = "CASH"           // 代金券
+	CardTypeDiscount      CardType = "DISCOUNT"       // 折扣券
+	CardTypeGift          CardType = "GIFT"           // 兑换券
+	CardTypeGeneralCoupon CardType = "GENERAL_COUPON" // 优惠券
+	CardTypeMemberCard    CardType = "MEMBER_CARD"    // 会员卡
+	CardTypeScenicTicket  CardType = "SCENIC_TICKET"  // 景点门票
+	CardTypeMovieTicket   CardType = "MOVIE_TICKET"   // 电影票
+	CardTypeBoardingPass  CardType = "BOARDING_PASS"  // 飞机票
+	CardTypeMeetingTicket CardType = "MEETING_TICKET" // 会议门票
+	CardTypeBusTicket     CardType = "BUS_TICKET"     // 汽车票
+)
+
+// 卡券码型
+const (
+	CodeTypeText        = "CODE_TYPE_TEXT"         // 文本
+	CodeTypeBarcode     = "CODE_TYPE_BARCODE"      // 一维码
+	CodeTypeQRCode      = "CODE_TYPE_QRCODE"       // 二维码
+	CodeTypeOnlyQRCode  = "CODE_TYPE_ONLY_QRCODE"  // 仅显示二维码
+	CodeTypeOnlyBarcode = "CODE_TYPE_ONLY_BARCODE" // 仅显示一维码
+	CodeTypeNone        = "CODE_TYPE_NONE"         // 不显示任何码型
+)
+
+// 卡券有效期类型
+const (
+	DateTypeFixTimeRange = "DATE_TYPE_FIX_TIME_RANGE" // 固定日期区间
+	DateTypeFixTerm      = "DATE_TYPE_FIX_TERM"       // 领取后固定天数
+	DateTypePermanent    = "DATE_TYPE_PERMANENT"      // 永久有效，仅会员卡可用
+)
+
+// CardStatus 卡券审核状态
+type CardStatus string
+
+const (
+	CardStatusNotVerify  CardStatus = "CARD_STATUS_NOT_VERIFY"  // 待审核
+	CardStatusVerifyFail CardStatus = "CARD_STATUS_VERIFY_FAIL" // 审核失败
+	CardStatusVerifyOK   CardStatus = "CARD_STATUS_VERIFY_OK"   // 通过审核
+	CardStatusDelete     CardStatus = "CARD_STATUS_DELETE"      // 已删除
+	CardStatusDispatch   CardStatus = "CARD_STATUS_DISPATCH"    // 已投放
+)
+
+// 卡券二维码类型
+const (
+	QRCard         = "QR_CARD"          // 单张卡券
+	QRMultipleCard = "QR_MULTIPLE_CARD" // 多张卡券
+)
+
+// CardAPI 卡券 API
+type CardAPI struct {
+	*BaseAPI
+	rootURL func(path string) string
+}
+
+// NewCardAPI 创建卡券 API
+func NewCardAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+	RootURL(path string) string
+}) *CardAPI {
+	return &CardAPI{
+		BaseAPI: NewBaseAPI(client),
+		rootURL: client.RootURL,
+	}
+}
+
+// post 调用 /card 下的接口，卡券接口不在 /cgi-bin 路径下
+func (api *CardAPI) post(path string, data interface{}) (map[string]interface{}, error) {
+	return api.Post(api.rootURL(path), data)
+}
+
+// CardDateInfo 卡券有效期
+type CardDateInfo struct {
+	Type           string `json:"type"`
+	BeginTimestamp int64  `json:"begin_timestamp,omitempty"`
+	EndTimestamp   int64  `json:"end_timestamp,omitempty"`
+	FixedTerm      int    `json:"fixed_term,omitempty"`
+	FixedBeginTerm int    `json:"fixed_begin_term,omitempty"`
+}
+
+// CardSKU 卡券库存
+type CardSKU struct {
+	Quantity int `json:"quantity"`
+}
+
+// CardBaseInfo 卡券基础信息
+type CardBaseInfo struct {
+	LogoURL                   string       `json:"logo_url"`
+	BrandName                 string       `json:"brand_name"`
+	CodeType                  string       `json:"code_type"`
+	Title                     string       `json:"title"`
+	Color                     string       `json:"color"`
+	Notice                    string       `json:"notice"`
+	ServicePhone              string       `json:"service_phone,omitempty"`
+	Description               string       `json:"description"`
+	DateInfo                  CardDateInfo `json:"date_info"`
+	SKU                       CardSKU      `json:"sku"`
+	UseLimit                  int          `json:"use_limit,omitempty"`
+	GetLimit                  int          `json:"get_limit,omitempty"`
+	UseCustomCode             bool         `json:"use_custom_code,omitempty"`
+	GetCustomCodeMode         string       `json:"get_custom_code_mode,omitempty"`
+	BindOpenID                bool         `json:"bind_openid,omitempty"`
+	CanShare                  *bool        `json:"can_share,omitempty"`
+	CanGiveFriend             *bool        `json:"can_give_friend,omitempty"`
+	LocationIDList            []int64      `json:"location_id_list,omitempty"`
+	UseAllLocations           bool         `json:"use_all_locations,omitempty"`
+	CenterTitle               string       `json:"center_title,omitempty"`
+	CenterSubTitle            string       `json:"center_sub_title,omitempty"`
+	CenterURL                 string       `json:"center_url,omitempty"`
+	CenterAppBrandUserName    string       `json:"center_app_brand_user_name,omitempty"`
+	CenterAppBrandPass        string       `json:"center_app_brand_pass,omitempty"`
+	CustomURLName             string       `json:"custom_url_name,omitempty"`
+	CustomURL                 string       `json:"custom_url,omitempty"`
+	CustomURLSubTitle         string       `json:"custom_url_sub_title,omitempty"`
+	CustomAppBrandUserName    string       `json:"custom_app_brand_user_name,omitempty"`
+	CustomAppBrandPass        string       `json:"custom_app_brand_pass,omitempty"`
+	PromotionURLName          string       `json:"promotion_url_name,omitempty"`
+	PromotionURL              string       `json:"promotion_url,omitempty"`
+	PromotionURLSubTitle      string       `json:"promotion_url_sub_title,omitempty"`
+	PromotionAppBrandUserName string       `json:"promotion_app_brand_user_name,omitempty"`
+	PromotionAppBrandPass     string       `json:"promotion_app_brand_pass,omitempty"`
+	Source                    string       `json:"source,omitempty"`
+}
+
+// CardAdvancedInfo 卡券高级信息，字段较多，按官方文档以 map 形式填写
+// 如 use_condition、abstract、text_image_list、time_limit、business_service
+type CardAdvancedInfo map[string]interface{}
+
+// Card 创建卡券时的卡券信息
+// Fields 为卡券类型特有的字段，例如团购券的 deal_detail、代金券的 least_cost 和 reduce_cost、
+// 会员卡的 prerogative、supply_bonus、supply_balance 等，会与 base_info 放在同一层级
+type Card struct {
+	CardType     CardType
+	BaseInfo     CardBaseInfo
+	AdvancedInfo CardAdvancedInfo
+	Fields       map[string]interface{}
+}
+
+// MarshalJSON 按卡券类型生成 {"card_type": "GROUPON", "groupon": {...}} 结构
+func (c Card) MarshalJSON() ([]byte, error) {
+	detail := make(map[string]interface{}, len(c.Fields)+2)
+	for k, v := range c.Fields {
+		detail[k] = v
+	}
+	detail["base_info"] = c.BaseInfo
+	if len(c.AdvancedInfo) > 0 {
+		detail["advanced_info"] = c.AdvancedInfo
+	}
+	return json.Marshal(map[string]interface{}{
+		"card_type":                         c.CardType,
+		strings.ToLower(string(c.CardType)): detail,
+	})
+}
+
+// NewGrouponCard 创建团购券，dealDetail 为团购详情
+func NewGrouponCard(baseInfo CardBaseInfo, dealDetail string) *Card {
+	return &Card{CardType: CardTypeGroupon, BaseInfo: baseInfo, Fields: map[string]interface{}{
+		"deal_detail": dealDetail,
+	}}
+}
+
+// NewCashCard 创建代金券，leastCost 为起用金额，reduceCost 为减免金额，单位为分
+func NewCashCard(baseInfo CardBaseInfo, leastCost, reduceCost int) *Card {
+	return &Card{CardType: CardTypeCash, BaseInfo: baseInfo, Fields: map[string]interface{}{
+		"least_cost":  leastCost,
+		"reduce_cost": reduceCost,
+	}}
+}
+
+// NewDiscountCard 创建折扣券，discount 为打折额度百分比，如 30 表示七折
+func NewDiscountCard(baseInfo CardBaseInfo, discount int) *Card {
+	return &Card{CardType: CardTypeDiscount, BaseInfo: baseInfo, Fields: map[string]interface{}{
+		"discount": discount,
+	}}
+}
+
+// NewGiftCard 创建兑换券，gift 为兑换内容
+func NewGiftCard(baseInfo CardBaseInfo, gift string) *Card {
+	return &Card{CardType: CardTypeGift, BaseInfo: baseInfo, Fields: map[string]interface{}{
+		"gift": gift,
+	}}
+}
+
+// NewGeneralCoupon 创建优惠券，defaultDetail 为优惠详情
+func NewGeneralCoupon(baseInfo CardBaseInfo, defaultDetail string) *Card {
+	return &Card{CardType: CardTypeGeneralCoupon, BaseInfo: baseInfo, Fields: map[string]interface{}{
+		"default_detail": defaultDetail,
+	}}
+}
+
+// Create 创建卡券，返回 card_id
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Create_a_Coupon_Voucher_or_Card.html
+func (api *CardAPI) Create(card *Card) (string, error) {
+	result, err := api.post("/card/create", map[string]interface{}{"card": *card})
+	if err != nil {
+		return "", err
+	}
+
+	if cardID, ok := result["card_id"].(string); ok {
+		return cardID, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// Get 查询卡券详情，返回接口中的 card 字段
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) Get(cardID string) (map[string]interface{}, error) {
+	result, err := api.post("/card/get", map[string]string{"card_id": cardID})
+	if err != nil {
+		return nil, err
+	}
+
+	if card, ok := result["card"].(map[string]interface{}); ok {
+		return card, nil
+	}
+	return nil, fmt.Errorf("unexpected response format")
+}
+
+// CardIDList 批量查询卡券列表结果
+type CardIDList struct {
+	CardIDList []string `json:"card_id_list"`
+	TotalNum   int      `json:"total_num"`
+}
+
+// BatchGet 批量查询卡券列表，statusList 为空时不按状态过滤
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) BatchGet(offset, count int, statusList ...CardStatus) (*CardIDList, error) {
+	data := map[string]interface{}{
+		"offset": offset,
+		"count":  count,
+	}
+	if len(statusList) > 0 {
+		data["status_list"] = statusList
+	}
+	result, err := api.post("/card/batchget", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list CardIDList
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Update 更改卡券信息，fields 为卡券类型下需要修改的字段（如 base_info）
+// 返回值表示是否需要重新提交审核
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) Update(cardID string, cardType CardType, fields map[string]interface{}) (bool, error) {
+	result, err := api.post("/card/update", map[string]interface{}{
+		"card_id":                         cardID,
+		strings.ToLower(string(cardType)): fields,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	sendCheck, _ := result["send_check"].(bool)
+	return sendCheck, nil
+}
+
+// Delete 删除卡券，已领取的卡券同时失效
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) Delete(cardID string) error {
+	_, err := api.post("/card/delete", map[string]string{"card_id": cardID})
+	return err
+}
+
+// ModifyStock 修改卡券库存，increase 和 reduce 分别为增加和减少的数量
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) ModifyStock(cardID string, increase, reduce int) error {
+	data := map[string]interface{}{
+		"card_id": cardID,
+	}
+	if increase > 0 {
+		data["increase_stock_value"] = increase
+	}
+	if reduce > 0 {
+		data["reduce_stock_value"] = reduce
+	}
+	_, err := api.post("/card/modifystock", data)
+	return err
+}
+
+// CardQRCodeInfo 二维码中的卡券
+type CardQRCodeInfo struct {
+	CardID       string `json:"card_id"`
+	Code         string `json:"code,omitempty"`
+	OpenID       string `json:"openid,omitempty"`
+	IsUniqueCode bool   `json:"is_unique_code,omitempty"`
+	OuterStr     string `json:"outer_str,omitempty"`
+}
+
+// CardQRCodeRequest 创建卡券二维码请求
+// 单张卡券使用 Card，多张卡券（最多 5 张）使用 CardList
+type CardQRCodeRequest struct {
+	ExpireSeconds int
+	Card          *CardQRCodeInfo
+	CardList      []CardQRCodeInfo
+}
+
+// CardQRCode 卡券二维码
+type CardQRCode struct {
+	Ticket        string `json:"ticket"`
+	ExpireSeconds int    `json:"expire_seconds"`
+	URL           string `json:"url"`
+	ShowQRCodeURL string `json:"show_qrcode_url"`
+}
+
+// CreateQRCode 创建卡券投放二维码
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Distributing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) CreateQRCode(req *CardQRCodeRequest) (*CardQRCode, error) {
+	data := map[string]interface{}{}
+	if req.ExpireSeconds > 0 {
+		data["expire_seconds"] = req.ExpireSeconds
+	}
+	if len(req.CardList) > 0 {
+		data["action_name"] = QRMultipleCard
+		data["action_info"] = map[string]interface{}{
+			"multiple_card": map[string]interface{}{"card_list": req.CardList},
+		}
+	} else if req.Card != nil {
+		data["action_name"] = QRCard
+		data["action_info"] = map[string]interface{}{"card": *req.Card}
+	} else {
+		return nil, fmt.Errorf("card or card list is required")
+	}
+
+	result, err := api.post("/card/qrcode/create", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var qrcode CardQRCode
+	if err := DecodeResult(result, &qrcode); err != nil {
+		return nil, err
+	}
+	return &qrcode, nil
+}
+
+// LandingPageCard 货架中的卡券
+type LandingPageCard struct {
+	CardID   string `json:"card_id"`
+	ThumbURL string `json:"thumb_url"`
+}
+
+// LandingPage 卡券货架
+// Scene 为投放场景，如 SCENE_NEAR_BY、SCENE_MENU、SCENE_QRCODE、SCENE_ARTICLE、SCENE_H5
+type LandingPage struct {
+	Banner    string            `json:"banner"`
+	PageTitle string            `json:"page_title"`
+	CanShare  bool              `json:"can_share"`
+	Scene     string            `json:"scene"`
+	CardList  []LandingPageCard `json:"card_list"`
+}
+
+// LandingPageResult 创建货架结果
+type LandingPageResult struct {
+	URL    string `json:"url"`
+	PageID int64  `json:"page_id"`
+}
+
+// CreateLandingPage 创建卡券货架
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Distributing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) CreateLandingPage(page *LandingPage) (*LandingPageResult, error) {
+	result, err := api.post("/card/landingpage/create", page)
+	if err != nil {
+		return nil, err
+	}
+
+	var landing LandingPageResult
+	if err := DecodeResult(result, &landing); err != nil {
+		return nil, err
+	}
+	return &landing, nil
+}
+
+// DecryptCode 解码卡券跳转链接或 JS-SDK 回调中的加密 code
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Redeeming_a_coupon_voucher_or_card.html
+func (api *CardAPI) DecryptCode(encryptCode string) (string, error) {
+	result, err := api.post("/card/code/decrypt", map[string]string{"encrypt_code": encryptCode})
+	if err != nil {
+		return "", err
+	}
+
+	if code, ok := result["code"].(string); ok {
+		return code, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// CardCode 卡券 code 信息
+// UserCardStatus 取值为 NORMAL、CONSUMED、EXPIRE、GIFTING、GIFT_TIMEOUT、DELETE、UNAVAILABLE 等
+type CardCode struct {
+	Card struct {
+		CardID    string `json:"card_id"`
+		BeginTime int64  `json:"begin_time"`
+		EndTime   int64  `json:"end_time"`
+	} `json:"card"`
+	OpenID         string `json:"openid"`
+	CanConsume     bool   `json:"can_consume"`
+	UserCardStatus string `json:"user_card_status"`
+}
+
+// GetCode 查询卡券 code 的状态，checkConsume 为 true 时校验是否可核销
+// 自定义 code 卡券需要传入 cardID
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Redeeming_a_coupon_voucher_or_card.html
+func (api *CardAPI) GetCode(cardID, code string, checkConsume bool) (*CardCode, error) {
+	data := map[string]interface{}{
+		"code":          code,
+		"check_consume": checkConsume,
+	}
+	if cardID != "" {
+		data["card_id"] = cardID
+	}
+	result, err := api.post("/card/code/get", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var cardCode CardCode
+	if err := DecodeResult(result, &cardCode); err != nil {
+		return nil, err
+	}
+	return &cardCode, nil
+}
+
+// ConsumeResult 核销卡券结果
+type ConsumeResult struct {
+	Card struct {
+		CardID string `json:"card_id"`
+	} `json:"card"`
+	OpenID string `json:"openid"`
+}
+
+// ConsumeCode 核销卡券 code，自定义 code 卡券需要传入 cardID
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Redeeming_a_coupon_voucher_or_card.html
+func (api *CardAPI) ConsumeCode(cardID, code string) (*ConsumeResult, error) {
+	data := map[string]string{"code": code}
+	if cardID != "" {
+		data["card_id"] = cardID
+	}
+	result, err := api.post("/card/code/consume", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var consume ConsumeResult
+	if err := DecodeResult(result, &consume); err != nil {
+		return nil, err
+	}
+	return &consume, nil
+}
+
+// UnavailableCode 设置卡券 code 失效，reason 为失效理由
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) UnavailableCode(cardID, code, reason string) error {
+	data := map[string]string{"code": code}
+	if cardID != "" {
+		data["card_id"] = cardID
+	}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	_, err := api.post("/card/code/unavailable", data)
+	return err
+}
+
+// UpdateCode 更改卡券 code
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) UpdateCode(cardID, code, newCode string) error {
+	_, err := api.post("/card/code/update", map[string]string{
+		"card_id":  cardID,
+		"code":     code,
+		"new_code": newCode,
+	})
+	return err
+}
+
+// UserCard 用户已领取的卡券
+type UserCard struct {
+	CardID string `json:"card_id"`
+	Code   string `json:"code"`
+}
+
+// GetUserCardList 获取用户已领取的卡券，cardID 为空时返回全部卡券
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Managing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) GetUserCardList(openID, cardID string) ([]UserCard, error) {
+	data := map[string]string{"openid": openID}
+	if cardID != "" {
+		data["card_id"] = cardID
+	}
+	result, err := api.post("/card/user/getcardlist", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		CardList []UserCard `json:"card_list"`
+	}
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, err
+	}
+	return list.CardList, nil
+}
+
+// SetPayCell 设置买单功能，开通后用户可在卡券详情页使用微信支付买单
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Create_a_Coupon_Voucher_or_Card.html
+func (api *CardAPI) SetPayCell(cardID string, isOpen bool) error {
+	_, err := api.post("/card/paycell/set", map[string]interface{}{
+		"card_id": cardID,
+		"is_open": isOpen,
+	})
+	return err
+}
+
+// SetSelfConsumeCell 设置自助核销功能
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Create_a_Coupon_Voucher_or_Card.html
+func (api *CardAPI) SetSelfConsumeCell(cardID string, isOpen, needVerifyCode, needRemarkAmount bool) error {
+	_, err := api.post("/card/selfconsumecell/set", map[string]interface{}{
+		"card_id":            cardID,
+		"is_open":            isOpen,
+		"need_verify_cod":    needVerifyCode, // 官方接口字段名即为 need_verify_cod
+		"need_remark_amount": needRemarkAmount,
+	})
+	return err
+}
+
+// SetTestWhitelist 设置测试白名单，卡券审核通过前只有白名单中的用户可以领取
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Distributing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) SetTestWhitelist(openIDs, userNames []string) error {
+	data := map[string]interface{}{}
+	if len(openIDs) > 0 {
+		data["openid"] = openIDs
+	}
+	if len(userNames) > 0 {
+		data["username"] = userNames
+	}
+	_, err := api.post("/card/testwhitelist/set", data)
+	return err
+}
+
+// GetMpNewsHTML 获取卡券嵌入图文消息的 HTML 内容
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Distributing_Coupons_Vouchers_and_Cards.html
+func (api *CardAPI) GetMpNewsHTML(cardID string) (string, error) {
+	result, err := api.post("/card/mpnews/gethtml", map[string]string{"card_id": cardID})
+	if err != nil {
+		return "", err
+	}
+
+	if content, ok := result["content"].(string); ok {
+		return content, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestCardCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/card/create", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("access_token"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"card":{
+			"card_type":"CASH",
+			"cash":{
+				"least_cost":10000,
+				"reduce_cost":1000,
+				"base_info":{
+					"logo_url":"http://mmbiz.qpic.cn/logo","brand_name":"海底捞","code_type":"CODE_TYPE_QRCODE",
+					"title":"100元代金券","color":"Color010","notice":"请出示二维码","description":"不可与其他优惠同享",
+					"date_info":{"type":"DATE_TYPE_FIX_TERM","fixed_term":15},"sku":{"quantity":500000},"get_limit":3
+				},
+				"advanced_info":{"abstract":{"abstract":"微信餐厅推出多种新季菜品"}}
+			}
+		}}`, string(body))
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","card_id":"p1Pj9jr90_SQRaVqYI239Ka1erkI"}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	card := api.NewCashCard(api.CardBaseInfo{
+		LogoURL:     "http://mmbiz.qpic.cn/logo",
+		BrandName:   "海底捞",
+		CodeType:    api.CodeTypeQRCode,
+		Title:       "100元代金券",
+		Color:       "Color010",
+		Notice:      "请出示二维码",
+		Description: "不可与其他优惠同享",
+		DateInfo:    api.CardDateInfo{Type: api.DateTypeFixTerm, FixedTerm: 15},
+		SKU:         api.CardSKU{Quantity: 500000},
+		GetLimit:    3,
+	}, 10000, 1000)
+	card.AdvancedInfo = api.CardAdvancedInfo{"abstract": map[string]string{"abstract": "微信餐厅推出多种新季菜品"}}
+
+	cardID, err := client.Card.Create(card)
+	assert.NoError(t, err)
+	assert.Equal(t, "p1Pj9jr90_SQRaVqYI239Ka1erkI", cardID)
+}
+
+func TestCardCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/card/code/decrypt":
+			assert.Equal(t, "XXIzTtMqCxwOaawoE91+VJdsFmv7b8g0VZIZkqf4GWA60Fzpc8ksZ/5ZZ0DVkXdE", req["encrypt_code"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","code":"751234212312"}`))
+		case "/card/code/get":
+			assert.Equal(t, true, req["check_consume"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","card":{"card_id":"pbLatjk4T4Hx-QFQGL4zGQy27_Qg","begin_time":1457452800,"end_time":1463155199},"openid":"obLatjm43RA5C6QfMO5szKYnT3dM","can_consume":true,"user_card_status":"NORMAL"}`))
+		case "/card/code/consume":
+			assert.Equal(t, "751234212312", req["code"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","card":{"card_id":"pbLatjk4T4Hx-QFQGL4zGQy27_Qg"},"openid":"obLatjm43RA5C6QfMO5szKYnT3dM"}`))
+		case "/card/qrcode/create":
+			assert.Equal(t, "QR_MULTIPLE_CARD", req["action_name"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","ticket":"TICKET","expire_seconds":1800,"url":"http://weixin.qq.com/q/xxx","show_qrcode_url":"https://mp.weixin.qq.com/cgi-bin/showqrcode?ticket=TICKET"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	code, err := client.Card.DecryptCode("XXIzTtMqCxwOaawoE91+VJdsFmv7b8g0VZIZkqf4GWA60Fzpc8ksZ/5ZZ0DVkXdE")
+	assert.NoError(t, err)
+	assert.Equal(t, "751234212312", code)
+
+	cardCode, err := client.Card.GetCode("", code, true)
+	assert.NoError(t, err)
+	assert.True(t, cardCode.CanConsume)
+	assert.Equal(t, "NORMAL", cardCode.UserCardStatus)
+	assert.Equal(t, int64(1463155199), cardCode.Card.EndTime)
+
+	consumed, err := client.Card.ConsumeCode("", code)
+	assert.NoError(t, err)
+	assert.Equal(t, "obLatjm43RA5C6QfMO5szKYnT3dM", consumed.OpenID)
+
+	qrcode, err := client.Card.CreateQRCode(&api.CardQRCodeRequest{
+		ExpireSeconds: 1800,
+		CardList:      []api.CardQRCodeInfo{{CardID: "p1"}, {CardID: "p2"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "TICKET", qrcode.Ticket)
+
+	_, err = client.Card.CreateQRCode(&api.CardQRCodeRequest{})
+	assert.Error(t, err)
+}
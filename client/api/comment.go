@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// commentPageSize 拉取评论列表时每页的最大数量
+const commentPageSize = 50
+
+// CommentType 评论类型
+type CommentType int
+
+// 评论类型
+const (
+	CommentTypeAll      CommentType = 0 // 普通评论和精选评论
+	CommentTypeNormal   CommentType = 1 // 普通评论
+	CommentTypeElection CommentType = 2 // 精选评论
+)
+
+// CommentAPI 图文消息留言管理 API
+type CommentAPI struct {
+	*BaseAPI
+}
+
+// NewCommentAPI 创建图文消息留言管理 API
+func NewCommentAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+}) *CommentAPI {
+	return &CommentAPI{
+		BaseAPI: NewBaseAPI(client),
+	}
+}
+
+// CommentReply 作者回复
+type CommentReply struct {
+	Content    string `json:"content"`
+	CreateTime int64  `json:"create_time"`
+}
+
+// Comment 评论
+type Comment struct {
+	UserCommentID int64         `json:"user_comment_id"`
+	OpenID        string        `json:"openid"`
+	CreateTime    int64         `json:"create_time"`
+	Content       string        `json:"content"`
+	CommentType   CommentType   `json:"comment_type"` // 1 普通评论，2 精选评论
+	Reply         *CommentReply `json:"reply,omitempty"`
+}
+
+// CommentList 评论列表
+type CommentList struct {
+	Total   int       `json:"total"`
+	Comment []Comment `json:"comment"`
+}
+
+// commentData 构建评论接口的公共请求参数
+// msgDataID 为群发或发布返回的 msg_data_id，index 为图文中的文章序号（从 0 开始）
+func commentData(msgDataID int64, index int) map[string]interface{} {
+	return map[string]interface{}{
+		"msg_data_id": msgDataID,
+		"index":       index,
+	}
+}
+
+// Open 打开已群发文章评论
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) Open(msgDataID int64, index int) error {
+	_, err := api.Post("/comment/open", commentData(msgDataID, index))
+	return err
+}
+
+// Close 关闭已群发文章评论
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) Close(msgDataID int64, index int) error {
+	_, err := api.Post("/comment/close", commentData(msgDataID, index))
+	return err
+}
+
+// List 查看指定文章的评论数据
+// begin 为起始位置，count 取值 1 到 50
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) List(msgDataID int64, index, begin, count int, commentType CommentType) (*CommentList, error) {
+	return api.list(context.Background(), msgDataID, index, begin, count, commentType)
+}
+
+func (api *CommentAPI) list(ctx context.Context, msgDataID int64, index, begin, count int, commentType CommentType) (*CommentList, error) {
+	data := commentData(msgDataID, index)
+	data["begin"] = begin
+	data["count"] = count
+	data["type"] = commentType
+
+	result, err := api.PostContext(ctx, "/comment/list", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list CommentList
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// All 按 begin/count 分页遍历指定文章的全部评论
+// 遍历在出错或 ctx 取消时终止，错误通过第二个返回值传出
+func (api *CommentAPI) All(ctx context.Context, msgDataID int64, index int, commentType CommentType) iter.Seq2[Comment, error] {
+	return func(yield func(Comment, error) bool) {
+		for begin := 0; ; {
+			if err := ctx.Err(); err != nil {
+				yield(Comment{}, err)
+				return
+			}
+
+			list, err := api.list(ctx, msgDataID, index, begin, commentPageSize, commentType)
+			if err != nil {
+				yield(Comment{}, err)
+				return
+			}
+			for _, comment := range list.Comment {
+				if !yield(comment, nil) {
+					return
+				}
+			}
+
+			begin += len(list.Comment)
+			if len(list.Comment) == 0 || begin >= list.Total {
+				return
+			}
+		}
+	}
+}
+
+// commentActionData 构建针对单条评论的请求参数
+func commentActionData(msgDataID int64, index int, userCommentID int64) map[string]interface{} {
+	data := commentData(msgDataID, index)
+	data["user_comment_id"] = userCommentID
+	return data
+}
+
+// MarkElect 将评论标记精选
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) MarkElect(msgDataID int64, index int, userCommentID int64) error {
+	_, err := api.Post("/comment/markelect", commentActionData(msgDataID, index, userCommentID))
+	return err
+}
+
+// UnmarkElect 将评论取消精选
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) UnmarkElect(msgDataID int64, index int, userCommentID int64) error {
+	_, err := api.Post("/comment/unmarkelect", commentActionData(msgDataID, index, userCommentID))
+	return err
+}
+
+// Delete 删除评论
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) Delete(msgDataID int64, index int, userCommentID int64) error {
+	_, err := api.Post("/comment/delete", commentActionData(msgDataID, index, userCommentID))
+	return err
+}
+
+// AddReply 回复评论
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) AddReply(msgDataID int64, index int, userCommentID int64, content string) error {
+	data := commentActionData(msgDataID, index, userCommentID)
+	data["content"] = content
+	_, err := api.Post("/comment/reply/add", data)
+	return err
+}
+
+// DeleteReply 删除回复
+// https://developers.weixin.qq.com/doc/offiaccount/Comments_management/Image_Comments_Management_Interface.html
+func (api *CommentAPI) DeleteReply(msgDataID int64, index int, userCommentID int64) error {
+	_, err := api.Post("/comment/reply/delete", commentActionData(msgDataID, index, userCommentID))
+	return err
+}
@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestCommentAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/comment/list", r.URL.Path)
+		var req struct {
+			MsgDataID int64 `json:"msg_data_id"`
+			Begin     int   `json:"begin"`
+			Count     int   `json:"count"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, int64(2247483673), req.MsgDataID)
+
+		comments := make([]string, 0, req.Count)
+		for i := req.Begin; i < 60 && len(comments) < req.Count; i++ {
+			comments = append(comments, fmt.Sprintf(`{"user_comment_id":%d,"openid":"o%d","content":"c"}`, i, i))
+		}
+		fmt.Fprintf(w, `{"errcode":0,"total":60,"comment":[%s]}`, strings.Join(comments, ","))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var ids []int64
+	for comment, err := range client.Comment.All(context.Background(), 2247483673, 0, api.CommentTypeAll) {
+		assert.NoError(t, err)
+		ids = append(ids, comment.UserCommentID)
+	}
+	assert.Len(t, ids, 60)
+	assert.Equal(t, int64(59), ids[59])
+
+	// 提前结束遍历
+	count := 0
+	for range client.Comment.All(context.Background(), 2247483673, 0, api.CommentTypeAll) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestCommentAll_Canceled(t *testing.T) {
+	client := testclient.New("http://127.0.0.1:0", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range client.Comment.All(ctx, 1, 0, api.CommentTypeAll) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
+
+func TestCommentAll_CanceledDuringRequest(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	// ctx 会传递到正在进行的 HTTP 请求
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	var errs []error
+	for _, err := range client.Comment.All(ctx, 1, 0, api.CommentTypeAll) {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], context.Canceled)
+	}
+}
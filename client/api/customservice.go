@@ -1,29 +1,31 @@
 package api
 
 import (
+	"context"
 	"crypto/md5"
-	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"os"
-
-	"github.com/wechatpy/wechatgo"
+	"path/filepath"
 )
 
 // CustomServiceAPI 客服消息管理 API
 type CustomServiceAPI struct {
 	*BaseAPI
+	uploader Uploader
 }
 
 // NewCustomServiceAPI 创建客服消息 API
 func NewCustomServiceAPI(client interface {
 	Get(url string, params map[string]string) (map[string]interface{}, error)
 	Post(url string, data interface{}) (map[string]interface{}, error)
-	Upload(url string, fileName string, file io.Reader) (map[string]interface{}, error)
+	UploadStream(url string, params map[string]string, file *UploadFile) (map[string]interface{}, error)
 	GetAccessToken() (string, error)
 }) *CustomServiceAPI {
 	return &CustomServiceAPI{
-		BaseAPI: NewBaseAPI(client),
+		BaseAPI:  NewBaseAPI(client),
+		uploader: client,
 	}
 }
 
@@ -105,7 +107,7 @@ func (api *CustomServiceAPI) UploadHeadImg(account, filePath string) (map[string
 	}
 	defer file.Close()
 
-	return api.uploadHeadImg(account, filePath, file)
+	return api.uploadHeadImg(account, filepath.Base(filePath), file)
 }
 
 // UploadHeadImgReader 设置客服账号的头像（从Reader）
@@ -115,37 +117,14 @@ func (api *CustomServiceAPI) UploadHeadImgReader(account, fileName string, reade
 
 // uploadHeadImg 内部上传头像方法
 func (api *CustomServiceAPI) uploadHeadImg(account, fileName string, reader io.Reader) (map[string]interface{}, error) {
-	token, err := api.GetAccessToken()
-	if err != nil {
-		return nil, err
-	}
-
-	url := fmt.Sprintf("https://api.weixin.qq.com/customservice/kfaccount/uploadheadimg?access_token=%s&kf_account=%s", token, account)
-
-	resp, err := api.httpClient.Post(url)
+	// 头像图片必须是 jpg 格式
+	file, err := newUploadFile(fileName, reader, &MediaLimit{Formats: []string{"jpg", "jpeg"}})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	// 检查错误
-	if errcode, ok := result["errcode"]; ok {
-		errcodeInt := int(errcode.(float64))
-		if errcodeInt != 0 {
-			errmsg := ""
-			if msg, ok := result["errmsg"]; ok {
-				errmsg = msg.(string)
-			}
-			return nil, wechatgo.NewError(errcodeInt, errmsg)
-		}
-	}
 
-	return result, nil
+	return api.uploader.UploadStream("https://api.weixin.qq.com/customservice/kfaccount/uploadheadimg",
+		map[string]string{"kf_account": account}, file)
 }
 
 // OnlineAccount 在线客服信息
@@ -242,11 +221,68 @@ func (api *CustomServiceAPI) GetWaitCase() (map[string]interface{}, error) {
 // GetRecords 获取客服聊天记录
 // https://developers.weixin.qq.com/doc/offiaccount/Customer_Service/Obtain_chat_transcript.html
 func (api *CustomServiceAPI) GetRecords(startTime, endTime int64, msgID, number int) (map[string]interface{}, error) {
+	return api.getRecords(context.Background(), startTime, endTime, msgID, number)
+}
+
+func (api *CustomServiceAPI) getRecords(ctx context.Context, startTime, endTime int64, msgID, number int) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"starttime": startTime,
 		"endtime":   endTime,
 		"msgid":     msgID,
 		"number":    number,
 	}
-	return api.Post("/customservice/msgrecord/getmsglist", data)
+	return api.PostContext(ctx, "/customservice/msgrecord/getmsglist", data)
+}
+
+// recordPageSize 拉取聊天记录时每页的最大数量
+const recordPageSize = 10000
+
+// Record 客服聊天记录
+type Record struct {
+	OpenID   string `json:"openid"`
+	OperCode int    `json:"opercode"`
+	Text     string `json:"text"`
+	Time     int64  `json:"time"`
+	Worker   string `json:"worker"`
+}
+
+// recordPage 一页客服聊天记录，MsgID 为下一页的起始消息 id
+type recordPage struct {
+	RecordList []Record `json:"recordlist"`
+	Number     int      `json:"number"`
+	MsgID      int      `json:"msgid"`
+}
+
+// AllRecords 遍历时间范围内的全部客服聊天记录，按 msgid 游标逐页拉取
+// 起止时间相差不能超过 24 小时，出错或 ctx 被取消时产生一个错误并结束遍历
+func (api *CustomServiceAPI) AllRecords(ctx context.Context, startTime, endTime int64) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		for msgID := 1; ; {
+			if err := ctx.Err(); err != nil {
+				yield(Record{}, err)
+				return
+			}
+
+			result, err := api.getRecords(ctx, startTime, endTime, msgID, recordPageSize)
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+			var page recordPage
+			if err := DecodeResult(result, &page); err != nil {
+				yield(Record{}, err)
+				return
+			}
+			for _, record := range page.RecordList {
+				if !yield(record, nil) {
+					return
+				}
+			}
+
+			if len(page.RecordList) < recordPageSize || page.MsgID <= msgID {
+				return
+			}
+			msgID = page.MsgID
+		}
+	}
 }
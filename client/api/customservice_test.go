@@ -0,0 +1,50 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestCustomServiceAllRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/customservice/msgrecord/getmsglist", r.URL.Path)
+		var req struct {
+			MsgID  int `json:"msgid"`
+			Number int `json:"number"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, 10000, req.Number)
+
+		if req.MsgID == 1 {
+			records := make([]string, req.Number)
+			for i := range records {
+				records[i] = `{"openid":"o1","opercode":2002,"text":"hello","time":1400000000,"worker":"kf@test"}`
+			}
+			fmt.Fprintf(w, `{"recordlist":[%s],"number":%d,"msgid":10001}`, strings.Join(records, ","), req.Number)
+			return
+		}
+		assert.Equal(t, 10001, req.MsgID)
+		w.Write([]byte(`{"recordlist":[{"openid":"o2","opercode":2003,"text":"bye","time":1400000001,"worker":"kf@test"}],"number":1,"msgid":10002}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var records []api.Record
+	for record, err := range client.CustomService.AllRecords(context.Background(), 1400000000, 1400080000) {
+		assert.NoError(t, err)
+		records = append(records, record)
+	}
+	assert.Len(t, records, 10001)
+	assert.Equal(t, "o2", records[10000].OpenID)
+	assert.Equal(t, 2003, records[10000].OperCode)
+}
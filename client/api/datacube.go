@@ -8,6 +8,7 @@ import (
 // DataCubeAPI 数据统计 API
 type DataCubeAPI struct {
 	*BaseAPI
+	rootURL func(path string) string
 }
 
 // NewDataCubeAPI 创建数据统计 API
@@ -16,9 +17,15 @@ func NewDataCubeAPI(client interface {
 	Post(url string, data interface{}) (map[string]interface{}, error)
 	GetAccessToken() (string, error)
 }) *DataCubeAPI {
-	return &DataCubeAPI{
+	api := &DataCubeAPI{
 		BaseAPI: NewBaseAPI(client),
+		rootURL: func(path string) string { return path },
 	}
+	// 数据统计接口位于 https://api.weixin.qq.com/datacube 下，不在 /cgi-bin 路径下
+	if c, ok := client.(interface{ RootURL(path string) string }); ok {
+		api.rootURL = c.RootURL
+	}
+	return api
 }
 
 // toDateStr 将日期转换为字符串
@@ -45,7 +52,7 @@ func (api *DataCubeAPI) GetUserSummary(beginDate, endDate interface{}) ([]map[st
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getusersummary", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getusersummary"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -77,7 +84,7 @@ func (api *DataCubeAPI) GetUserCumulate(beginDate, endDate interface{}) ([]map[s
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getusercumulate", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getusercumulate"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -109,7 +116,7 @@ func (api *DataCubeAPI) GetInterfaceSummary(beginDate, endDate interface{}) ([]m
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getinterfacesummary", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getinterfacesummary"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -141,7 +148,7 @@ func (api *DataCubeAPI) GetInterfaceSummaryHour(beginDate, endDate interface{})
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getinterfacesummaryhour", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getinterfacesummaryhour"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -173,7 +180,7 @@ func (api *DataCubeAPI) GetArticleSummary(beginDate, endDate interface{}) ([]map
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getarticlesummary", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getarticlesummary"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -205,7 +212,7 @@ func (api *DataCubeAPI) GetArticleTotal(beginDate, endDate interface{}) ([]map[s
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getarticletotal", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getarticletotal"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -237,7 +244,7 @@ func (api *DataCubeAPI) GetUserRead(beginDate, endDate interface{}) ([]map[strin
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getuserread", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getuserread"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -269,7 +276,7 @@ func (api *DataCubeAPI) GetUserReadHour(beginDate, endDate interface{}) ([]map[s
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getuserreadhour", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getuserreadhour"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -301,7 +308,7 @@ func (api *DataCubeAPI) GetUserShare(beginDate, endDate interface{}) ([]map[stri
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getusershare", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getusershare"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -333,7 +340,7 @@ func (api *DataCubeAPI) GetUserShareHour(beginDate, endDate interface{}) ([]map[
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getusersharehour", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getusersharehour"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -365,7 +372,7 @@ func (api *DataCubeAPI) GetUpstreamMsg(beginDate, endDate interface{}) ([]map[st
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsg", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsg"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -397,7 +404,7 @@ func (api *DataCubeAPI) GetUpstreamMsgHour(beginDate, endDate interface{}) ([]ma
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsghour", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsghour"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -429,7 +436,7 @@ func (api *DataCubeAPI) GetUpstreamMsgWeek(beginDate, endDate interface{}) ([]ma
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsgweek", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsgweek"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -461,7 +468,7 @@ func (api *DataCubeAPI) GetUpstreamMsgMonth(beginDate, endDate interface{}) ([]m
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsgmonth", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsgmonth"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -493,7 +500,7 @@ func (api *DataCubeAPI) GetUpstreamMsgDist(beginDate, endDate interface{}) ([]ma
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsgdist", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsgdist"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -525,7 +532,7 @@ func (api *DataCubeAPI) GetUpstreamMsgDistWeek(beginDate, endDate interface{}) (
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsgdistweek", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsgdistweek"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -557,7 +564,7 @@ func (api *DataCubeAPI) GetUpstreamMsgDistMonth(beginDate, endDate interface{})
 		return nil, err
 	}
 
-	result, err := api.Post("/datacube/getupstreammsgdistmonth", map[string]interface{}{
+	result, err := api.Post(api.rootURL("/datacube/getupstreammsgdistmonth"), map[string]interface{}{
 		"begin_date": beginDateStr,
 		"end_date":   endDateStr,
 	})
@@ -0,0 +1,503 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// dateLayout 数据统计接口使用的日期格式
+const dateLayout = "2006-01-02"
+
+// DateRange 日期范围，起止日期均包含在内
+type DateRange struct {
+	Begin time.Time
+	End   time.Time
+}
+
+// String 返回 "2006-01-02~2006-01-02" 格式的日期范围
+func (r DateRange) String() string {
+	return r.Begin.Format(dateLayout) + "~" + r.End.Format(dateLayout)
+}
+
+// RangeChunkError 按日期范围拉取报表时单个时间段的错误
+type RangeChunkError struct {
+	Index int       // 时间段序号，从 0 开始
+	Range DateRange // 时间段
+	Err   error
+}
+
+// Error 实现 error 接口
+func (e *RangeChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (%s): %v", e.Index, e.Range, e.Err)
+}
+
+// Unwrap 返回时间段的原始错误
+func (e *RangeChunkError) Unwrap() error {
+	return e.Err
+}
+
+// RangeError 按日期范围拉取报表的部分失败，Chunks 按时间段顺序排列
+// 未列出的时间段均已成功，ctx 取消后未执行的时间段也会以 ctx 的错误列出
+type RangeError struct {
+	Total  int // 时间段总数
+	Chunks []*RangeChunkError
+}
+
+// Error 实现 error 接口
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%d of %d date ranges failed, first error: %v", len(e.Chunks), e.Total, e.Chunks[0])
+}
+
+// Unwrap 返回各时间段的错误，便于使用 errors.Is/As 判断
+func (e *RangeError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		errs[i] = chunk
+	}
+	return errs
+}
+
+// SplitDateRange 将 [begin, end] 按最多 maxDays 天切分为多个连续的日期范围
+// 时间部分会被忽略，begin 晚于 end 时返回错误
+func SplitDateRange(begin, end time.Time, maxDays int) ([]DateRange, error) {
+	if maxDays < 1 {
+		return nil, fmt.Errorf("invalid max days: %d", maxDays)
+	}
+	begin = truncateDate(begin)
+	end = truncateDate(end)
+	if begin.After(end) {
+		return nil, fmt.Errorf("begin date %s is after end date %s", begin.Format(dateLayout), end.Format(dateLayout))
+	}
+
+	var ranges []DateRange
+	for start := begin; !start.After(end); start = start.AddDate(0, 0, maxDays) {
+		stop := start.AddDate(0, 0, maxDays-1)
+		if stop.After(end) {
+			stop = end
+		}
+		ranges = append(ranges, DateRange{Begin: start, End: stop})
+	}
+	return ranges, nil
+}
+
+// truncateDate 去掉时间部分，保留原时区
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// fetchRange 将日期范围按接口允许的最大跨度切分后并发拉取，按日期顺序合并结果
+// 部分时间段失败时返回其余时间段的数据和 *RangeError
+func fetchRange[T any](ctx context.Context, api *DataCubeAPI, endpoint string, maxDays int, begin, end time.Time, opts []BulkOption) ([]T, error) {
+	ranges, err := SplitDateRange(begin, end, maxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]T, len(ranges))
+	errs := runParallel(ctx, len(ranges), opts, func(i int) error {
+		result, err := api.PostContext(ctx, api.rootURL(endpoint), map[string]interface{}{
+			"begin_date": ranges[i].Begin.Format(dateLayout),
+			"end_date":   ranges[i].End.Format(dateLayout),
+		})
+		if err != nil {
+			return err
+		}
+		var page struct {
+			List []T `json:"list"`
+		}
+		if err := DecodeResult(result, &page); err != nil {
+			return err
+		}
+		results[i] = page.List
+		return nil
+	})
+	var rows []T
+	for _, list := range results {
+		rows = append(rows, list...)
+	}
+
+	var failed []*RangeChunkError
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &RangeChunkError{Index: i, Range: ranges[i], Err: fmt.Errorf("%s: %w", endpoint, err)})
+		}
+	}
+	if len(failed) > 0 {
+		return rows, &RangeError{Total: len(ranges), Chunks: failed}
+	}
+	return rows, nil
+}
+
+// CSVRow 可导出为 CSV 的报表行
+type CSVRow interface {
+	CSVHeader() []string
+	CSVRecord() []string
+}
+
+// WriteCSV 将报表行写入 CSV，第一行为表头
+func WriteCSV[T CSVRow](w io.Writer, rows []T) error {
+	cw := csv.NewWriter(w)
+	var zero T
+	if err := cw.Write(zero.CSVHeader()); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := cw.Write(row.CSVRecord()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// itoa 将整数字段格式化为 CSV 字段
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+// UserSummaryRow 用户增减数据
+type UserSummaryRow struct {
+	RefDate    string `json:"ref_date"`
+	UserSource int    `json:"user_source"`
+	NewUser    int    `json:"new_user"`
+	CancelUser int    `json:"cancel_user"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (UserSummaryRow) CSVHeader() []string {
+	return []string{"ref_date", "user_source", "new_user", "cancel_user"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r UserSummaryRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.UserSource), itoa(r.NewUser), itoa(r.CancelUser)}
+}
+
+// UserCumulateRow 累计用户数据
+type UserCumulateRow struct {
+	RefDate      string `json:"ref_date"`
+	CumulateUser int    `json:"cumulate_user"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (UserCumulateRow) CSVHeader() []string {
+	return []string{"ref_date", "cumulate_user"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r UserCumulateRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.CumulateUser)}
+}
+
+// InterfaceSummaryRow 接口分析数据，RefHour 仅分时数据有效（如 1500 表示 15 点）
+type InterfaceSummaryRow struct {
+	RefDate       string `json:"ref_date"`
+	RefHour       int    `json:"ref_hour"`
+	CallbackCount int    `json:"callback_count"`
+	FailCount     int    `json:"fail_count"`
+	TotalTimeCost int    `json:"total_time_cost"`
+	MaxTimeCost   int    `json:"max_time_cost"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (InterfaceSummaryRow) CSVHeader() []string {
+	return []string{"ref_date", "ref_hour", "callback_count", "fail_count", "total_time_cost", "max_time_cost"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r InterfaceSummaryRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.RefHour), itoa(r.CallbackCount), itoa(r.FailCount), itoa(r.TotalTimeCost), itoa(r.MaxTimeCost)}
+}
+
+// ArticleSummaryRow 图文群发每日数据
+type ArticleSummaryRow struct {
+	RefDate          string `json:"ref_date"`
+	MsgID            string `json:"msgid"`
+	Title            string `json:"title"`
+	IntPageReadUser  int    `json:"int_page_read_user"`
+	IntPageReadCount int    `json:"int_page_read_count"`
+	OriPageReadUser  int    `json:"ori_page_read_user"`
+	OriPageReadCount int    `json:"ori_page_read_count"`
+	ShareUser        int    `json:"share_user"`
+	ShareCount       int    `json:"share_count"`
+	AddToFavUser     int    `json:"add_to_fav_user"`
+	AddToFavCount    int    `json:"add_to_fav_count"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (ArticleSummaryRow) CSVHeader() []string {
+	return []string{"ref_date", "msgid", "title", "int_page_read_user", "int_page_read_count",
+		"ori_page_read_user", "ori_page_read_count", "share_user", "share_count", "add_to_fav_user", "add_to_fav_count"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r ArticleSummaryRow) CSVRecord() []string {
+	return []string{r.RefDate, r.MsgID, r.Title, itoa(r.IntPageReadUser), itoa(r.IntPageReadCount),
+		itoa(r.OriPageReadUser), itoa(r.OriPageReadCount), itoa(r.ShareUser), itoa(r.ShareCount), itoa(r.AddToFavUser), itoa(r.AddToFavCount)}
+}
+
+// ArticleTotalDetail 图文群发总数据中某一天的累计数据
+type ArticleTotalDetail struct {
+	StatDate                    string `json:"stat_date"`
+	TargetUser                  int    `json:"target_user"`
+	IntPageReadUser             int    `json:"int_page_read_user"`
+	IntPageReadCount            int    `json:"int_page_read_count"`
+	OriPageReadUser             int    `json:"ori_page_read_user"`
+	OriPageReadCount            int    `json:"ori_page_read_count"`
+	ShareUser                   int    `json:"share_user"`
+	ShareCount                  int    `json:"share_count"`
+	AddToFavUser                int    `json:"add_to_fav_user"`
+	AddToFavCount               int    `json:"add_to_fav_count"`
+	IntPageFromSessionReadUser  int    `json:"int_page_from_session_read_user"`
+	IntPageFromSessionReadCount int    `json:"int_page_from_session_read_count"`
+	IntPageFromHistMsgReadUser  int    `json:"int_page_from_hist_msg_read_user"`
+	IntPageFromHistMsgReadCount int    `json:"int_page_from_hist_msg_read_count"`
+	IntPageFromFeedReadUser     int    `json:"int_page_from_feed_read_user"`
+	IntPageFromFeedReadCount    int    `json:"int_page_from_feed_read_count"`
+	IntPageFromFriendsReadUser  int    `json:"int_page_from_friends_read_user"`
+	IntPageFromFriendsReadCount int    `json:"int_page_from_friends_read_count"`
+	IntPageFromOtherReadUser    int    `json:"int_page_from_other_read_user"`
+	IntPageFromOtherReadCount   int    `json:"int_page_from_other_read_count"`
+	FeedShareFromSessionUser    int    `json:"feed_share_from_session_user"`
+	FeedShareFromSessionCount   int    `json:"feed_share_from_session_cnt"`
+	FeedShareFromFeedUser       int    `json:"feed_share_from_feed_user"`
+	FeedShareFromFeedCount      int    `json:"feed_share_from_feed_cnt"`
+	FeedShareFromOtherUser      int    `json:"feed_share_from_other_user"`
+	FeedShareFromOtherCount     int    `json:"feed_share_from_other_cnt"`
+}
+
+// articleTotal 接口返回的图文群发总数据
+type articleTotal struct {
+	RefDate string               `json:"ref_date"`
+	MsgID   string               `json:"msgid"`
+	Title   string               `json:"title"`
+	Details []ArticleTotalDetail `json:"details"`
+}
+
+// ArticleTotalRow 图文群发总数据，每篇图文的每个统计日为一行
+type ArticleTotalRow struct {
+	RefDate string
+	MsgID   string
+	Title   string
+	ArticleTotalDetail
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (ArticleTotalRow) CSVHeader() []string {
+	return []string{"ref_date", "msgid", "title", "stat_date", "target_user",
+		"int_page_read_user", "int_page_read_count", "ori_page_read_user", "ori_page_read_count",
+		"share_user", "share_count", "add_to_fav_user", "add_to_fav_count",
+		"int_page_from_session_read_user", "int_page_from_session_read_count",
+		"int_page_from_hist_msg_read_user", "int_page_from_hist_msg_read_count",
+		"int_page_from_feed_read_user", "int_page_from_feed_read_count",
+		"int_page_from_friends_read_user", "int_page_from_friends_read_count",
+		"int_page_from_other_read_user", "int_page_from_other_read_count",
+		"feed_share_from_session_user", "feed_share_from_session_cnt",
+		"feed_share_from_feed_user", "feed_share_from_feed_cnt",
+		"feed_share_from_other_user", "feed_share_from_other_cnt"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r ArticleTotalRow) CSVRecord() []string {
+	return []string{r.RefDate, r.MsgID, r.Title, r.StatDate, itoa(r.TargetUser),
+		itoa(r.IntPageReadUser), itoa(r.IntPageReadCount), itoa(r.OriPageReadUser), itoa(r.OriPageReadCount),
+		itoa(r.ShareUser), itoa(r.ShareCount), itoa(r.AddToFavUser), itoa(r.AddToFavCount),
+		itoa(r.IntPageFromSessionReadUser), itoa(r.IntPageFromSessionReadCount),
+		itoa(r.IntPageFromHistMsgReadUser), itoa(r.IntPageFromHistMsgReadCount),
+		itoa(r.IntPageFromFeedReadUser), itoa(r.IntPageFromFeedReadCount),
+		itoa(r.IntPageFromFriendsReadUser), itoa(r.IntPageFromFriendsReadCount),
+		itoa(r.IntPageFromOtherReadUser), itoa(r.IntPageFromOtherReadCount),
+		itoa(r.FeedShareFromSessionUser), itoa(r.FeedShareFromSessionCount),
+		itoa(r.FeedShareFromFeedUser), itoa(r.FeedShareFromFeedCount),
+		itoa(r.FeedShareFromOtherUser), itoa(r.FeedShareFromOtherCount)}
+}
+
+// UserReadRow 图文统计数据，RefHour 仅分时数据有效
+type UserReadRow struct {
+	RefDate          string `json:"ref_date"`
+	RefHour          int    `json:"ref_hour"`
+	UserSource       int    `json:"user_source"`
+	IntPageReadUser  int    `json:"int_page_read_user"`
+	IntPageReadCount int    `json:"int_page_read_count"`
+	OriPageReadUser  int    `json:"ori_page_read_user"`
+	OriPageReadCount int    `json:"ori_page_read_count"`
+	ShareUser        int    `json:"share_user"`
+	ShareCount       int    `json:"share_count"`
+	AddToFavUser     int    `json:"add_to_fav_user"`
+	AddToFavCount    int    `json:"add_to_fav_count"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (UserReadRow) CSVHeader() []string {
+	return []string{"ref_date", "ref_hour", "user_source", "int_page_read_user", "int_page_read_count",
+		"ori_page_read_user", "ori_page_read_count", "share_user", "share_count", "add_to_fav_user", "add_to_fav_count"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r UserReadRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.RefHour), itoa(r.UserSource), itoa(r.IntPageReadUser), itoa(r.IntPageReadCount),
+		itoa(r.OriPageReadUser), itoa(r.OriPageReadCount), itoa(r.ShareUser), itoa(r.ShareCount), itoa(r.AddToFavUser), itoa(r.AddToFavCount)}
+}
+
+// UserShareRow 图文分享转发数据，RefHour 仅分时数据有效
+type UserShareRow struct {
+	RefDate    string `json:"ref_date"`
+	RefHour    int    `json:"ref_hour"`
+	ShareScene int    `json:"share_scene"`
+	ShareCount int    `json:"share_count"`
+	ShareUser  int    `json:"share_user"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (UserShareRow) CSVHeader() []string {
+	return []string{"ref_date", "ref_hour", "share_scene", "share_count", "share_user"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r UserShareRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.RefHour), itoa(r.ShareScene), itoa(r.ShareCount), itoa(r.ShareUser)}
+}
+
+// UpstreamMsgRow 消息发送概况数据，RefHour 仅分时数据有效
+type UpstreamMsgRow struct {
+	RefDate  string `json:"ref_date"`
+	RefHour  int    `json:"ref_hour"`
+	MsgType  int    `json:"msg_type"`
+	MsgUser  int    `json:"msg_user"`
+	MsgCount int    `json:"msg_count"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (UpstreamMsgRow) CSVHeader() []string {
+	return []string{"ref_date", "ref_hour", "msg_type", "msg_user", "msg_count"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r UpstreamMsgRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.RefHour), itoa(r.MsgType), itoa(r.MsgUser), itoa(r.MsgCount)}
+}
+
+// UpstreamMsgDistRow 消息发送分布数据
+type UpstreamMsgDistRow struct {
+	RefDate       string `json:"ref_date"`
+	CountInterval int    `json:"count_interval"`
+	MsgUser       int    `json:"msg_user"`
+}
+
+// CSVHeader 实现 CSVRow 接口
+func (UpstreamMsgDistRow) CSVHeader() []string {
+	return []string{"ref_date", "count_interval", "msg_user"}
+}
+
+// CSVRecord 实现 CSVRow 接口
+func (r UpstreamMsgDistRow) CSVRecord() []string {
+	return []string{r.RefDate, itoa(r.CountInterval), itoa(r.MsgUser)}
+}
+
+// 以下方法接受任意日期范围，按各接口允许的最大跨度切分后并发请求
+// 并发数可通过 WithConcurrency 设置，请求速率受客户端限流器控制
+// 部分时间段失败时返回已获取的数据和 *RangeError
+
+// UserSummaryRange 获取任意日期范围的用户增减数据（单次最大跨度 7 天）
+func (api *DataCubeAPI) UserSummaryRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UserSummaryRow, error) {
+	return fetchRange[UserSummaryRow](ctx, api, "/datacube/getusersummary", 7, begin, end, opts)
+}
+
+// UserCumulateRange 获取任意日期范围的累计用户数据（单次最大跨度 7 天）
+func (api *DataCubeAPI) UserCumulateRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UserCumulateRow, error) {
+	return fetchRange[UserCumulateRow](ctx, api, "/datacube/getusercumulate", 7, begin, end, opts)
+}
+
+// InterfaceSummaryRange 获取任意日期范围的接口分析数据（单次最大跨度 30 天）
+func (api *DataCubeAPI) InterfaceSummaryRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]InterfaceSummaryRow, error) {
+	return fetchRange[InterfaceSummaryRow](ctx, api, "/datacube/getinterfacesummary", 30, begin, end, opts)
+}
+
+// InterfaceSummaryHourRange 获取任意日期范围的接口分析分时数据（单次最大跨度 1 天）
+func (api *DataCubeAPI) InterfaceSummaryHourRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]InterfaceSummaryRow, error) {
+	return fetchRange[InterfaceSummaryRow](ctx, api, "/datacube/getinterfacesummaryhour", 1, begin, end, opts)
+}
+
+// ArticleSummaryRange 获取任意日期范围的图文群发每日数据（单次最大跨度 1 天）
+func (api *DataCubeAPI) ArticleSummaryRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]ArticleSummaryRow, error) {
+	return fetchRange[ArticleSummaryRow](ctx, api, "/datacube/getarticlesummary", 1, begin, end, opts)
+}
+
+// ArticleTotalRange 获取任意日期范围的图文群发总数据（单次最大跨度 1 天）
+// 每篇图文的每个统计日展开为一行
+func (api *DataCubeAPI) ArticleTotalRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]ArticleTotalRow, error) {
+	totals, err := fetchRange[articleTotal](ctx, api, "/datacube/getarticletotal", 1, begin, end, opts)
+
+	var rows []ArticleTotalRow
+	for _, total := range totals {
+		for _, detail := range total.Details {
+			rows = append(rows, ArticleTotalRow{
+				RefDate:            total.RefDate,
+				MsgID:              total.MsgID,
+				Title:              total.Title,
+				ArticleTotalDetail: detail,
+			})
+		}
+	}
+	return rows, err
+}
+
+// UserReadRange 获取任意日期范围的图文统计数据（单次最大跨度 3 天）
+func (api *DataCubeAPI) UserReadRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UserReadRow, error) {
+	return fetchRange[UserReadRow](ctx, api, "/datacube/getuserread", 3, begin, end, opts)
+}
+
+// UserReadHourRange 获取任意日期范围的图文分时统计数据（单次最大跨度 1 天）
+func (api *DataCubeAPI) UserReadHourRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UserReadRow, error) {
+	return fetchRange[UserReadRow](ctx, api, "/datacube/getuserreadhour", 1, begin, end, opts)
+}
+
+// UserShareRange 获取任意日期范围的图文分享转发数据（单次最大跨度 7 天）
+func (api *DataCubeAPI) UserShareRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UserShareRow, error) {
+	return fetchRange[UserShareRow](ctx, api, "/datacube/getusershare", 7, begin, end, opts)
+}
+
+// UserShareHourRange 获取任意日期范围的图文分享转发分时数据（单次最大跨度 1 天）
+func (api *DataCubeAPI) UserShareHourRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UserShareRow, error) {
+	return fetchRange[UserShareRow](ctx, api, "/datacube/getusersharehour", 1, begin, end, opts)
+}
+
+// UpstreamMsgRange 获取任意日期范围的消息发送概况数据（单次最大跨度 7 天）
+func (api *DataCubeAPI) UpstreamMsgRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgRow, error) {
+	return fetchRange[UpstreamMsgRow](ctx, api, "/datacube/getupstreammsg", 7, begin, end, opts)
+}
+
+// UpstreamMsgHourRange 获取任意日期范围的消息发送分时数据（单次最大跨度 1 天）
+func (api *DataCubeAPI) UpstreamMsgHourRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgRow, error) {
+	return fetchRange[UpstreamMsgRow](ctx, api, "/datacube/getupstreammsghour", 1, begin, end, opts)
+}
+
+// UpstreamMsgWeekRange 获取任意日期范围的消息发送周数据（单次最大跨度 30 天）
+func (api *DataCubeAPI) UpstreamMsgWeekRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgRow, error) {
+	return fetchRange[UpstreamMsgRow](ctx, api, "/datacube/getupstreammsgweek", 30, begin, end, opts)
+}
+
+// UpstreamMsgMonthRange 获取任意日期范围的消息发送月数据（单次最大跨度 30 天）
+func (api *DataCubeAPI) UpstreamMsgMonthRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgRow, error) {
+	return fetchRange[UpstreamMsgRow](ctx, api, "/datacube/getupstreammsgmonth", 30, begin, end, opts)
+}
+
+// UpstreamMsgDistRange 获取任意日期范围的消息发送分布数据（单次最大跨度 15 天）
+func (api *DataCubeAPI) UpstreamMsgDistRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgDistRow, error) {
+	return fetchRange[UpstreamMsgDistRow](ctx, api, "/datacube/getupstreammsgdist", 15, begin, end, opts)
+}
+
+// UpstreamMsgDistWeekRange 获取任意日期范围的消息发送分布周数据（单次最大跨度 30 天）
+func (api *DataCubeAPI) UpstreamMsgDistWeekRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgDistRow, error) {
+	return fetchRange[UpstreamMsgDistRow](ctx, api, "/datacube/getupstreammsgdistweek", 30, begin, end, opts)
+}
+
+// UpstreamMsgDistMonthRange 获取任意日期范围的消息发送分布月数据（单次最大跨度 30 天）
+func (api *DataCubeAPI) UpstreamMsgDistMonthRange(ctx context.Context, begin, end time.Time, opts ...BulkOption) ([]UpstreamMsgDistRow, error) {
+	return fetchRange[UpstreamMsgDistRow](ctx, api, "/datacube/getupstreammsgdistmonth", 30, begin, end, opts)
+}
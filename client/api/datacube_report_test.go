@@ -0,0 +1,133 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestSplitDateRange(t *testing.T) {
+	begin := time.Date(2024, 1, 1, 15, 0, 0, 0, time.Local)
+	end := time.Date(2024, 1, 17, 0, 0, 0, 0, time.Local)
+
+	ranges, err := api.SplitDateRange(begin, end, 7)
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 3)
+	assert.Equal(t, "2024-01-01~2024-01-07", ranges[0].String())
+	assert.Equal(t, "2024-01-08~2024-01-14", ranges[1].String())
+	assert.Equal(t, "2024-01-15~2024-01-17", ranges[2].String())
+
+	_, err = api.SplitDateRange(end, begin, 7)
+	assert.Error(t, err)
+}
+
+func TestDataCubeUserSummaryRange(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/datacube/getusersummary", r.URL.Path)
+		atomic.AddInt32(&calls, 1)
+		var req struct {
+			BeginDate string `json:"begin_date"`
+			EndDate   string `json:"end_date"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		begin, _ := time.Parse("2006-01-02", req.BeginDate)
+		end, _ := time.Parse("2006-01-02", req.EndDate)
+		assert.LessOrEqual(t, end.Sub(begin), 6*24*time.Hour)
+
+		var rows []string
+		for d := begin; !d.After(end); d = d.AddDate(0, 0, 1) {
+			rows = append(rows, fmt.Sprintf(`{"ref_date":"%s","user_source":0,"new_user":%d,"cancel_user":1}`, d.Format("2006-01-02"), d.Day()))
+		}
+		fmt.Fprintf(w, `{"list":[%s]}`, strings.Join(rows, ","))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	rows, err := client.DataCube.UserSummaryRange(context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	assert.Len(t, rows, 31)
+	for i, row := range rows {
+		assert.Equal(t, i+1, row.NewUser)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.WriteCSV(&buf, rows[:2]))
+	assert.Equal(t, "ref_date,user_source,new_user,cancel_user\n2024-01-01,0,1,1\n2024-01-02,0,2,1\n", buf.String())
+}
+
+func TestDataCubeArticleTotalRange_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			BeginDate string `json:"begin_date"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.BeginDate == "2024-01-02" {
+			w.Write([]byte(`{"errcode":61501,"errmsg":"date range error"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"list":[{"ref_date":"%s","msgid":"1_1","title":"t","details":[{"stat_date":"%s","target_user":10},{"stat_date":"%s","target_user":10}]}]}`,
+			req.BeginDate, req.BeginDate, req.BeginDate)
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	rows, err := client.DataCube.ArticleTotalRange(context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "1_1", rows[1].MsgID)
+	assert.Equal(t, 10, rows[1].TargetUser)
+
+	// 部分时间段失败时返回其余时间段的数据
+	rows, err = client.DataCube.ArticleTotalRange(context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	assert.ErrorContains(t, err, "2024-01-02~2024-01-02")
+	var clientErr *wechatgo.ClientError
+	assert.True(t, errors.As(err, &clientErr))
+	var rangeErr *api.RangeError
+	assert.True(t, errors.As(err, &rangeErr))
+	assert.Equal(t, 3, rangeErr.Total)
+	assert.Len(t, rangeErr.Chunks, 1)
+	assert.Equal(t, 1, rangeErr.Chunks[0].Index)
+	assert.Equal(t, "2024-01-02~2024-01-02", rangeErr.Chunks[0].Range.String())
+	assert.Len(t, rows, 4)
+	assert.Equal(t, "2024-01-01", rows[0].RefDate)
+	assert.Equal(t, "2024-01-03", rows[3].RefDate)
+}
+
+func TestDataCubeRange_RootURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 数据统计接口不在 /cgi-bin 路径下
+		assert.Equal(t, "/datacube/getusershare", r.URL.Path)
+		w.Write([]byte(`{"list":[{"ref_date":"2024-01-01","share_scene":1,"share_count":2,"share_user":1}]}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL+"/cgi-bin/", nil)
+
+	rows, err := client.DataCube.UserShareRange(context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+
+	_, err = client.DataCube.GetUserShare("2024-01-01", "2024-01-01")
+	assert.NoError(t, err)
+}
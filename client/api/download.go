@@ -0,0 +1,96 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/wechatpy/wechatgo"
+)
+
+// DownloadResult 下载结果
+type DownloadResult struct {
+	FileName    string // 文件名，取自 Content-Disposition 响应头
+	ContentType string // 内容类型
+	Size        int64  // 写入的字节数
+}
+
+// maxJSONResponseSize JSON 响应的最大读取字节数
+const maxJSONResponseSize = 1 << 20
+
+// sniffResponse 区分二进制响应与 JSON 响应
+// 二进制响应返回可继续读取的 body；JSON 响应返回解析结果，其中的 errcode 会被转换为错误
+func sniffResponse(resp *http.Response) (io.Reader, map[string]interface{}, error) {
+	body := bufio.NewReader(resp.Body)
+	if !isJSONResponse(resp, body) {
+		return body, nil, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxJSONResponseSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, nil, err
+	}
+	if err := wechatgo.CheckResponse(result, resp, data); err != nil {
+		return nil, nil, err
+	}
+	return nil, result, nil
+}
+
+// isJSONResponse 根据 Content-Type 和首字节判断响应是否为 JSON
+// 微信部分接口出错时以 text/plain 返回 JSON，因此还需要检查内容
+func isJSONResponse(resp *http.Response, body *bufio.Reader) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json":
+		return true
+	case mediaType == "" || strings.HasPrefix(mediaType, "text/"):
+		for {
+			b, err := body.Peek(1)
+			if err != nil || len(b) == 0 {
+				return false
+			}
+			// 跳过开头的空白字符
+			if b[0] == ' ' || b[0] == '\n' || b[0] == '\r' || b[0] == '\t' {
+				body.ReadByte()
+				continue
+			}
+			return b[0] == '{'
+		}
+	}
+	return false
+}
+
+// newDownloadResult 从响应头构建下载结果
+func newDownloadResult(resp *http.Response) *DownloadResult {
+	result := &DownloadResult{
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if cd := resp.Header.Get("Content-Disposition"); cd != "" {
+		if _, params, err := mime.ParseMediaType(cd); err == nil {
+			result.FileName = params["filename"]
+		}
+	}
+	return result
+}
+
+// ReadDownload 读取下载类接口的响应
+// 二进制内容写入 w 并返回下载结果；JSON 响应中的 errcode 会被转换为错误，
+// 无错误的 JSON 响应不写入 w，以 map 形式返回，由调用方进一步处理
+func ReadDownload(resp *http.Response, w io.Writer) (*DownloadResult, map[string]interface{}, error) {
+	body, result, err := sniffResponse(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result != nil {
+		return nil, result, nil
+	}
+	download, err := copyDownload(resp, body, w)
+	return download, nil, err
+}
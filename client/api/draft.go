@@ -0,0 +1,169 @@
+package api
+
+import (
+	"fmt"
+)
+
+// 草稿文章类型
+const (
+	ArticleTypeNews    = "news"    // 图文消息
+	ArticleTypeNewsPic = "newspic" // 图片消息
+)
+
+// DraftAPI 草稿箱 API
+type DraftAPI struct {
+	*BaseAPI
+}
+
+// NewDraftAPI 创建草稿箱 API
+func NewDraftAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+}) *DraftAPI {
+	return &DraftAPI{
+		BaseAPI: NewBaseAPI(client),
+	}
+}
+
+// DraftImage 图片消息中的图片
+type DraftImage struct {
+	ImageMediaID string `json:"image_media_id"`
+}
+
+// DraftImageInfo 图片消息的图片列表
+type DraftImageInfo struct {
+	ImageList []DraftImage `json:"image_list"`
+}
+
+// DraftArticle 草稿文章
+// URL、ThumbURL、IsDeleted 仅在获取时返回
+type DraftArticle struct {
+	ArticleType        string          `json:"article_type,omitempty"`
+	Title              string          `json:"title"`
+	Author             string          `json:"author,omitempty"`
+	Digest             string          `json:"digest,omitempty"`
+	Content            string          `json:"content"`
+	ContentSourceURL   string          `json:"content_source_url,omitempty"`
+	ThumbMediaID       string          `json:"thumb_media_id,omitempty"`
+	NeedOpenComment    int             `json:"need_open_comment,omitempty"`
+	OnlyFansCanComment int             `json:"only_fans_can_comment,omitempty"`
+	PicCrop2351        string          `json:"pic_crop_235_1,omitempty"`
+	PicCrop11          string          `json:"pic_crop_1_1,omitempty"`
+	ImageInfo          *DraftImageInfo `json:"image_info,omitempty"`
+	URL                string          `json:"url,omitempty"`
+	ThumbURL           string          `json:"thumb_url,omitempty"`
+	IsDeleted          bool            `json:"is_deleted,omitempty"`
+}
+
+// DraftContent 草稿或已发布文章的内容
+type DraftContent struct {
+	NewsItem   []DraftArticle `json:"news_item"`
+	CreateTime int64          `json:"create_time,omitempty"`
+	UpdateTime int64          `json:"update_time,omitempty"`
+}
+
+// DraftItem 草稿列表中的草稿
+type DraftItem struct {
+	MediaID    string       `json:"media_id"`
+	Content    DraftContent `json:"content"`
+	UpdateTime int64        `json:"update_time"`
+}
+
+// DraftList 草稿列表
+type DraftList struct {
+	TotalCount int         `json:"total_count"`
+	ItemCount  int         `json:"item_count"`
+	Item       []DraftItem `json:"item"`
+}
+
+// Add 新建草稿，返回草稿的 media_id
+// https://developers.weixin.qq.com/doc/offiaccount/Draft_Box/Add_draft.html
+func (api *DraftAPI) Add(articles []DraftArticle) (string, error) {
+	result, err := api.Post("/draft/add", map[string]interface{}{
+		"articles": articles,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if mediaID, ok := result["media_id"].(string); ok {
+		return mediaID, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// Get 获取草稿
+// https://developers.weixin.qq.com/doc/offiaccount/Draft_Box/Get_draft.html
+func (api *DraftAPI) Get(mediaID string) ([]DraftArticle, error) {
+	result, err := api.Post("/draft/get", map[string]string{"media_id": mediaID})
+	if err != nil {
+		return nil, err
+	}
+
+	var content DraftContent
+	if err := DecodeResult(result, &content); err != nil {
+		return nil, err
+	}
+	return content.NewsItem, nil
+}
+
+// Delete 删除草稿
+// https://developers.weixin.qq.com/doc/offiaccount/Draft_Box/Delete_draft.html
+func (api *DraftAPI) Delete(mediaID string) error {
+	_, err := api.Post("/draft/delete", map[string]string{"media_id": mediaID})
+	return err
+}
+
+// Update 修改草稿中指定位置的文章，index 从 0 开始
+// https://developers.weixin.qq.com/doc/offiaccount/Draft_Box/Update_draft.html
+func (api *DraftAPI) Update(mediaID string, index int, article DraftArticle) error {
+	_, err := api.Post("/draft/update", map[string]interface{}{
+		"media_id": mediaID,
+		"index":    index,
+		"articles": article,
+	})
+	return err
+}
+
+// Count 获取草稿总数
+// https://developers.weixin.qq.com/doc/offiaccount/Draft_Box/Count_drafts.html
+func (api *DraftAPI) Count() (int, error) {
+	result, err := api.BaseAPI.Get("/draft/count", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if count, ok := result["total_count"].(float64); ok {
+		return int(count), nil
+	}
+	return 0, fmt.Errorf("unexpected response format")
+}
+
+// BatchGet 获取草稿列表
+// count 取值 1 到 20，noContent 为 true 时不返回 content 字段
+// https://developers.weixin.qq.com/doc/offiaccount/Draft_Box/Get_draft_list.html
+func (api *DraftAPI) BatchGet(offset, count int, noContent bool) (*DraftList, error) {
+	result, err := api.Post("/draft/batchget", batchGetData(offset, count, noContent))
+	if err != nil {
+		return nil, err
+	}
+
+	var list DraftList
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// batchGetData 构建草稿箱和发布能力分页接口的请求参数
+func batchGetData(offset, count int, noContent bool) map[string]interface{} {
+	data := map[string]interface{}{
+		"offset": offset,
+		"count":  count,
+	}
+	if noContent {
+		data["no_content"] = 1
+	}
+	return data
+}
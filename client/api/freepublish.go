@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/wechatpy/wechatgo"
+)
+
+// PublishStatus 发布状态
+type PublishStatus int
+
+// 发布状态
+const (
+	PublishStatusSuccess        PublishStatus = 0 // 发布成功
+	PublishStatusPublishing     PublishStatus = 1 // 发布中
+	PublishStatusOriginalFailed PublishStatus = 2 // 原创失败
+	PublishStatusFailed         PublishStatus = 3 // 常规失败
+	PublishStatusAuditRejected  PublishStatus = 4 // 平台审核不通过
+	PublishStatusUserDeleted    PublishStatus = 5 // 成功后用户删除所有文章
+	PublishStatusSystemBanned   PublishStatus = 6 // 成功后系统封禁所有文章
+)
+
+// FreePublishAPI 发布能力 API
+type FreePublishAPI struct {
+	*BaseAPI
+}
+
+// NewFreePublishAPI 创建发布能力 API
+func NewFreePublishAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+}) *FreePublishAPI {
+	return &FreePublishAPI{
+		BaseAPI: NewBaseAPI(client),
+	}
+}
+
+// PublishSubmitResult 发布提交结果
+// PublishID 与 PUBLISHJOBFINISH 事件中的 publish_id 对应，MsgDataID 用于评论管理
+type PublishSubmitResult struct {
+	PublishID string `json:"publish_id"`
+	MsgDataID int64  `json:"msg_data_id"`
+}
+
+// PublishStatusResult 发布状态
+type PublishStatusResult struct {
+	PublishID     string                         `json:"publish_id"`
+	PublishStatus PublishStatus                  `json:"publish_status"`
+	ArticleID     string                         `json:"article_id,omitempty"`
+	ArticleDetail *wechatgo.PublishArticleDetail `json:"article_detail,omitempty"`
+	FailIdx       []int                          `json:"fail_idx,omitempty"`
+}
+
+// PublishedItem 已发布文章列表中的文章
+type PublishedItem struct {
+	ArticleID  string       `json:"article_id"`
+	Content    DraftContent `json:"content"`
+	UpdateTime int64        `json:"update_time"`
+}
+
+// PublishedList 已发布文章列表
+type PublishedList struct {
+	TotalCount int             `json:"total_count"`
+	ItemCount  int             `json:"item_count"`
+	Item       []PublishedItem `json:"item"`
+}
+
+// Submit 发布草稿
+// 发布结果通过 PUBLISHJOBFINISH 事件推送，也可以通过 Get 查询
+// https://developers.weixin.qq.com/doc/offiaccount/Publish/Publish.html
+func (api *FreePublishAPI) Submit(mediaID string) (*PublishSubmitResult, error) {
+	result, err := api.Post("/freepublish/submit", map[string]string{"media_id": mediaID})
+	if err != nil {
+		return nil, err
+	}
+
+	// publish_id 可能以数字形式返回
+	if id, ok := result["publish_id"].(float64); ok {
+		result["publish_id"] = fmt.Sprintf("%.0f", id)
+	}
+
+	var submit PublishSubmitResult
+	if err := DecodeResult(result, &submit); err != nil {
+		return nil, err
+	}
+	return &submit, nil
+}
+
+// Get 查询发布状态
+// https://developers.weixin.qq.com/doc/offiaccount/Publish/Get_status.html
+func (api *FreePublishAPI) Get(publishID string) (*PublishStatusResult, error) {
+	result, err := api.Post("/freepublish/get", map[string]string{"publish_id": publishID})
+	if err != nil {
+		return nil, err
+	}
+
+	if id, ok := result["publish_id"].(float64); ok {
+		result["publish_id"] = fmt.Sprintf("%.0f", id)
+	}
+
+	var status PublishStatusResult
+	if err := DecodeResult(result, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Delete 删除发布的文章，index 从 1 开始，为 0 时删除全部文章
+// https://developers.weixin.qq.com/doc/offiaccount/Publish/Delete_posts.html
+func (api *FreePublishAPI) Delete(articleID string, index int) error {
+	data := map[string]interface{}{
+		"article_id": articleID,
+	}
+	if index > 0 {
+		data["index"] = index
+	}
+	_, err := api.Post("/freepublish/delete", data)
+	return err
+}
+
+// GetArticle 通过 article_id 获取已发布文章
+// https://developers.weixin.qq.com/doc/offiaccount/Publish/Get_article_from_id.html
+func (api *FreePublishAPI) GetArticle(articleID string) ([]DraftArticle, error) {
+	result, err := api.Post("/freepublish/getarticle", map[string]string{"article_id": articleID})
+	if err != nil {
+		return nil, err
+	}
+
+	var content DraftContent
+	if err := DecodeResult(result, &content); err != nil {
+		return nil, err
+	}
+	return content.NewsItem, nil
+}
+
+// BatchGet 获取成功发布列表
+// count 取值 1 到 20，noContent 为 true 时不返回 content 字段
+// https://developers.weixin.qq.com/doc/offiaccount/Publish/Get_publication_records.html
+func (api *FreePublishAPI) BatchGet(offset, count int, noContent bool) (*PublishedList, error) {
+	result, err := api.Post("/freepublish/batchget", batchGetData(offset, count, noContent))
+	if err != nil {
+		return nil, err
+	}
+
+	var list PublishedList
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
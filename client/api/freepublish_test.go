@@ -0,0 +1,29 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestFreePublishGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/freepublish/get", r.URL.Path)
+		w.Write([]byte(`{"publish_id":"2247503051","publish_status":0,"article_id":"b5O2OUs25HBxRceL7hfReg","article_detail":{"count":1,"item":[{"idx":1,"article_url":"ARTICLE_URL"}]},"fail_idx":[]}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	result, err := client.FreePublish.Get("2247503051")
+	assert.NoError(t, err)
+	assert.Equal(t, api.PublishStatusSuccess, result.PublishStatus)
+	if assert.NotNil(t, result.ArticleDetail) {
+		assert.Equal(t, 1, result.ArticleDetail.Count)
+		assert.Equal(t, "ARTICLE_URL", result.ArticleDetail.Item[0].ArticleURL)
+	}
+}
@@ -0,0 +1,341 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/wechatpy/wechatgo"
+)
+
+// 发票授权类型
+const (
+	InvoiceAuthTypeInvoice  = 0 // 开票授权
+	InvoiceAuthTypeNoTitle  = 1 // 填写字段开票授权
+	InvoiceAuthTypeBizTitle = 2 // 领票授权
+)
+
+// 发票授权来源
+const (
+	InvoiceSourceApp = "app" // App 开票
+	InvoiceSourceWeb = "web" // 微信 H5 开票
+	InvoiceSourceWxa = "wxa" // 小程序开票
+	InvoiceSourceWap = "wap" // 普通网页开票
+)
+
+// InvoiceAPI 电子发票 API
+// 报销方接口用于查询和核销用户卡包中的发票，开票方接口用于获取授权并将发票插入用户卡包
+type InvoiceAPI struct {
+	*BaseAPI
+	rootURL func(path string) string
+	jsapi   *JSAPI
+}
+
+// NewInvoiceAPI 创建电子发票 API，jsapi 用于在生成授权链接时获取 wx_card ticket
+func NewInvoiceAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+	RootURL(path string) string
+}, jsapi *JSAPI) *InvoiceAPI {
+	return &InvoiceAPI{
+		BaseAPI: NewBaseAPI(client),
+		rootURL: client.RootURL,
+		jsapi:   jsapi,
+	}
+}
+
+// post 调用 /card/invoice 下的接口
+func (api *InvoiceAPI) post(path string, data interface{}) (map[string]interface{}, error) {
+	return api.Post(api.rootURL(path), data)
+}
+
+// InvoiceItem 发票商品明细
+type InvoiceItem struct {
+	Name  string `json:"name"`
+	Num   int    `json:"num,omitempty"`
+	Unit  string `json:"unit,omitempty"`
+	Price int    `json:"price,omitempty"`
+}
+
+// InvoiceUserInfo 发票的用户信息，金额单位为分
+type InvoiceUserInfo struct {
+	Fee                   int                      `json:"fee"`
+	Title                 string                   `json:"title"`
+	BillingTime           int64                    `json:"billing_time"`
+	BillingNo             string                   `json:"billing_no"`
+	BillingCode           string                   `json:"billing_code"`
+	Info                  []InvoiceItem            `json:"info,omitempty"`
+	FeeWithoutTax         int                      `json:"fee_without_tax"`
+	Tax                   int                      `json:"tax"`
+	PDFURL                string                   `json:"pdf_url,omitempty"`
+	TripPDFURL            string                   `json:"trip_pdf_url,omitempty"`
+	CheckCode             string                   `json:"check_code,omitempty"`
+	BuyerNumber           string                   `json:"buyer_number,omitempty"`
+	BuyerAddressAndPhone  string                   `json:"buyer_address_and_phone,omitempty"`
+	BuyerBankAccount      string                   `json:"buyer_bank_account,omitempty"`
+	SellerNumber          string                   `json:"seller_number,omitempty"`
+	SellerAddressAndPhone string                   `json:"seller_address_and_phone,omitempty"`
+	SellerBankAccount     string                   `json:"seller_bank_account,omitempty"`
+	Remarks               string                   `json:"remarks,omitempty"`
+	Cashier               string                   `json:"cashier,omitempty"`
+	Maker                 string                   `json:"maker,omitempty"`
+	ReimburseStatus       wechatgo.ReimburseStatus `json:"reimburse_status,omitempty"`
+}
+
+// InvoiceInfo 用户卡包中的发票信息
+type InvoiceInfo struct {
+	CardID    string          `json:"card_id"`
+	BeginTime int64           `json:"begin_time"`
+	EndTime   int64           `json:"end_time"`
+	OpenID    string          `json:"openid"`
+	Type      string          `json:"type"`
+	Payee     string          `json:"payee"`
+	Detail    string          `json:"detail"`
+	UserInfo  InvoiceUserInfo `json:"user_info"`
+}
+
+// InvoiceCard 通过 card_id 和加密 code 标识的一张发票
+type InvoiceCard struct {
+	CardID      string `json:"card_id"`
+	EncryptCode string `json:"encrypt_code"`
+}
+
+// GetInvoiceInfo 查询用户选择的电子发票信息（报销方）
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Reimburser_API_List.html
+func (api *InvoiceAPI) GetInvoiceInfo(cardID, encryptCode string) (*InvoiceInfo, error) {
+	result, err := api.post("/card/invoice/reimburse/getinvoiceinfo", InvoiceCard{
+		CardID:      cardID,
+		EncryptCode: encryptCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info InvoiceInfo
+	if err := DecodeResult(result, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetInvoiceBatch 批量查询电子发票信息（报销方）
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Reimburser_API_List.html
+func (api *InvoiceAPI) GetInvoiceBatch(items []InvoiceCard) ([]InvoiceInfo, error) {
+	result, err := api.post("/card/invoice/reimburse/getinvoicebatch", map[string]interface{}{
+		"item_list": items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var batch struct {
+		ItemList []InvoiceInfo `json:"item_list"`
+	}
+	if err := DecodeResult(result, &batch); err != nil {
+		return nil, err
+	}
+	return batch.ItemList, nil
+}
+
+// UpdateStatus 更新单张发票的报销状态（报销方）
+// 状态只能按 INIT → LOCK → CLOSURE 的顺序变更，LOCK 状态可以回退到 INIT
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Reimburser_API_List.html
+func (api *InvoiceAPI) UpdateStatus(cardID, encryptCode string, status wechatgo.ReimburseStatus) error {
+	_, err := api.post("/card/invoice/reimburse/updateinvoicestatus", map[string]interface{}{
+		"card_id":          cardID,
+		"encrypt_code":     encryptCode,
+		"reimburse_status": status,
+	})
+	return err
+}
+
+// UpdateStatusBatch 批量更新同一用户多张发票的报销状态（报销方）
+// 批量更新是原子操作，任一发票更新失败时全部不生效
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Reimburser_API_List.html
+func (api *InvoiceAPI) UpdateStatusBatch(openID string, status wechatgo.ReimburseStatus, invoices []InvoiceCard) error {
+	_, err := api.post("/card/invoice/reimburse/updatestatusbatch", map[string]interface{}{
+		"openid":           openID,
+		"reimburse_status": status,
+		"invoice_list":     invoices,
+	})
+	return err
+}
+
+// GetAuthDomain 获取开票平台授权页的链接域名（开票平台）
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Invoicing_Platform_API_List.html
+func (api *InvoiceAPI) GetAuthDomain() (string, error) {
+	result, err := api.post("/card/invoice/seturl", map[string]string{})
+	if err != nil {
+		return "", err
+	}
+
+	if invoiceURL, ok := result["invoice_url"].(string); ok {
+		return invoiceURL, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// InvoiceAuthURLRequest 获取授权页链接请求
+// Ticket 为空时自动使用 JSAPI 的 wx_card ticket，Money 单位为分
+type InvoiceAuthURLRequest struct {
+	SPAppID     string `json:"s_pappid"`
+	OrderID     string `json:"order_id"`
+	Money       int    `json:"money"`
+	Timestamp   int64  `json:"timestamp"`
+	Source      string `json:"source"`
+	RedirectURL string `json:"redirect_url,omitempty"`
+	Ticket      string `json:"ticket"`
+	Type        int    `json:"type"`
+}
+
+// InvoiceAuthURL 授权页链接
+type InvoiceAuthURL struct {
+	AuthURL string `json:"auth_url"`
+	AppID   string `json:"appid,omitempty"`
+}
+
+// GetAuthURL 获取用户开票授权页链接（商户）
+// 用户完成授权后会推送 user_authorize_invoice 事件
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Vendor_API_List.html
+func (api *InvoiceAPI) GetAuthURL(req InvoiceAuthURLRequest) (*InvoiceAuthURL, error) {
+	if req.Ticket == "" {
+		if api.jsapi == nil {
+			return nil, fmt.Errorf("ticket is required")
+		}
+		ticket, err := api.jsapi.GetCardTicket()
+		if err != nil {
+			return nil, err
+		}
+		req.Ticket = ticket
+	}
+
+	result, err := api.post("/card/invoice/getauthurl", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var authURL InvoiceAuthURL
+	if err := DecodeResult(result, &authURL); err != nil {
+		return nil, err
+	}
+	return &authURL, nil
+}
+
+// InvoiceTitle 用户授权时填写的抬头信息
+type InvoiceTitle struct {
+	Title    string `json:"title"`
+	Phone    string `json:"phone,omitempty"`
+	TaxNo    string `json:"tax_no,omitempty"`
+	Addr     string `json:"addr,omitempty"`
+	BankType string `json:"bank_type,omitempty"`
+	BankNo   string `json:"bank_no,omitempty"`
+}
+
+// InvoiceAuthData 用户授权数据
+// InvoiceStatus 为 auth success 表示已授权，UserField 为个人抬头，BizField 为单位抬头
+type InvoiceAuthData struct {
+	InvoiceStatus string `json:"invoice_status"`
+	AuthTime      int64  `json:"auth_time"`
+	UserAuthInfo  struct {
+		UserField *InvoiceTitle `json:"user_field,omitempty"`
+		BizField  *InvoiceTitle `json:"biz_field,omitempty"`
+	} `json:"user_auth_info"`
+}
+
+// GetAuthData 查询用户授权完成状态及填写的抬头信息（商户）
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Vendor_API_List.html
+func (api *InvoiceAPI) GetAuthData(sPAppID, orderID string) (*InvoiceAuthData, error) {
+	result, err := api.post("/card/invoice/getauthdata", map[string]string{
+		"s_pappid": sPAppID,
+		"order_id": orderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data InvoiceAuthData
+	if err := DecodeResult(result, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RejectInsert 拒绝为用户开票，url 为可选的跳转链接（商户）
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Vendor_API_List.html
+func (api *InvoiceAPI) RejectInsert(sPAppID, orderID, reason, url string) error {
+	data := map[string]string{
+		"s_pappid": sPAppID,
+		"order_id": orderID,
+		"reason":   reason,
+	}
+	if url != "" {
+		data["url"] = url
+	}
+	_, err := api.post("/card/invoice/rejectinsert", data)
+	return err
+}
+
+// InvoiceUserData 插入卡包的发票数据，PDF 需先通过开票平台上传获得 s_media_id
+type InvoiceUserData struct {
+	Fee                   int           `json:"fee"`
+	Title                 string        `json:"title"`
+	BillingTime           int64         `json:"billing_time"`
+	BillingNo             string        `json:"billing_no"`
+	BillingCode           string        `json:"billing_code"`
+	Info                  []InvoiceItem `json:"info,omitempty"`
+	FeeWithoutTax         int           `json:"fee_without_tax"`
+	Tax                   int           `json:"tax"`
+	SPDFMediaID           string        `json:"s_pdf_media_id"`
+	STripPDFMediaID       string        `json:"s_trip_pdf_media_id,omitempty"`
+	CheckCode             string        `json:"check_code"`
+	BuyerNumber           string        `json:"buyer_number,omitempty"`
+	BuyerAddressAndPhone  string        `json:"buyer_address_and_phone,omitempty"`
+	BuyerBankAccount      string        `json:"buyer_bank_account,omitempty"`
+	SellerNumber          string        `json:"seller_number,omitempty"`
+	SellerAddressAndPhone string        `json:"seller_address_and_phone,omitempty"`
+	SellerBankAccount     string        `json:"seller_bank_account,omitempty"`
+	Remarks               string        `json:"remarks,omitempty"`
+	Cashier               string        `json:"cashier,omitempty"`
+	Maker                 string        `json:"maker,omitempty"`
+}
+
+// InvoiceInsertRequest 将发票插入用户卡包请求
+// AppID 为用户授权时所在的公众号或小程序 AppID
+type InvoiceInsertRequest struct {
+	OrderID  string
+	CardID   string
+	AppID    string
+	NonceStr string
+	UserData InvoiceUserData
+}
+
+// InvoiceInsertResult 插入卡包结果
+type InvoiceInsertResult struct {
+	Code    string `json:"code"`
+	OpenID  string `json:"openid"`
+	UnionID string `json:"unionid"`
+}
+
+// Insert 将电子发票插入用户卡包（开票平台）
+// https://developers.weixin.qq.com/doc/offiaccount/WeChat_Invoice/E_Invoice/Invoicing_Platform_API_List.html
+func (api *InvoiceAPI) Insert(req InvoiceInsertRequest) (*InvoiceInsertResult, error) {
+	result, err := api.post("/card/invoice/insert", map[string]interface{}{
+		"order_id": req.OrderID,
+		"card_id":  req.CardID,
+		"appid":    req.AppID,
+		"card_ext": map[string]interface{}{
+			"nonce_str": req.NonceStr,
+			"user_card": map[string]interface{}{
+				"invoice_user_data": req.UserData,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var inserted InvoiceInsertResult
+	if err := DecodeResult(result, &inserted); err != nil {
+		return nil, err
+	}
+	return &inserted, nil
+}
@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestInvoiceReimburse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/card/invoice/reimburse/getinvoiceinfo":
+			assert.Equal(t, "pjZ8Yt1XGILfi-FUsewpnnolGgZk", req["card_id"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","card_id":"pjZ8Yt1XGILfi-FUsewpnnolGgZk","begin_time":1473305510,"end_time":1473305510,"openid":"oxRcv0JfM9xz8qILNN6bzQ1zkvDs","type":"广东增值税普通发票","payee":"测试-收款方","detail":"detail","user_info":{"fee":123,"title":"灌哥发票","billing_time":1478625213,"billing_no":"00000001","billing_code":"000000000001","fee_without_tax":2345,"tax":123,"pdf_url":"pdf_url","reimburse_status":"INVOICE_REIMBURSE_INIT","check_code":"check_code"}}`))
+		case "/card/invoice/reimburse/getinvoicebatch":
+			assert.Len(t, req["item_list"], 2)
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","item_list":[{"card_id":"c1","user_info":{"fee":100}},{"card_id":"c2","user_info":{"fee":200}}]}`))
+		case "/card/invoice/reimburse/updateinvoicestatus":
+			assert.Equal(t, "INVOICE_REIMBURSE_LOCK", req["reimburse_status"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		case "/card/invoice/reimburse/updatestatusbatch":
+			assert.Equal(t, "oxRcv0JfM9xz8qILNN6bzQ1zkvDs", req["openid"])
+			assert.Equal(t, "INVOICE_REIMBURSE_CLOSURE", req["reimburse_status"])
+			w.Write([]byte(`{"errcode":72017,"errmsg":"invoice status error"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	info, err := client.Invoice.GetInvoiceInfo("pjZ8Yt1XGILfi-FUsewpnnolGgZk", "encrypt")
+	assert.NoError(t, err)
+	assert.Equal(t, 123, info.UserInfo.Fee)
+	assert.Equal(t, wechatgo.ReimburseStatusInit, info.UserInfo.ReimburseStatus)
+
+	items, err := client.Invoice.GetInvoiceBatch([]api.InvoiceCard{{CardID: "c1", EncryptCode: "e1"}, {CardID: "c2", EncryptCode: "e2"}})
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, 200, items[1].UserInfo.Fee)
+
+	err = client.Invoice.UpdateStatus("c1", "e1", wechatgo.ReimburseStatusLock)
+	assert.NoError(t, err)
+
+	err = client.Invoice.UpdateStatusBatch("oxRcv0JfM9xz8qILNN6bzQ1zkvDs", wechatgo.ReimburseStatusClosure, []api.InvoiceCard{{CardID: "c1", EncryptCode: "e1"}})
+	assert.Error(t, err)
+}
+
+func TestInvoiceAuthAndInsert(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ticket/getticket" {
+			assert.Equal(t, "wx_card", r.URL.Query().Get("type"))
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","ticket":"CARD_TICKET","expires_in":7200}`))
+			return
+		}
+
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/card/invoice/getauthurl":
+			assert.Equal(t, "CARD_TICKET", req["ticket"])
+			assert.Equal(t, "web", req["source"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","auth_url":"http://auth.weixin.qq.com/invoice"}`))
+		case "/card/invoice/getauthdata":
+			assert.Equal(t, "order1", req["order_id"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","invoice_status":"auth success","auth_time":1480342498,"user_auth_info":{"biz_field":{"title":"某公司","tax_no":"1234567890"}}}`))
+		case "/card/invoice/insert":
+			cardExt := req["card_ext"].(map[string]interface{})
+			userCard := cardExt["user_card"].(map[string]interface{})
+			userData := userCard["invoice_user_data"].(map[string]interface{})
+			assert.Equal(t, "media", userData["s_pdf_media_id"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","code":"CODE","openid":"OPENID"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	authURL, err := client.Invoice.GetAuthURL(api.InvoiceAuthURLRequest{
+		SPAppID: "s_pappid",
+		OrderID: "order1",
+		Money:   100,
+		Source:  api.InvoiceSourceWeb,
+		Type:    api.InvoiceAuthTypeInvoice,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://auth.weixin.qq.com/invoice", authURL.AuthURL)
+
+	data, err := client.Invoice.GetAuthData("s_pappid", "order1")
+	assert.NoError(t, err)
+	assert.Equal(t, "auth success", data.InvoiceStatus)
+	assert.Nil(t, data.UserAuthInfo.UserField)
+	assert.Equal(t, "1234567890", data.UserAuthInfo.BizField.TaxNo)
+
+	inserted, err := client.Invoice.Insert(api.InvoiceInsertRequest{
+		OrderID:  "order1",
+		CardID:   "card1",
+		AppID:    "test_appid",
+		NonceStr: "nonce",
+		UserData: api.InvoiceUserData{Fee: 100, Title: "某公司", SPDFMediaID: "media"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "CODE", inserted.Code)
+}
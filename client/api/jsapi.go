@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+// 票据类型
+const (
+	TicketTypeJSAPI  = "jsapi"   // JS-SDK 票据
+	TicketTypeWxCard = "wx_card" // 卡券 api_ticket
+)
+
+// JSAPI JS-SDK 票据与签名 API
+type JSAPI struct {
+	*BaseAPI
+	appID   string
+	storage session.Storage
+	// keyPrefix 会话存储键前缀，与客户端的 access token 存储键一致
+	keyPrefix string
+	// mu 保证同一时刻只有一个 goroutine 刷新票据
+	mu sync.Mutex
+}
+
+// NewJSAPI 创建 JS-SDK API，票据缓存在客户端的会话存储中
+func NewJSAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+	Session() session.Storage
+}, appID string) *JSAPI {
+	api := &JSAPI{
+		BaseAPI: NewBaseAPI(client),
+		appID:   appID,
+		storage: client.Session(),
+	}
+	if c, ok := client.(interface{ KeyPrefix() string }); ok {
+		api.keyPrefix = c.KeyPrefix()
+	}
+	return api
+}
+
+// JSConfig wx.config 所需的签名参数
+type JSConfig struct {
+	AppID     string `json:"appId"`
+	Timestamp int64  `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Signature string `json:"signature"`
+}
+
+// ticketKey 获取票据存储键
+func (api *JSAPI) ticketKey(ticketType string) string {
+	return fmt.Sprintf("%s%s_%s_ticket", api.keyPrefix, api.appID, ticketType)
+}
+
+// ticketExpiresAtKey 获取票据过期时间存储键
+func (api *JSAPI) ticketExpiresAtKey(ticketType string) string {
+	return fmt.Sprintf("%s%s_%s_ticket_expires_at", api.keyPrefix, api.appID, ticketType)
+}
+
+// FetchTicket 向微信请求新的票据，不读取也不写入缓存
+// https://developers.weixin.qq.com/doc/offiaccount/OA_Web_Apps/JS-SDK.html#62
+func (api *JSAPI) FetchTicket(ticketType string) (string, int, error) {
+	result, err := api.BaseAPI.Get("/ticket/getticket", map[string]string{"type": ticketType})
+	if err != nil {
+		return "", 0, err
+	}
+
+	ticket, ok := result["ticket"].(string)
+	if !ok || ticket == "" {
+		return "", 0, fmt.Errorf("unexpected response format")
+	}
+	expiresIn := 7200
+	if v, ok := result["expires_in"].(float64); ok && v > 0 {
+		expiresIn = int(v)
+	}
+	return ticket, expiresIn, nil
+}
+
+// GetTicket 获取票据，缓存中不存在或即将过期时自动刷新
+func (api *JSAPI) GetTicket(ticketType string) (string, error) {
+	if ticket, ok := api.cachedTicket(ticketType); ok {
+		return ticket, nil
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	// 等待锁期间可能已被其他 goroutine 刷新
+	if ticket, ok := api.cachedTicket(ticketType); ok {
+		return ticket, nil
+	}
+	ticket, expiresIn, err := api.FetchTicket(ticketType)
+	if err != nil {
+		return "", err
+	}
+	if err := api.SetTicket(ticketType, ticket, expiresIn); err != nil {
+		return "", err
+	}
+	return ticket, nil
+}
+
+// GetJSAPITicket 获取 jsapi_ticket
+func (api *JSAPI) GetJSAPITicket() (string, error) {
+	return api.GetTicket(TicketTypeJSAPI)
+}
+
+// GetCardTicket 获取卡券 api_ticket
+func (api *JSAPI) GetCardTicket() (string, error) {
+	return api.GetTicket(TicketTypeWxCard)
+}
+
+// SetTicket 设置票据，过期时间处理与 access token 一致
+func (api *JSAPI) SetTicket(ticketType, ticket string, expiresIn int) error {
+	ttl := time.Duration(expiresIn) * time.Second
+	if err := api.storage.Set(api.ticketKey(ticketType), ticket, ttl); err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Unix() + int64(expiresIn)
+	expiresAtData, err := json.Marshal(expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal expiresAt: %w", err)
+	}
+	return api.storage.Set(api.ticketExpiresAtKey(ticketType), string(expiresAtData), ttl)
+}
+
+// cachedTicket 从会话存储读取未过期的票据
+func (api *JSAPI) cachedTicket(ticketType string) (string, bool) {
+	ticket, err := api.storage.Get(api.ticketKey(ticketType))
+	if err != nil || ticket == "" {
+		return "", false
+	}
+
+	expiresAtStr, err := api.storage.Get(api.ticketExpiresAtKey(ticketType))
+	if err == nil && expiresAtStr != "" {
+		var expiresAt int64
+		if err := json.Unmarshal([]byte(expiresAtStr), &expiresAt); err == nil {
+			if time.Now().Unix() < expiresAt-60 {
+				return ticket, true
+			}
+		}
+		return "", false
+	}
+
+	// 用户提供的票据，直接返回
+	return ticket, true
+}
+
+// GetSignature 计算 JS-SDK 签名
+// pageURL 中 # 及其后面的部分会被去除
+// https://developers.weixin.qq.com/doc/offiaccount/OA_Web_Apps/JS-SDK.html#62
+func GetSignature(ticket, nonceStr string, timestamp int64, pageURL string) string {
+	if i := strings.IndexByte(pageURL, '#'); i >= 0 {
+		pageURL = pageURL[:i]
+	}
+
+	signer := wechatgo.NewSigner("&")
+	signer.AddData(
+		"jsapi_ticket="+ticket,
+		"noncestr="+nonceStr,
+		"timestamp="+strconv.FormatInt(timestamp, 10),
+		"url="+pageURL,
+	)
+	return signer.Signature()
+}
+
+// GetConfig 生成页面 wx.config 所需的 appId、timestamp、nonceStr、signature
+func (api *JSAPI) GetConfig(pageURL string) (*JSConfig, error) {
+	ticket, err := api.GetJSAPITicket()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &JSConfig{
+		AppID:     api.appID,
+		Timestamp: time.Now().Unix(),
+		NonceStr:  wechatgo.RandomString(16),
+	}
+	config.Signature = GetSignature(ticket, config.NonceStr, config.Timestamp, pageURL)
+	return config, nil
+}
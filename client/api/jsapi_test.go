@@ -0,0 +1,80 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+func TestJSAPIGetConfig(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ticket/getticket", r.URL.Path)
+		assert.Equal(t, "jsapi", r.URL.Query().Get("type"))
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","ticket":"sM4AOVdWfPE4DxkXGEs8VMCPGGVi4C3VM0P37wVUCFvkVAy_90u5h9nbSlYy3-Sl-HhTdfl2fzFy1AOcHKP7qg","expires_in":7200}`))
+	}))
+	defer server.Close()
+
+	storage := session.NewMemoryStorage()
+	client := testclient.New(server.URL, storage)
+
+	config, err := client.JSAPI.GetConfig("http://mp.weixin.qq.com?params=value#hash")
+	assert.NoError(t, err)
+	assert.Equal(t, "test_appid", config.AppID)
+	assert.Len(t, config.NonceStr, 16)
+	assert.Equal(t, api.GetSignature(
+		"sM4AOVdWfPE4DxkXGEs8VMCPGGVi4C3VM0P37wVUCFvkVAy_90u5h9nbSlYy3-Sl-HhTdfl2fzFy1AOcHKP7qg",
+		config.NonceStr, config.Timestamp, "http://mp.weixin.qq.com?params=value"), config.Signature)
+
+	_, err = client.JSAPI.GetJSAPITicket()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	ticket, err := storage.Get("test_appid_jsapi_ticket")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ticket)
+}
+
+func TestJSAPIGetSignature(t *testing.T) {
+	// 官方文档示例
+	signature := api.GetSignature(
+		"sM4AOVdWfPE4DxkXGEs8VMCPGGVi4C3VM0P37wVUCFvkVAy_90u5h9nbSlYy3-Sl-HhTdfl2fzFy1AOcHKP7qg",
+		"Wm3WZYTPz0wzccnW", 1414587457, "http://mp.weixin.qq.com?params=value")
+	assert.Equal(t, "0f9de62fce790f9a083d5c99e95740ceb90c27ed", signature)
+}
+
+func TestJSAPITicket_KeyPrefix(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"errcode":0,"errmsg":"ok","ticket":"ticket_%d","expires_in":7200}`, n)
+	}))
+	defer server.Close()
+
+	// 共用存储的两个客户端使用不同前缀时，票据互不覆盖
+	storage := session.NewMemoryStorage()
+	a := testclient.New(server.URL, storage, client.WithKeyPrefix("a_"))
+	b := testclient.New(server.URL, storage, client.WithKeyPrefix("b_"))
+
+	ticketA, err := a.JSAPI.GetJSAPITicket()
+	assert.NoError(t, err)
+	ticketB, err := b.JSAPI.GetJSAPITicket()
+	assert.NoError(t, err)
+	assert.NotEqual(t, ticketA, ticketB)
+
+	stored, err := storage.Get("a_test_appid_jsapi_ticket")
+	assert.NoError(t, err)
+	assert.Equal(t, ticketA, stored)
+	stored, err = storage.Get("b_test_appid_jsapi_ticket")
+	assert.NoError(t, err)
+	assert.Equal(t, ticketB, stored)
+}
@@ -0,0 +1,356 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wechatpy/wechatgo"
+)
+
+// 群发消息状态
+const (
+	MassStatusSendSuccess = "SEND_SUCCESS" // 发送成功
+	MassStatusSending     = "SENDING"      // 发送中
+	MassStatusSendFail    = "SEND_FAIL"    // 发送失败
+	MassStatusDelete      = "DELETE"       // 已删除
+)
+
+// MassTarget 群发对象，按标签、全部用户或 OpenID 列表三选一
+type MassTarget struct {
+	TagID   int
+	ToAll   bool
+	OpenIDs []string
+}
+
+// MassToTag 按标签群发
+func MassToTag(tagID int) MassTarget {
+	return MassTarget{TagID: tagID}
+}
+
+// MassToAll 群发给全部用户
+func MassToAll() MassTarget {
+	return MassTarget{ToAll: true}
+}
+
+// MassToUsers 按 OpenID 列表群发，至少 2 个、最多 10000 个
+func MassToUsers(openIDs ...string) MassTarget {
+	return MassTarget{OpenIDs: openIDs}
+}
+
+// MassMessage 群发消息内容，使用 NewMassXxx 创建
+type MassMessage struct {
+	msgType string
+	body    interface{}
+}
+
+// MsgType 返回群发消息类型
+func (m MassMessage) MsgType() string {
+	return m.msgType
+}
+
+// NewMassText 群发文本消息
+func NewMassText(content string) MassMessage {
+	return MassMessage{msgType: "text", body: map[string]string{"content": content}}
+}
+
+// NewMassImage 群发图片消息
+func NewMassImage(mediaID string) MassMessage {
+	return MassMessage{msgType: "image", body: map[string]string{"media_id": mediaID}}
+}
+
+// NewMassVoice 群发语音消息
+func NewMassVoice(mediaID string) MassMessage {
+	return MassMessage{msgType: "voice", body: map[string]string{"media_id": mediaID}}
+}
+
+// NewMassVideo 群发视频消息，mediaID 为 MediaAPI.UploadVideo 返回的 media_id
+func NewMassVideo(mediaID string) MassMessage {
+	return MassMessage{msgType: "mpvideo", body: map[string]string{"media_id": mediaID}}
+}
+
+// NewMassMPNews 群发图文消息
+func NewMassMPNews(mediaID string) MassMessage {
+	return MassMessage{msgType: "mpnews", body: map[string]string{"media_id": mediaID}}
+}
+
+// NewMassWxCard 群发卡券
+func NewMassWxCard(cardID string) MassMessage {
+	return MassMessage{msgType: "wxcard", body: map[string]string{"card_id": cardID}}
+}
+
+// NewMassMusic 群发音乐消息
+func NewMassMusic(music Music) MassMessage {
+	return MassMessage{msgType: "music", body: music}
+}
+
+// MassRequest 群发请求
+// SendIgnoreReprint 仅对图文消息有效，为 true 时图文被判为转载也继续群发
+// ClientMsgID 用于避免重复群发，24 小时内相同 ClientMsgID 的请求只会群发一次
+type MassRequest struct {
+	Target            MassTarget
+	Message           MassMessage
+	SendIgnoreReprint bool
+	ClientMsgID       string
+}
+
+// MassResult 群发结果，MsgID 与 MassSendJobFinishEvent.MsgID 一致
+type MassResult struct {
+	MsgID     int64 `json:"msg_id"`
+	MsgDataID int64 `json:"msg_data_id"`
+}
+
+// SendMass 群发消息
+// 群发是异步任务，完成后会推送 MASSSENDJOBFINISH 事件，可以通过 MassJobs 关联结果
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Batch_Sends_and_Originality_Checks.html
+func (api *MessageAPI) SendMass(req MassRequest) (*MassResult, error) {
+	if req.Message.msgType == "" {
+		return nil, fmt.Errorf("mass message is required")
+	}
+
+	data := map[string]interface{}{
+		"msgtype":           req.Message.msgType,
+		req.Message.msgType: req.Message.body,
+	}
+	if req.Message.msgType == "mpnews" {
+		data["send_ignore_reprint"] = boolToInt(req.SendIgnoreReprint)
+	}
+	if req.ClientMsgID != "" {
+		data["clientmsgid"] = req.ClientMsgID
+	}
+
+	var endpoint string
+	switch target := req.Target; {
+	case len(target.OpenIDs) > 0:
+		data["touser"] = target.OpenIDs
+		endpoint = "/message/mass/send"
+	case target.ToAll:
+		data["filter"] = map[string]interface{}{"is_to_all": true}
+		endpoint = "/message/mass/sendall"
+	case target.TagID != 0:
+		data["filter"] = map[string]interface{}{"is_to_all": false, "tag_id": target.TagID}
+		endpoint = "/message/mass/sendall"
+	default:
+		return nil, fmt.Errorf("mass target is required")
+	}
+
+	result, err := api.Post(endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent MassResult
+	if err := DecodeResult(result, &sent); err != nil {
+		return nil, err
+	}
+	if sent.MsgID == 0 {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return &sent, nil
+}
+
+// PreviewMass 发送群发预览消息给指定用户，每日调用上限为 100 次
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Batch_Sends_and_Originality_Checks.html
+func (api *MessageAPI) PreviewMass(openID string, msg MassMessage) error {
+	if msg.msgType == "" {
+		return fmt.Errorf("mass message is required")
+	}
+	_, err := api.Post("/message/mass/preview", map[string]interface{}{
+		"touser":    openID,
+		"msgtype":   msg.msgType,
+		msg.msgType: msg.body,
+	})
+	return err
+}
+
+// MassStatus 群发消息状态，MsgStatus 使用 MassStatus 常量
+type MassStatus struct {
+	MsgID     int64  `json:"msg_id"`
+	MsgStatus string `json:"msg_status"`
+}
+
+// GetMass 查询群发消息发送状态
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Batch_Sends_and_Originality_Checks.html
+func (api *MessageAPI) GetMass(msgID int64) (*MassStatus, error) {
+	result, err := api.Post("/message/mass/get", map[string]int64{"msg_id": msgID})
+	if err != nil {
+		return nil, err
+	}
+
+	var status MassStatus
+	if err := DecodeResult(result, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// MassSpeed 群发速度，Speed 为档位 0-4，RealSpeed 为每分钟发送的万人数
+type MassSpeed struct {
+	Speed     int `json:"speed"`
+	RealSpeed int `json:"realspeed"`
+}
+
+// GetMassSpeed 获取群发速度
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Batch_Sends_and_Originality_Checks.html
+func (api *MessageAPI) GetMassSpeed() (*MassSpeed, error) {
+	result, err := api.Post("/message/mass/speed/get", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var speed MassSpeed
+	if err := DecodeResult(result, &speed); err != nil {
+		return nil, err
+	}
+	return &speed, nil
+}
+
+// SetMassSpeed 设置群发速度档位，0 为 80w/分钟，4 为 10w/分钟
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Batch_Sends_and_Originality_Checks.html
+func (api *MessageAPI) SetMassSpeed(speed int) error {
+	if speed < 0 || speed > 4 {
+		return fmt.Errorf("mass speed must be between 0 and 4, got %d", speed)
+	}
+	_, err := api.Post("/message/mass/speed/set", map[string]int{"speed": speed})
+	return err
+}
+
+// defaultUnclaimedTTL 未登记事件的默认保留时间
+const defaultUnclaimedTTL = 10 * time.Minute
+
+// MassJobs 按 msg_id 关联群发任务和 MASSSENDJOBFINISH 事件
+// 在 SendMass 返回后调用 Track，在消息处理中对收到的事件调用 Resolve
+// 事件可能早于 Track 到达，此时先保留一段时间（默认 10 分钟），之后的 Track 会直接收到该事件
+// 同一 msg_id 可以多次 Track，事件会交付给到达时已登记的每个 channel
+// 关联只在当前进程内有效：多实例部署时事件可能推送到其他实例，需要自行转发或改用 GetMass 查询
+type MassJobs struct {
+	mu        sync.Mutex
+	pending   map[int64][]chan *wechatgo.MassSendJobFinishEvent
+	unclaimed map[int64]unclaimedMassEvent
+	ttl       time.Duration
+}
+
+// unclaimedMassEvent 尚未被 Track 领取的事件
+type unclaimedMassEvent struct {
+	event     *wechatgo.MassSendJobFinishEvent
+	expiresAt time.Time
+}
+
+// MassJobsOption 群发任务关联器选项
+type MassJobsOption func(*MassJobs)
+
+// WithUnclaimedTTL 设置未登记事件的保留时间，小于等于 0 时不保留
+func WithUnclaimedTTL(ttl time.Duration) MassJobsOption {
+	return func(j *MassJobs) {
+		j.ttl = ttl
+	}
+}
+
+// NewMassJobs 创建群发任务关联器
+func NewMassJobs(opts ...MassJobsOption) *MassJobs {
+	j := &MassJobs{
+		pending:   make(map[int64][]chan *wechatgo.MassSendJobFinishEvent),
+		unclaimed: make(map[int64]unclaimedMassEvent),
+		ttl:       defaultUnclaimedTTL,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// Track 登记群发任务，返回的 channel 在收到对应事件后接收一次
+// 事件已先于 Track 到达且未过期时，channel 中已有该事件
+// 每次调用返回独立的 channel，互不影响
+func (j *MassJobs) Track(msgID int64) <-chan *wechatgo.MassSendJobFinishEvent {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ch := make(chan *wechatgo.MassSendJobFinishEvent, 1)
+
+	j.pruneLocked(time.Now())
+	if u, ok := j.unclaimed[msgID]; ok {
+		delete(j.unclaimed, msgID)
+		ch <- u.event
+		return ch
+	}
+	j.pending[msgID] = append(j.pending[msgID], ch)
+	return ch
+}
+
+// Resolve 将事件交付给对应群发任务的所有 channel
+// 未登记的任务返回 false，事件会保留到过期，期间调用 Track 仍可收到
+func (j *MassJobs) Resolve(event *wechatgo.MassSendJobFinishEvent) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	chs, ok := j.pending[event.MsgID]
+	if !ok {
+		now := time.Now()
+		j.pruneLocked(now)
+		if j.ttl > 0 {
+			j.unclaimed[event.MsgID] = unclaimedMassEvent{event: event, expiresAt: now.Add(j.ttl)}
+		}
+		return false
+	}
+	delete(j.pending, event.MsgID)
+	for _, ch := range chs {
+		ch <- event
+	}
+	return true
+}
+
+// pruneLocked 清理过期的未登记事件，调用方需持有 mu
+func (j *MassJobs) pruneLocked(now time.Time) {
+	for msgID, u := range j.unclaimed {
+		if now.After(u.expiresAt) {
+			delete(j.unclaimed, msgID)
+		}
+	}
+}
+
+// Forget 取消登记群发任务，该 msg_id 的所有 channel 都不会再收到事件
+func (j *MassJobs) Forget(msgID int64) {
+	j.mu.Lock()
+	delete(j.pending, msgID)
+	j.mu.Unlock()
+}
+
+// untrack 只取消登记 ch，同一 msg_id 的其他 channel 不受影响
+func (j *MassJobs) untrack(msgID int64, ch <-chan *wechatgo.MassSendJobFinishEvent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	chs := j.pending[msgID]
+	for i, c := range chs {
+		if c == ch {
+			chs = append(chs[:i], chs[i+1:]...)
+			break
+		}
+	}
+	if len(chs) == 0 {
+		delete(j.pending, msgID)
+	} else {
+		j.pending[msgID] = chs
+	}
+}
+
+// Wait 等待群发任务完成，ctx 取消时只取消本次登记并返回 ctx 的错误
+func (j *MassJobs) Wait(ctx context.Context, msgID int64) (*wechatgo.MassSendJobFinishEvent, error) {
+	ch := j.Track(msgID)
+	select {
+	case event := <-ch:
+		return event, nil
+	case <-ctx.Done():
+		j.untrack(msgID, ch)
+		return nil, ctx.Err()
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestMessageMass(t *testing.T) {
+	var sendAll map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/message/mass/sendall":
+			sendAll = req
+			w.Write([]byte(`{"errcode":0,"errmsg":"send job submission success","msg_id":34182,"msg_data_id":206227730}`))
+		case "/message/mass/send":
+			assert.Equal(t, []interface{}{"o1", "o2"}, req["touser"])
+			assert.NotContains(t, req, "send_ignore_reprint")
+			w.Write([]byte(`{"errcode":0,"errmsg":"send job submission success","msg_id":34183}`))
+		case "/message/mass/get":
+			assert.Equal(t, float64(34182), req["msg_id"])
+			w.Write([]byte(`{"msg_id":34182,"msg_status":"SEND_SUCCESS"}`))
+		case "/message/mass/speed/get":
+			w.Write([]byte(`{"speed":3,"realspeed":15}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	result, err := client.Message.SendMass(api.MassRequest{
+		Target:            api.MassToTag(2),
+		Message:           api.NewMassMPNews("MEDIA_ID"),
+		SendIgnoreReprint: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(34182), result.MsgID)
+	assert.Equal(t, float64(1), sendAll["send_ignore_reprint"])
+	assert.Equal(t, map[string]interface{}{"is_to_all": false, "tag_id": float64(2)}, sendAll["filter"])
+	assert.Equal(t, map[string]interface{}{"media_id": "MEDIA_ID"}, sendAll["mpnews"])
+
+	_, err = client.Message.SendMass(api.MassRequest{
+		Target:  api.MassToUsers("o1", "o2"),
+		Message: api.NewMassWxCard("CARD_ID"),
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Message.SendMass(api.MassRequest{Message: api.NewMassText("hi")})
+	assert.Error(t, err)
+
+	status, err := client.Message.GetMass(result.MsgID)
+	assert.NoError(t, err)
+	assert.Equal(t, api.MassStatusSendSuccess, status.MsgStatus)
+
+	speed, err := client.Message.GetMassSpeed()
+	assert.NoError(t, err)
+	assert.Equal(t, 15, speed.RealSpeed)
+	assert.Error(t, client.Message.SetMassSpeed(5))
+
+	jobs := api.NewMassJobs()
+	done := jobs.Track(result.MsgID)
+	assert.False(t, jobs.Resolve(&wechatgo.MassSendJobFinishEvent{MsgID: 1}))
+	assert.True(t, jobs.Resolve(&wechatgo.MassSendJobFinishEvent{MsgID: result.MsgID, Status: "send success"}))
+	assert.Equal(t, "send success", (<-done).Status)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = jobs.Wait(ctx, 34183)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, jobs.Resolve(&wechatgo.MassSendJobFinishEvent{MsgID: 34183}))
+}
+
+func TestMassJobs_EarlyEvent(t *testing.T) {
+	jobs := api.NewMassJobs()
+
+	// 事件先于 Track 到达
+	assert.False(t, jobs.Resolve(&wechatgo.MassSendJobFinishEvent{MsgID: 1000, Status: "send success"}))
+	event, err := jobs.Wait(context.Background(), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, "send success", event.Status)
+
+	// 事件只能领取一次
+	select {
+	case <-jobs.Track(1000):
+		t.Fatal("unclaimed event delivered twice")
+	default:
+	}
+	jobs.Forget(1000)
+
+	// 过期的事件不再交付
+	jobs = api.NewMassJobs(api.WithUnclaimedTTL(time.Millisecond))
+	assert.False(t, jobs.Resolve(&wechatgo.MassSendJobFinishEvent{MsgID: 2000}))
+	time.Sleep(5 * time.Millisecond)
+	select {
+	case <-jobs.Track(2000):
+		t.Fatal("expired event delivered")
+	default:
+	}
+}
+
+func TestMassJobs_MultipleWaiters(t *testing.T) {
+	jobs := api.NewMassJobs()
+
+	first := jobs.Track(3000)
+	second := jobs.Track(3000)
+
+	// 取消一个等待者不影响同一 msg_id 的其他等待者
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := jobs.Wait(ctx, 3000)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.True(t, jobs.Resolve(&wechatgo.MassSendJobFinishEvent{MsgID: 3000, Status: "send success"}))
+	assert.Equal(t, "send success", (<-first).Status)
+	assert.Equal(t, "send success", (<-second).Status)
+}
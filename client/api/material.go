@@ -0,0 +1,337 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// materialBatchCount 批量获取素材列表时每页的最大数量
+const materialBatchCount = 20
+
+// MaterialLimits 各类型永久素材的限制
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Adding_Permanent_Assets.html
+var MaterialLimits = map[string]MediaLimit{
+	MediaTypeImage: MediaLimits[MediaTypeImage],
+	MediaTypeVoice: {MaxSize: 2 << 20, Formats: []string{"mp3", "wma", "wav", "amr"}, SizeErr: MediaLimits[MediaTypeVoice].SizeErr},
+	MediaTypeVideo: MediaLimits[MediaTypeVideo],
+	MediaTypeThumb: MediaLimits[MediaTypeThumb],
+}
+
+// MaterialAPI 永久素材管理 API
+type MaterialAPI struct {
+	*BaseAPI
+	uploader Uploader
+	raw      RawRequester
+}
+
+// NewMaterialAPI 创建永久素材管理 API
+func NewMaterialAPI(client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	UploadStream(url string, params map[string]string, file *UploadFile) (map[string]interface{}, error)
+	RawRequest(ctx context.Context, method, url string, params map[string]string, body io.Reader) (*http.Response, error)
+	GetAccessToken() (string, error)
+}) *MaterialAPI {
+	return &MaterialAPI{
+		BaseAPI:  NewBaseAPI(client),
+		uploader: client,
+		raw:      client,
+	}
+}
+
+// MaterialAddResult 新增永久素材结果
+type MaterialAddResult struct {
+	MediaID string `json:"media_id"`
+	URL     string `json:"url,omitempty"` // 仅图片素材返回
+}
+
+// MaterialNewsItem 永久图文素材中的文章
+type MaterialNewsItem struct {
+	Title              string `json:"title"`
+	ThumbMediaID       string `json:"thumb_media_id"`
+	ThumbURL           string `json:"thumb_url,omitempty"`
+	ShowCoverPic       int    `json:"show_cover_pic"`
+	Author             string `json:"author"`
+	Digest             string `json:"digest"`
+	Content            string `json:"content"`
+	URL                string `json:"url"`
+	ContentSourceURL   string `json:"content_source_url"`
+	NeedOpenComment    int    `json:"need_open_comment,omitempty"`
+	OnlyFansCanComment int    `json:"only_fans_can_comment,omitempty"`
+}
+
+// MaterialVideo 永久视频素材信息
+type MaterialVideo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DownURL     string `json:"down_url"`
+}
+
+// MaterialCount 永久素材总数
+type MaterialCount struct {
+	VoiceCount int `json:"voice_count"`
+	VideoCount int `json:"video_count"`
+	ImageCount int `json:"image_count"`
+	NewsCount  int `json:"news_count"`
+}
+
+// MaterialNewsContent 素材列表中图文素材的内容
+type MaterialNewsContent struct {
+	NewsItem   []MaterialNewsItem `json:"news_item"`
+	CreateTime int64              `json:"create_time"`
+	UpdateTime int64              `json:"update_time"`
+}
+
+// MaterialItem 素材列表中的素材
+// 图文素材返回 Content，其他类型返回 Name 和 URL
+type MaterialItem struct {
+	MediaID    string               `json:"media_id"`
+	Name       string               `json:"name,omitempty"`
+	URL        string               `json:"url,omitempty"`
+	UpdateTime int64                `json:"update_time"`
+	Content    *MaterialNewsContent `json:"content,omitempty"`
+}
+
+// MaterialList 素材列表
+type MaterialList struct {
+	TotalCount int            `json:"total_count"`
+	ItemCount  int            `json:"item_count"`
+	Item       []MaterialItem `json:"item"`
+}
+
+// errVideoRequiresDescription 视频素材必须携带 description 表单字段
+var errVideoRequiresDescription = errors.New("video material requires title and introduction, use AddVideo")
+
+// Add 新增其他类型永久素材（图片、语音、缩略图）
+// 视频素材需要描述信息，请使用 AddVideo
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Adding_Permanent_Assets.html
+func (api *MaterialAPI) Add(mediaType, filePath string, opts ...UploadOption) (*MaterialAddResult, error) {
+	if mediaType == MediaTypeVideo {
+		return nil, errVideoRequiresDescription
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return api.addMaterial(mediaType, filepath.Base(filePath), file, opts...)
+}
+
+// AddReader 新增其他类型永久素材（从Reader）
+func (api *MaterialAPI) AddReader(mediaType, fileName string, reader io.Reader, opts ...UploadOption) (*MaterialAddResult, error) {
+	if mediaType == MediaTypeVideo {
+		return nil, errVideoRequiresDescription
+	}
+	return api.addMaterial(mediaType, fileName, reader, opts...)
+}
+
+// AddVideo 新增永久视频素材
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Adding_Permanent_Assets.html
+func (api *MaterialAPI) AddVideo(filePath, title, introduction string, opts ...UploadOption) (*MaterialAddResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return api.AddVideoReader(filepath.Base(filePath), file, title, introduction, opts...)
+}
+
+// AddVideoReader 新增永久视频素材（从Reader）
+func (api *MaterialAPI) AddVideoReader(fileName string, reader io.Reader, title, introduction string, opts ...UploadOption) (*MaterialAddResult, error) {
+	description, err := json.Marshal(map[string]string{
+		"title":        title,
+		"introduction": introduction,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, WithFormField("description", string(description)))
+	return api.addMaterial(MediaTypeVideo, fileName, reader, opts...)
+}
+
+// addMaterial 内部上传方法
+func (api *MaterialAPI) addMaterial(mediaType, fileName string, reader io.Reader, opts ...UploadOption) (*MaterialAddResult, error) {
+	var limit *MediaLimit
+	if l, ok := MaterialLimits[mediaType]; ok {
+		limit = &l
+	}
+	file, err := newUploadFile(fileName, reader, limit, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := api.uploader.UploadStream("/material/add_material", map[string]string{"type": mediaType}, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var added MaterialAddResult
+	if err := DecodeResult(result, &added); err != nil {
+		return nil, err
+	}
+	return &added, nil
+}
+
+// GetNews 获取永久图文素材
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Getting_Permanent_Assets.html
+func (api *MaterialAPI) GetNews(mediaID string) ([]MaterialNewsItem, error) {
+	result, err := api.Post("/material/get_material", map[string]string{"media_id": mediaID})
+	if err != nil {
+		return nil, err
+	}
+
+	var news struct {
+		NewsItem []MaterialNewsItem `json:"news_item"`
+	}
+	if err := DecodeResult(result, &news); err != nil {
+		return nil, err
+	}
+	if news.NewsItem == nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return news.NewsItem, nil
+}
+
+// GetVideo 获取永久视频素材信息
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Getting_Permanent_Assets.html
+func (api *MaterialAPI) GetVideo(mediaID string) (*MaterialVideo, error) {
+	result, err := api.Post("/material/get_material", map[string]string{"media_id": mediaID})
+	if err != nil {
+		return nil, err
+	}
+
+	var video MaterialVideo
+	if err := DecodeResult(result, &video); err != nil {
+		return nil, err
+	}
+	if video.DownURL == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return &video, nil
+}
+
+// DownloadTo 获取永久素材内容并流式写入 w
+// 适用于图片、语音、缩略图；视频素材会从返回的 down_url 继续下载
+func (api *MaterialAPI) DownloadTo(ctx context.Context, mediaID string, w io.Writer) (*DownloadResult, error) {
+	token, err := api.GetAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]string{"media_id": mediaID})
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{"access_token": token}
+	resp, err := api.raw.RawRequest(ctx, http.MethodPost, "/material/get_material", params, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	content, result, err := sniffResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return copyDownload(resp, content, w)
+	}
+
+	// 图文素材没有文件内容，请使用 GetNews
+	downURL, _ := result["down_url"].(string)
+	if downURL == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return downloadURL(ctx, api.raw, downURL, w)
+}
+
+// Delete 删除永久素材
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Deleting_Permanent_Assets.html
+func (api *MaterialAPI) Delete(mediaID string) error {
+	_, err := api.Post("/material/del_material", map[string]string{"media_id": mediaID})
+	return err
+}
+
+// GetCount 获取永久素材总数
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Get_the_total_of_all_materials.html
+func (api *MaterialAPI) GetCount() (*MaterialCount, error) {
+	result, err := api.BaseAPI.Get("/material/get_materialcount", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var count MaterialCount
+	if err := DecodeResult(result, &count); err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// BatchGet 获取永久素材列表
+// count 取值 1 到 20
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Get_materials_list.html
+func (api *MaterialAPI) BatchGet(mediaType string, offset, count int) (*MaterialList, error) {
+	return api.batchGet(context.Background(), mediaType, offset, count)
+}
+
+func (api *MaterialAPI) batchGet(ctx context.Context, mediaType string, offset, count int) (*MaterialList, error) {
+	data := map[string]interface{}{
+		"type":   mediaType,
+		"offset": offset,
+		"count":  count,
+	}
+	result, err := api.PostContext(ctx, "/material/batchget_material", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list MaterialList
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// All 遍历指定类型的全部永久素材，按需逐页拉取
+// 出错或 ctx 被取消时产生一个错误并结束遍历
+func (api *MaterialAPI) All(ctx context.Context, mediaType string) iter.Seq2[MaterialItem, error] {
+	return func(yield func(MaterialItem, error) bool) {
+		for offset := 0; ; {
+			if err := ctx.Err(); err != nil {
+				yield(MaterialItem{}, err)
+				return
+			}
+
+			list, err := api.batchGet(ctx, mediaType, offset, materialBatchCount)
+			if err != nil {
+				yield(MaterialItem{}, err)
+				return
+			}
+			for _, item := range list.Item {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			offset += len(list.Item)
+			if len(list.Item) == 0 || offset >= list.TotalCount {
+				return
+			}
+		}
+	}
+}
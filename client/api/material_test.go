@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestMaterialAddVideo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/material/add_material", r.URL.Path)
+		assert.Equal(t, "video", r.URL.Query().Get("type"))
+
+		_, header, err := r.FormFile("media")
+		assert.NoError(t, err)
+		assert.Equal(t, "movie.mp4", header.Filename)
+		assert.JSONEq(t, `{"title":"标题","introduction":"简介"}`, r.FormValue("description"))
+		w.Write([]byte(`{"media_id":"video_media_id"}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	result, err := client.Material.AddVideoReader("movie.mp4", bytes.NewReader(make([]byte, 512)), "标题", "简介")
+	assert.NoError(t, err)
+	assert.Equal(t, "video_media_id", result.MediaID)
+}
+
+func TestMaterialAll(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/material/batchget_material", r.URL.Path)
+		var req struct {
+			Type   string `json:"type"`
+			Offset int    `json:"offset"`
+			Count  int    `json:"count"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "image", req.Type)
+		assert.Equal(t, 20, req.Count)
+		calls++
+
+		items := make([]string, 0, req.Count)
+		for i := req.Offset; i < 25 && len(items) < req.Count; i++ {
+			items = append(items, fmt.Sprintf(`{"media_id":"m%d","name":"%d.jpg","update_time":1}`, i, i))
+		}
+		fmt.Fprintf(w, `{"total_count":25,"item_count":%d,"item":[%s]}`, len(items), strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var items []api.MaterialItem
+	for item, err := range client.Material.All(context.Background(), "image") {
+		assert.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Len(t, items, 25)
+	assert.Equal(t, "m24", items[24].MediaID)
+	assert.Equal(t, 2, calls)
+}
+
+func TestMaterialAll_Canceled(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	// ctx 会传递到正在进行的 HTTP 请求
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	var errs []error
+	for _, err := range client.Material.All(ctx, "image") {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], context.Canceled)
+	}
+}
+
+func TestMaterialAdd_Video(t *testing.T) {
+	client := testclient.New("http://127.0.0.1:0", nil)
+
+	_, err := client.Material.AddReader(api.MediaTypeVideo, "video.mp4", bytes.NewReader([]byte("data")))
+	assert.ErrorContains(t, err, "AddVideo")
+	_, err = client.Material.Add(api.MediaTypeVideo, "video.mp4")
+	assert.ErrorContains(t, err, "AddVideo")
+}
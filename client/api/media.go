@@ -2,129 +2,159 @@ package api
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
-
-	"github.com/wechatpy/wechatgo"
 )
 
 // MediaAPI 素材管理 API
 type MediaAPI struct {
 	*BaseAPI
+	uploader Uploader
+	raw      RawRequester
+	rootURL  func(path string) string
 }
 
 // NewMediaAPI 创建素材管理 API
 func NewMediaAPI(client interface {
 	Get(url string, params map[string]string) (map[string]interface{}, error)
 	Post(url string, data interface{}) (map[string]interface{}, error)
-	Upload(url string, fileName string, file io.Reader) (map[string]interface{}, error)
+	UploadStream(url string, params map[string]string, file *UploadFile) (map[string]interface{}, error)
+	RawRequest(ctx context.Context, method, url string, params map[string]string, body io.Reader) (*http.Response, error)
 	GetAccessToken() (string, error)
 }) *MediaAPI {
-	return &MediaAPI{
-		BaseAPI: NewBaseAPI(client),
+	api := &MediaAPI{
+		BaseAPI:  NewBaseAPI(client),
+		uploader: client,
+		raw:      client,
+		rootURL:  func(path string) string { return "https://api.weixin.qq.com" + path },
+	}
+	// 下载地址需要是完整 URL，使用客户端配置的根地址
+	if c, ok := client.(interface{ RootURL(path string) string }); ok {
+		api.rootURL = c.RootURL
 	}
+	return api
 }
 
 // Upload 上传临时素材
 // https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/New_temporary_materials.html
-func (api *MediaAPI) Upload(mediaType, filePath string) (map[string]interface{}, error) {
+func (api *MediaAPI) Upload(mediaType, filePath string, opts ...UploadOption) (map[string]interface{}, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	return api.uploadMedia(mediaType, filepath.Base(filePath), file)
+	return api.uploadMedia(mediaType, filepath.Base(filePath), file, opts...)
 }
 
 // UploadReader 上传临时素材（从Reader）
-func (api *MediaAPI) UploadReader(mediaType, fileName string, reader io.Reader) (map[string]interface{}, error) {
-	return api.uploadMedia(mediaType, fileName, reader)
+// 可通过 WithFileSize 提供大小以便提前校验，未提供时在上传过程中校验
+func (api *MediaAPI) UploadReader(mediaType, fileName string, reader io.Reader, opts ...UploadOption) (map[string]interface{}, error) {
+	return api.uploadMedia(mediaType, fileName, reader, opts...)
 }
 
 // uploadMedia 内部上传方法
-func (api *MediaAPI) uploadMedia(mediaType, fileName string, reader io.Reader) (map[string]interface{}, error) {
-	// 构建multipart表单
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// 添加type字段
-	writer.WriteField("type", mediaType)
-
-	// 添加文件
-	part, err := writer.CreateFormFile("media", fileName)
+func (api *MediaAPI) uploadMedia(mediaType, fileName string, reader io.Reader, opts ...UploadOption) (map[string]interface{}, error) {
+	var limit *MediaLimit
+	if l, ok := MediaLimits[mediaType]; ok {
+		limit = &l
+	}
+	file, err := newUploadFile(fileName, reader, limit, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = io.Copy(part, reader)
-	if err != nil {
+	return api.uploader.UploadStream("/media/upload", map[string]string{"type": mediaType}, file)
+}
+
+// Download 获取临时素材
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Get_temporary_materials.html
+func (api *MediaAPI) Download(mediaID string) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := api.DownloadTo(context.Background(), mediaID, &buf); err != nil {
 		return nil, err
 	}
-	writer.Close()
+	return buf.Bytes(), nil
+}
 
-	// 构建URL
+// DownloadTo 获取临时素材并流式写入 w
+// 接口以 JSON 返回错误时返回 *wechatgo.ClientError；视频素材返回的是下载地址，会继续从该地址下载
+func (api *MediaAPI) DownloadTo(ctx context.Context, mediaID string, w io.Writer) (*DownloadResult, error) {
 	token, err := api.GetAccessToken()
 	if err != nil {
 		return nil, err
 	}
-	url := fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/media/upload?access_token=%s&type=%s", token, mediaType)
 
-	// 发送请求
-	resp, err := api.httpClient.Post(url)
+	params := map[string]string{
+		"access_token": token,
+		"media_id":     mediaID,
+	}
+	resp, err := api.raw.RawRequest(ctx, http.MethodGet, "/media/get", params, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// 解析响应
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed: %s", resp.Status)
+	}
+	body, result, err := sniffResponse(resp)
+	if err != nil {
 		return nil, err
 	}
-
-	// 检查错误
-	if errcode, ok := result["errcode"]; ok {
-		errcodeInt := int(errcode.(float64))
-		if errcodeInt != 0 {
-			errmsg := ""
-			if msg, ok := result["errmsg"]; ok {
-				errmsg = msg.(string)
-			}
-			return nil, wechatgo.NewError(errcodeInt, errmsg)
-		}
+	if result == nil {
+		return copyDownload(resp, body, w)
 	}
 
-	return result, nil
+	videoURL, _ := result["video_url"].(string)
+	if videoURL == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return downloadURL(ctx, api.raw, videoURL, w)
 }
 
-// Download 获取临时素材
-// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Get_temporary_materials.html
-func (api *MediaAPI) Download(mediaID string) ([]byte, error) {
-	token, err := api.GetAccessToken()
+// downloadURL 从接口返回的下载地址获取内容，该地址无需 access_token
+func downloadURL(ctx context.Context, raw RawRequester, url string, w io.Writer) (*DownloadResult, error) {
+	resp, err := raw.RawRequest(ctx, http.MethodGet, url, nil, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	url := fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/media/get?access_token=%s&media_id=%s", token, mediaID)
-
-	resp, err := api.httpClient.GetRaw(url)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed: %s", resp.Status)
+	}
+	body, result, err := sniffResponse(resp)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if result != nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return copyDownload(resp, body, w)
+}
 
-	return io.ReadAll(resp.Body)
+// copyDownload 将响应内容写入 w 并记录结果
+func copyDownload(resp *http.Response, body io.Reader, w io.Writer) (*DownloadResult, error) {
+	result := newDownloadResult(resp)
+	n, err := io.Copy(w, body)
+	result.Size = n
+	if err != nil {
+		return result, err
+	}
+	return result, nil
 }
 
-// GetURL 获取临时素材下载地址
+// GetURL 获取临时素材下载地址，地址位于客户端配置的 API 根地址下
 func (api *MediaAPI) GetURL(mediaID string) string {
 	token, _ := api.GetAccessToken()
-	return fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/media/get?access_token=%s&media_id=%s", token, mediaID)
+	query := url.Values{"access_token": {token}, "media_id": {mediaID}}
+	return api.rootURL("/cgi-bin/media/get") + "?" + query.Encode()
 }
 
 // UploadVideo 上传视频（用于群发视频消息）
@@ -158,14 +188,14 @@ func (api *MediaAPI) UploadArticles(articles []Article) (map[string]interface{},
 
 // UploadImage 上传群发消息内的图片
 // https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/Adding_Permanent_Assets.html
-func (api *MediaAPI) UploadImage(filePath string) (string, error) {
+func (api *MediaAPI) UploadImage(filePath string, opts ...UploadOption) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", err
 	}
 	defer file.Close()
 
-	result, err := api.uploadImage(filepath.Base(filePath), file)
+	result, err := api.uploadImage(filepath.Base(filePath), file, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -178,8 +208,8 @@ func (api *MediaAPI) UploadImage(filePath string) (string, error) {
 }
 
 // UploadImageReader 上传群发消息内的图片（从Reader）
-func (api *MediaAPI) UploadImageReader(fileName string, reader io.Reader) (string, error) {
-	result, err := api.uploadImage(fileName, reader)
+func (api *MediaAPI) UploadImageReader(fileName string, reader io.Reader, opts ...UploadOption) (string, error) {
+	result, err := api.uploadImage(fileName, reader, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -192,54 +222,12 @@ func (api *MediaAPI) UploadImageReader(fileName string, reader io.Reader) (strin
 }
 
 // uploadImage 内部上传图片方法
-func (api *MediaAPI) uploadImage(fileName string, reader io.Reader) (map[string]interface{}, error) {
-	// 构建multipart表单
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// 添加文件
-	part, err := writer.CreateFormFile("media", fileName)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = io.Copy(part, reader)
-	if err != nil {
-		return nil, err
-	}
-	writer.Close()
-
-	// 构建URL
-	token, err := api.GetAccessToken()
+func (api *MediaAPI) uploadImage(fileName string, reader io.Reader, opts ...UploadOption) (map[string]interface{}, error) {
+	limit := uploadImgLimit
+	file, err := newUploadFile(fileName, reader, &limit, opts...)
 	if err != nil {
 		return nil, err
 	}
-	url := fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/media/uploadimg?access_token=%s", token)
-
-	// 发送请求
-	resp, err := api.httpClient.Post(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
 
-	// 解析响应
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	// 检查错误
-	if errcode, ok := result["errcode"]; ok {
-		errcodeInt := int(errcode.(float64))
-		if errcodeInt != 0 {
-			errmsg := ""
-			if msg, ok := result["errmsg"]; ok {
-				errmsg = msg.(string)
-			}
-			return nil, wechatgo.NewError(errcodeInt, errmsg)
-		}
-	}
-
-	return result, nil
+	return api.uploader.UploadStream("/media/uploadimg", nil, file)
 }
@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestMediaDownloadTo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/media/get", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("access_token"))
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Content-Disposition", `attachment; filename="photo.jpg"`)
+		w.Write(make([]byte, 2048))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var buf bytes.Buffer
+	result, err := client.Media.DownloadTo(context.Background(), "media_id", &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "photo.jpg", result.FileName)
+	assert.Equal(t, "image/jpeg", result.ContentType)
+	assert.Equal(t, int64(2048), result.Size)
+	assert.Equal(t, 2048, buf.Len())
+}
+
+func TestMediaDownloadTo_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(`{"errcode":40007,"errmsg":"invalid media_id rid: 1234-5678"}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var buf bytes.Buffer
+	_, err := client.Media.DownloadTo(context.Background(), "bad_media_id", &buf)
+	assert.True(t, errors.Is(err, wechatgo.ErrInvalidMediaID))
+
+	var clientErr *wechatgo.ClientError
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, "/media/get", clientErr.Endpoint)
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestMediaDownloadTo_Video(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/media/get":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"video_url":"` + server.URL + `/video.mp4"}`))
+		case "/video.mp4":
+			assert.Empty(t, r.URL.Query().Get("access_token"))
+			w.Header().Set("Content-Type", "video/mp4")
+			w.Write([]byte("video content"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	data, err := client.Media.Download("video_media_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "video content", string(data))
+}
+
+func TestMediaDownloadTo_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>502 Bad Gateway</html>"))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var buf bytes.Buffer
+	_, err := client.Media.DownloadTo(context.Background(), "media_id", &buf)
+	assert.ErrorContains(t, err, "502")
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestMediaGetURL(t *testing.T) {
+	c := testclient.New("http://proxy.example.com/cgi-bin/", nil)
+	assert.Equal(t, "http://proxy.example.com/cgi-bin/media/get?access_token=token&media_id=media_id", c.Media.GetURL("media_id"))
+
+	c = testclient.New("http://proxy.example.com/cgi-bin/", nil, client.WithRootURL("https://root.example.com"))
+	assert.Equal(t, "https://root.example.com/cgi-bin/media/get?access_token=token&media_id=media_id", c.Media.GetURL("media_id"))
+}
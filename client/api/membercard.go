@@ -0,0 +1,200 @@
+package api
+
+import (
+	"github.com/wechatpy/wechatgo"
+)
+
+// 富文本字段类型
+const (
+	FormFieldTypeRadio  = "FORM_FIELD_RADIO"     // 单选
+	FormFieldTypeSelect = "FORM_FIELD_SELECT"    // 选择项
+	FormFieldTypeCheck  = "FORM_FIELD_CHECK_BOX" // 多选
+)
+
+// ActivateFormLink 开卡页面中的链接
+type ActivateFormLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ActivateRichField 开卡表单中的选择项字段
+type ActivateRichField struct {
+	Type   string   `json:"type"`
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// ActivateForm 开卡表单字段
+// CommonFieldIDList 使用 wechatgo.UserFormInfoFlag 常量，CustomFieldList 为自定义文本字段名称
+type ActivateForm struct {
+	CanModify         bool                        `json:"can_modify"`
+	CommonFieldIDList []wechatgo.UserFormInfoFlag `json:"common_field_id_list,omitempty"`
+	CustomFieldList   []string                    `json:"custom_field_list,omitempty"`
+	RichFieldList     []ActivateRichField         `json:"rich_field_list,omitempty"`
+}
+
+// ActivateUserForm 会员卡一键开卡表单
+type ActivateUserForm struct {
+	CardID           string            `json:"card_id"`
+	ServiceStatement *ActivateFormLink `json:"service_statement,omitempty"`
+	BindOldCard      *ActivateFormLink `json:"bind_old_card,omitempty"`
+	RequiredForm     *ActivateForm     `json:"required_form,omitempty"`
+	OptionalForm     *ActivateForm     `json:"optional_form,omitempty"`
+}
+
+// SetActivateUserForm 设置会员卡一键开卡表单
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Membership_Cards/Create_a_membership_card.html
+func (api *CardAPI) SetActivateUserForm(form ActivateUserForm) error {
+	_, err := api.post("/card/membercard/activateuserform/set", form)
+	return err
+}
+
+// MemberCardActivation 激活会员卡请求
+// 初始积分和余额使用指针，以便区分未设置和设置为 0
+type MemberCardActivation struct {
+	MembershipNumber      string `json:"membership_number"`
+	Code                  string `json:"code"`
+	CardID                string `json:"card_id,omitempty"`
+	BackgroundPicURL      string `json:"background_pic_url,omitempty"`
+	ActivateBeginTime     int64  `json:"activate_begin_time,omitempty"`
+	ActivateEndTime       int64  `json:"activate_end_time,omitempty"`
+	InitBonus             *int   `json:"init_bonus,omitempty"`
+	InitBonusRecord       string `json:"init_bonus_record,omitempty"`
+	InitBalance           *int   `json:"init_balance,omitempty"`
+	InitCustomFieldValue1 string `json:"init_custom_field_value1,omitempty"`
+	InitCustomFieldValue2 string `json:"init_custom_field_value2,omitempty"`
+	InitCustomFieldValue3 string `json:"init_custom_field_value3,omitempty"`
+}
+
+// ActivateMemberCard 激活会员卡
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Membership_Cards/Create_a_membership_card.html
+func (api *CardAPI) ActivateMemberCard(activation MemberCardActivation) error {
+	_, err := api.post("/card/membercard/activate", activation)
+	return err
+}
+
+// MemberCardField 会员信息字段
+type MemberCardField struct {
+	Name      string   `json:"name"`
+	Value     string   `json:"value"`
+	ValueList []string `json:"value_list,omitempty"`
+}
+
+// MemberCardUserInfo 会员卡用户信息
+// CommonFieldList 中的 Name 为 wechatgo.UserFormInfoFlag 常量的值
+type MemberCardUserInfo struct {
+	OpenID           string `json:"openid"`
+	Nickname         string `json:"nickname"`
+	MembershipNumber string `json:"membership_number"`
+	Bonus            int    `json:"bonus"`
+	Balance          int    `json:"balance"`
+	Sex              string `json:"sex"`
+	UserInfo         struct {
+		CommonFieldList []MemberCardField `json:"common_field_list"`
+		CustomFieldList []MemberCardField `json:"custom_field_list"`
+	} `json:"user_info"`
+	UserCardStatus string `json:"user_card_status"`
+	HasActive      bool   `json:"has_active"`
+}
+
+// Field 返回开卡时填写的指定字段值，未填写时返回空字符串
+func (info *MemberCardUserInfo) Field(flag wechatgo.UserFormInfoFlag) string {
+	for _, field := range info.UserInfo.CommonFieldList {
+		if field.Name == string(flag) {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// GetMemberCardUserInfo 拉取会员信息，包括积分、余额和开卡时填写的资料
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Membership_Cards/Manage_Member_Card.html
+func (api *CardAPI) GetMemberCardUserInfo(cardID, code string) (*MemberCardUserInfo, error) {
+	result, err := api.post("/card/membercard/userinfo/get", map[string]string{
+		"card_id": cardID,
+		"code":    code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info MemberCardUserInfo
+	if err := DecodeResult(result, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// MemberCardNotify 更新会员信息时是否向用户推送变更通知
+type MemberCardNotify struct {
+	IsNotifyBonus        bool `json:"is_notify_bonus"`
+	IsNotifyBalance      bool `json:"is_notify_balance"`
+	IsNotifyCustomField1 bool `json:"is_notify_custom_field1"`
+	IsNotifyCustomField2 bool `json:"is_notify_custom_field2"`
+	IsNotifyCustomField3 bool `json:"is_notify_custom_field3"`
+}
+
+// MemberCardUpdate 更新会员信息请求
+// Bonus/Balance 为设置后的全量值，AddBonus/AddBalance 为变动值（可为负数），两者选其一
+type MemberCardUpdate struct {
+	Code              string            `json:"code"`
+	CardID            string            `json:"card_id"`
+	BackgroundPicURL  string            `json:"background_pic_url,omitempty"`
+	Bonus             *int              `json:"bonus,omitempty"`
+	AddBonus          *int              `json:"add_bonus,omitempty"`
+	RecordBonus       string            `json:"record_bonus,omitempty"`
+	Balance           *int              `json:"balance,omitempty"`
+	AddBalance        *int              `json:"add_balance,omitempty"`
+	RecordBalance     string            `json:"record_balance,omitempty"`
+	CustomFieldValue1 string            `json:"custom_field_value1,omitempty"`
+	CustomFieldValue2 string            `json:"custom_field_value2,omitempty"`
+	CustomFieldValue3 string            `json:"custom_field_value3,omitempty"`
+	NotifyOptional    *MemberCardNotify `json:"notify_optional,omitempty"`
+}
+
+// MemberCardUpdateResult 更新会员信息结果
+type MemberCardUpdateResult struct {
+	ResultBonus   int    `json:"result_bonus"`
+	ResultBalance int    `json:"result_balance"`
+	OpenID        string `json:"openid"`
+}
+
+// UpdateMemberCardUser 更新会员积分、余额等信息
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Membership_Cards/Manage_Member_Card.html
+func (api *CardAPI) UpdateMemberCardUser(update MemberCardUpdate) (*MemberCardUpdateResult, error) {
+	result, err := api.post("/card/membercard/updateuser", update)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated MemberCardUpdateResult
+	if err := DecodeResult(result, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// ActivateTempInfo 用户在开卡页面填写但尚未提交激活的资料
+type ActivateTempInfo struct {
+	Info struct {
+		CommonFieldList []MemberCardField `json:"common_field_list"`
+		CustomFieldList []MemberCardField `json:"custom_field_list"`
+	} `json:"info"`
+}
+
+// GetActivateTempInfo 使用跳转型开卡时 URL 中的 activate_ticket 获取用户填写的资料
+// https://developers.weixin.qq.com/doc/offiaccount/Cards_and_Offer/Membership_Cards/Create_a_membership_card.html
+func (api *CardAPI) GetActivateTempInfo(activateTicket string) (*ActivateTempInfo, error) {
+	result, err := api.post("/card/membercard/activatetempinfo/get", map[string]string{
+		"activate_ticket": activateTicket,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info ActivateTempInfo
+	if err := DecodeResult(result, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
@@ -0,0 +1,80 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestCardMemberCard(t *testing.T) {
+	var bonuses []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/card/membercard/activateuserform/set":
+			assert.Equal(t, []interface{}{"USER_FORM_INFO_FLAG_MOBILE", "USER_FORM_INFO_FLAG_BIRTHDAY"},
+				req["required_form"].(map[string]interface{})["common_field_id_list"])
+			assert.NotContains(t, req, "optional_form")
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		case "/card/membercard/activate":
+			assert.Equal(t, float64(0), req["init_bonus"])
+			assert.NotContains(t, req, "init_balance")
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		case "/card/membercard/userinfo/get":
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","openid":"obLatjjwDolFjRRd3doGIdwNqRXw","nickname":"saky","membership_number":"1","bonus":100,"sex":"MALE","user_info":{"common_field_list":[{"name":"USER_FORM_INFO_FLAG_MOBILE","value":"15802055555"}],"custom_field_list":[{"name":"兴趣","value_list":["钢琴"]}]},"user_card_status":"NORMAL","has_active":true}`))
+		case "/card/membercard/updateuser":
+			bonuses = append(bonuses, req["add_bonus"].(float64))
+			fmt.Fprintf(w, `{"errcode":0,"errmsg":"ok","result_bonus":%v,"result_balance":0,"openid":"oFS7Fjl0WsZ9AMZqrI80nbIq8xrA"}`, 100+req["add_bonus"].(float64))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	err := client.Card.SetActivateUserForm(api.ActivateUserForm{
+		CardID: "pbLatjtZ7v1BG_ZnTjbW85GYc_E8",
+		RequiredForm: &api.ActivateForm{
+			CanModify:         false,
+			CommonFieldIDList: []wechatgo.UserFormInfoFlag{wechatgo.UserFormInfoFlagMobile, wechatgo.UserFormInfoFlagBirthday},
+		},
+	})
+	assert.NoError(t, err)
+
+	zero := 0
+	err = client.Card.ActivateMemberCard(api.MemberCardActivation{
+		MembershipNumber: "1",
+		Code:             "018255396048",
+		InitBonus:        &zero,
+	})
+	assert.NoError(t, err)
+
+	info, err := client.Card.GetMemberCardUserInfo("pbLatjtZ7v1BG_ZnTjbW85GYc_E8", "018255396048")
+	assert.NoError(t, err)
+	assert.Equal(t, 100, info.Bonus)
+	assert.True(t, info.HasActive)
+	assert.Equal(t, "15802055555", info.Field(wechatgo.UserFormInfoFlagMobile))
+	assert.Equal(t, []string{"钢琴"}, info.UserInfo.CustomFieldList[0].ValueList)
+
+	// 复用同一个请求结构体，修改指针指向的值后应发送新值
+	addBonus := 10
+	update := api.MemberCardUpdate{Code: "018255396048", CardID: "pbLatjtZ7v1BG_ZnTjbW85GYc_E8", AddBonus: &addBonus, RecordBonus: "消费"}
+	result, err := client.Card.UpdateMemberCardUser(update)
+	assert.NoError(t, err)
+	assert.Equal(t, 110, result.ResultBonus)
+
+	addBonus = -5
+	result, err = client.Card.UpdateMemberCardUser(update)
+	assert.NoError(t, err)
+	assert.Equal(t, 95, result.ResultBonus)
+	assert.Equal(t, []float64{10, -5}, bonuses)
+}
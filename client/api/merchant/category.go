@@ -2,28 +2,102 @@ package merchant
 
 import (
 	"fmt"
+	"strconv"
 )
 
+// Category 商品分类
+type Category struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryValue SKU 或属性的可选值
+type CategoryValue struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategorySKU 分类下的 SKU 属性
+type CategorySKU struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	ValueList []CategoryValue `json:"value_list"`
+}
+
+// CategoryProperty 分类下的商品属性
+type CategoryProperty struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	PropertyValue []CategoryValue `json:"property_value"`
+}
+
 // CategoryAPI 分类相关API
 type CategoryAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewCategoryAPI 创建分类API
-func NewCategoryAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *CategoryAPI {
+func NewCategoryAPI(client Client) *CategoryAPI {
 	return &CategoryAPI{
 		BaseAPI: client,
 	}
 }
 
-// GetCategory 获取分类
+// GetSubCategories 获取指定分类的所有子分类，根分类 ID 为 1
+func (api *CategoryAPI) GetSubCategories(categoryID int64) ([]Category, error) {
+	result, err := post(api.BaseAPI, "/merchant/category/getsub", map[string]int64{"cate_id": categoryID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		CateList []Category `json:"cate_list"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.CateList, nil
+}
+
+// GetCategory 获取指定分类的所有子分类，返回原始响应
+//
+// Deprecated: 使用 GetSubCategories
 func (api *CategoryAPI) GetCategory(categoryID string) (map[string]interface{}, error) {
-	// TODO: 实现获取分类逻辑
-	return nil, fmt.Errorf("not implemented")
+	id, err := strconv.ParseInt(categoryID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category id %q: %w", categoryID, err)
+	}
+	return post(api.BaseAPI, "/merchant/category/getsub", map[string]int64{"cate_id": id})
+}
+
+// GetSKUs 获取指定子分类的所有 SKU
+func (api *CategoryAPI) GetSKUs(categoryID int64) ([]CategorySKU, error) {
+	result, err := post(api.BaseAPI, "/merchant/category/getsku", map[string]int64{"cate_id": categoryID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		SKUTable []CategorySKU `json:"sku_table"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.SKUTable, nil
+}
+
+// GetProperties 获取指定分类的所有属性
+func (api *CategoryAPI) GetProperties(categoryID int64) ([]CategoryProperty, error) {
+	result, err := post(api.BaseAPI, "/merchant/category/getproperty", map[string]int64{"cate_id": categoryID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Properties []CategoryProperty `json:"properties"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Properties, nil
 }
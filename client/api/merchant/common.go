@@ -1,29 +1,66 @@
 package merchant
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wechatpy/wechatgo"
 )
 
 // CommonAPI 通用API
 type CommonAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewCommonAPI 创建通用API
-func NewCommonAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *CommonAPI {
+func NewCommonAPI(client Client) *CommonAPI {
 	return &CommonAPI{
 		BaseAPI: client,
 	}
 }
 
-// GetMerchantInfo 获取商户信息
+// UploadImage 上传图片，返回可用于商品和货架的图片 URL
+// 图片内容直接作为请求体发送，而不是 multipart 表单
+func (api *CommonAPI) UploadImage(ctx context.Context, fileName string, image io.Reader) (string, error) {
+	token, err := api.BaseAPI.GetAccessToken()
+	if err != nil {
+		return "", err
+	}
+
+	params := map[string]string{
+		"access_token": token,
+		"filename":     fileName,
+	}
+	resp, err := api.BaseAPI.RawRequest(ctx, http.MethodPost, api.BaseAPI.RootURL("/merchant/common/upload_img"), params, image)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	if err := wechatgo.CheckResponse(result, resp, body); err != nil {
+		return "", err
+	}
+
+	if imageURL, ok := result["image_url"].(string); ok {
+		return imageURL, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// GetMerchantInfo 获取商户信息，小店接口没有对应的接口，总是返回错误
+//
+// Deprecated: 小店接口不提供商户信息
 func (api *CommonAPI) GetMerchantInfo() (map[string]interface{}, error) {
-	// TODO: 实现获取商户信息逻辑
-	return nil, fmt.Errorf("not implemented")
+	return nil, fmt.Errorf("merchant info is not provided by the merchant API")
 }
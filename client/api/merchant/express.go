@@ -4,26 +4,131 @@ import (
 	"fmt"
 )
 
+// 邮费模板支付方
+const (
+	ExpressAssumerBuyer  = 0 // 买家承担运费
+	ExpressAssumerSeller = 1 // 卖家承担运费
+)
+
+// 邮费模板计费方式
+const (
+	ExpressValuationByItem = 0 // 按件计费
+)
+
+// 邮费模板快递类型
+const (
+	ExpressTypePost    = 10000027 // 平邮
+	ExpressTypeExpress = 10000028 // 快递
+	ExpressTypeEMS     = 10000029 // EMS
+)
+
+// ExpressFee 邮费计费规则，费用单位为分
+type ExpressFee struct {
+	StartStandards int `json:"StartStandards"`
+	StartFees      int `json:"StartFees"`
+	AddStandards   int `json:"AddStandards"`
+	AddFees        int `json:"AddFees"`
+}
+
+// ExpressCustomFee 指定地区的邮费计费规则
+type ExpressCustomFee struct {
+	ExpressFee
+	DestCountry  string `json:"DestCountry"`
+	DestProvince string `json:"DestProvince"`
+	DestCity     string `json:"DestCity"`
+}
+
+// ExpressTopFee 某一快递类型的邮费设置
+type ExpressTopFee struct {
+	Type   int                `json:"Type"`
+	Normal ExpressFee         `json:"Normal"`
+	Custom []ExpressCustomFee `json:"Custom,omitempty"`
+}
+
+// ExpressTemplate 邮费模板
+type ExpressTemplate struct {
+	ID        int64           `json:"Id,omitempty"`
+	Name      string          `json:"Name"`
+	Assumer   int             `json:"Assumer"`
+	Valuation int             `json:"Valuation"`
+	TopFee    []ExpressTopFee `json:"TopFee"`
+}
+
 // ExpressAPI 快递相关API
 type ExpressAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewExpressAPI 创建快递API
-func NewExpressAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *ExpressAPI {
+func NewExpressAPI(client Client) *ExpressAPI {
 	return &ExpressAPI{
 		BaseAPI: client,
 	}
 }
 
-// GetExpressTemplates 获取快递模板
-func (api *ExpressAPI) GetExpressTemplates() ([]map[string]interface{}, error) {
-	// TODO: 实现获取快递模板逻辑
-	return nil, fmt.Errorf("not implemented")
+// AddTemplate 增加邮费模板，返回模板 ID
+func (api *ExpressAPI) AddTemplate(template ExpressTemplate) (int64, error) {
+	template.ID = 0
+	result, err := post(api.BaseAPI, "/merchant/express/add", map[string]interface{}{
+		"delivery_template": template,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if templateID, ok := result["template_id"].(float64); ok {
+		return int64(templateID), nil
+	}
+	return 0, fmt.Errorf("unexpected response format")
+}
+
+// DeleteTemplate 删除邮费模板
+func (api *ExpressAPI) DeleteTemplate(templateID int64) error {
+	_, err := post(api.BaseAPI, "/merchant/express/del", map[string]int64{"template_id": templateID})
+	return err
+}
+
+// UpdateTemplate 修改邮费模板
+func (api *ExpressAPI) UpdateTemplate(templateID int64, template ExpressTemplate) error {
+	template.ID = 0
+	_, err := post(api.BaseAPI, "/merchant/express/update", map[string]interface{}{
+		"template_id":       templateID,
+		"delivery_template": template,
+	})
+	return err
+}
+
+// GetExpressTemplate 获取指定 ID 的邮费模板
+func (api *ExpressAPI) GetExpressTemplate(templateID int64) (*ExpressTemplate, error) {
+	result, err := post(api.BaseAPI, "/merchant/express/getbyid", map[string]int64{"template_id": templateID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		TemplateInfo *ExpressTemplate `json:"template_info"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	if resp.TemplateInfo == nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return resp.TemplateInfo, nil
+}
+
+// GetExpressTemplates 获取所有邮费模板
+func (api *ExpressAPI) GetExpressTemplates() ([]ExpressTemplate, error) {
+	result, err := get(api.BaseAPI, "/merchant/express/getall")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		TemplatesInfo []ExpressTemplate `json:"templates_info"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.TemplatesInfo, nil
 }
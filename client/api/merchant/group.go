@@ -4,26 +4,107 @@ import (
 	"fmt"
 )
 
+// 分组商品修改操作
+const (
+	GroupProductDelete = 0 // 从分组中删除
+	GroupProductAdd    = 1 // 添加到分组
+)
+
+// Group 商品分组
+type Group struct {
+	GroupID     int64    `json:"group_id"`
+	GroupName   string   `json:"group_name"`
+	ProductList []string `json:"product_list,omitempty"`
+}
+
+// GroupProductMod 分组商品修改项，ModAction 使用 GroupProduct 常量
+type GroupProductMod struct {
+	ProductID string `json:"product_id"`
+	ModAction int    `json:"mod_action"`
+}
+
 // GroupAPI 分组相关API
 type GroupAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewGroupAPI 创建分组API
-func NewGroupAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *GroupAPI {
+func NewGroupAPI(client Client) *GroupAPI {
 	return &GroupAPI{
 		BaseAPI: client,
 	}
 }
 
-// GetGroups 获取分组列表
-func (api *GroupAPI) GetGroups() ([]map[string]interface{}, error) {
-	// TODO: 实现获取分组列表逻辑
-	return nil, fmt.Errorf("not implemented")
+// AddGroup 增加分组，返回分组 ID
+func (api *GroupAPI) AddGroup(name string, productIDs []string) (int64, error) {
+	result, err := post(api.BaseAPI, "/merchant/group/add", map[string]interface{}{
+		"group_detail": Group{GroupName: name, ProductList: productIDs},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if groupID, ok := result["group_id"].(float64); ok {
+		return int64(groupID), nil
+	}
+	return 0, fmt.Errorf("unexpected response format")
+}
+
+// DeleteGroup 删除分组
+func (api *GroupAPI) DeleteGroup(groupID int64) error {
+	_, err := post(api.BaseAPI, "/merchant/group/del", map[string]int64{"group_id": groupID})
+	return err
+}
+
+// RenameGroup 修改分组名称
+func (api *GroupAPI) RenameGroup(groupID int64, name string) error {
+	_, err := post(api.BaseAPI, "/merchant/group/propertymod", map[string]interface{}{
+		"group_id":   groupID,
+		"group_name": name,
+	})
+	return err
+}
+
+// UpdateGroupProducts 修改分组中的商品
+func (api *GroupAPI) UpdateGroupProducts(groupID int64, products []GroupProductMod) error {
+	_, err := post(api.BaseAPI, "/merchant/group/productmod", map[string]interface{}{
+		"group_id": groupID,
+		"product":  products,
+	})
+	return err
+}
+
+// GetGroup 获取分组信息及分组中的商品
+func (api *GroupAPI) GetGroup(groupID int64) (*Group, error) {
+	result, err := post(api.BaseAPI, "/merchant/group/getbyid", map[string]int64{"group_id": groupID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		GroupDetail *Group `json:"group_detail"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	if resp.GroupDetail == nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return resp.GroupDetail, nil
+}
+
+// GetGroups 获取所有分组，返回的分组不包含商品列表
+func (api *GroupAPI) GetGroups() ([]Group, error) {
+	result, err := get(api.BaseAPI, "/merchant/group/getall")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		GroupsDetail []Group `json:"groups_detail"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.GroupsDetail, nil
 }
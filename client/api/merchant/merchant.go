@@ -0,0 +1,59 @@
+package merchant
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/wechatpy/wechatgo/client/api"
+)
+
+// Client 小店 API 依赖的客户端接口
+// 小店接口位于 https://api.weixin.qq.com/merchant 下，需要通过 RootURL 构建地址
+type Client interface {
+	Get(url string, params map[string]string) (map[string]interface{}, error)
+	Post(url string, data interface{}) (map[string]interface{}, error)
+	GetAccessToken() (string, error)
+	RootURL(path string) string
+	RawRequest(ctx context.Context, method, url string, params map[string]string, body io.Reader) (*http.Response, error)
+}
+
+// API 微信小店 API
+type API struct {
+	Product  *ProductAPI
+	Category *CategoryAPI
+	Stock    *StockAPI
+	Order    *OrderAPI
+	Shelf    *ShelfAPI
+	Express  *ExpressAPI
+	Group    *GroupAPI
+	Common   *CommonAPI
+}
+
+// NewAPI 创建微信小店 API
+func NewAPI(client Client) *API {
+	return &API{
+		Product:  NewProductAPI(client),
+		Category: NewCategoryAPI(client),
+		Stock:    NewStockAPI(client),
+		Order:    NewOrderAPI(client),
+		Shelf:    NewShelfAPI(client),
+		Express:  NewExpressAPI(client),
+		Group:    NewGroupAPI(client),
+		Common:   NewCommonAPI(client),
+	}
+}
+
+// get 调用小店 GET 接口
+func get(client Client, path string) (map[string]interface{}, error) {
+	return client.Get(client.RootURL(path), nil)
+}
+
+// decodeResult 将响应解码到结构体
+// 接收者名 api 会遮蔽包名，这里引用 api.DecodeResult 供各文件使用
+var decodeResult = api.DecodeResult
+
+// post 调用小店 POST 接口
+func post(client Client, path string, data interface{}) (map[string]interface{}, error) {
+	return client.Post(client.RootURL(path), data)
+}
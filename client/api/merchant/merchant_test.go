@@ -0,0 +1,172 @@
+package merchant_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api/merchant"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestMerchant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.URL.Query().Get("access_token"))
+		if r.URL.Path == "/merchant/common/upload_img" {
+			assert.Equal(t, "test.png", r.URL.Query().Get("filename"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "PNGDATA", string(body))
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","image_url":"http://mmbiz.qpic.cn/test"}`))
+			return
+		}
+		if r.Method == http.MethodGet {
+			switch r.URL.Path {
+			case "/merchant/group/getall":
+				w.Write([]byte(`{"errcode":0,"errmsg":"success","groups_detail":[{"group_id":200077549,"group_name":"新品上架"}]}`))
+			case "/merchant/shelf/getall":
+				w.Write([]byte(`{"errcode":0,"errmsg":"success","shelves":[{"shelf_info":{"module_infos":[{"group_info":{"group_id":200080093,"filter":{"count":2}},"eid":1}]},"shelf_banner":"banner","shelf_name":"货架","shelf_id":22}]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+			return
+		}
+
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/merchant/create":
+			assert.NotContains(t, req, "product_id")
+			base := req["product_base"].(map[string]interface{})
+			assert.Equal(t, "testaddproduct", base["name"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","product_id":"pDF3iYwktI7INGsPeNnW"}`))
+		case "/merchant/get":
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","product_info":{"product_id":"pDF3iYwktI7INGsPeNnW","product_base":{"name":"testaddproduct","category_id":["537074298"]},"sku_list":[{"sku_id":"1075741873:1079742386","price":30,"ori_price":9000000,"quantity":800}],"status":2}}`))
+		case "/merchant/stock/reduce":
+			assert.Equal(t, float64(5), req["quantity"])
+			assert.Equal(t, "1075741873:1079742386", req["sku_info"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success"}`))
+		case "/merchant/category/getsku":
+			assert.Equal(t, float64(537074298), req["cate_id"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","sku_table":[{"id":"1075741873","name":"颜色","value_list":[{"id":"1079742375","name":"撞色"}]}]}`))
+		case "/merchant/order/getbyfilter":
+			assert.Equal(t, float64(2), req["status"])
+			assert.NotContains(t, req, "begintime")
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","order_list":[{"order_id":"7197417460812533543","order_status":2,"order_total_price":6,"product_sku":"10000983:10000995"}]}`))
+		case "/merchant/order/setdelivery":
+			assert.Equal(t, float64(1), req["need_delivery"])
+			assert.Equal(t, "007shunfeng", req["delivery_company"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success"}`))
+		case "/merchant/express/add":
+			template := req["delivery_template"].(map[string]interface{})
+			assert.NotContains(t, template, "Id")
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","template_id":123456}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	productID, err := client.Merchant.Product.Create(merchant.Product{
+		ProductBase: merchant.ProductBase{Name: "testaddproduct", CategoryID: []string{"537074298"}},
+		SKUList:     []merchant.ProductSKU{{SKUID: "1075741873:1079742386", Price: 30, Quantity: 800}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "pDF3iYwktI7INGsPeNnW", productID)
+
+	product, err := client.Merchant.Product.Get(productID)
+	assert.NoError(t, err)
+	assert.Equal(t, merchant.ProductStatusOffShelf, product.Status)
+	assert.Equal(t, 800, product.SKUList[0].Quantity)
+
+	assert.NoError(t, client.Merchant.Stock.ModifyStock(productID, "1075741873:1079742386", -5))
+	assert.NoError(t, client.Merchant.Stock.ModifyStock(productID, "", 0))
+
+	skus, err := client.Merchant.Category.GetSKUs(537074298)
+	assert.NoError(t, err)
+	assert.Equal(t, "撞色", skus[0].ValueList[0].Name)
+
+	orders, err := client.Merchant.Order.GetOrders(merchant.OrderStatusToDeliver, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "7197417460812533543", orders[0].OrderID)
+
+	err = client.Merchant.Order.SetDelivery(merchant.OrderDelivery{
+		OrderID:         orders[0].OrderID,
+		DeliveryCompany: merchant.DeliverySF,
+		DeliveryTrackNo: "1900659372473",
+		NeedDelivery:    true,
+	})
+	assert.NoError(t, err)
+
+	templateID, err := client.Merchant.Express.AddTemplate(merchant.ExpressTemplate{ID: 1, Name: "测试模板"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123456), templateID)
+
+	groups, err := client.Merchant.Group.GetGroups()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200077549), groups[0].GroupID)
+
+	shelves, err := client.Merchant.Shelf.GetShelves()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(22), shelves[0].ShelfID)
+	assert.Equal(t, 2, shelves[0].ShelfData.ModuleInfos[0].GroupInfo.Filter.Count)
+
+	imageURL, err := client.Merchant.Common.UploadImage(context.Background(), "test.png", strings.NewReader("PNGDATA"))
+	assert.NoError(t, err)
+	assert.Equal(t, "http://mmbiz.qpic.cn/test", imageURL)
+}
+
+func TestMerchantDeprecated(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/merchant/category/getsub":
+			assert.Equal(t, float64(1), req["cate_id"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","cate_list":[{"id":"537074292","name":"数码相机"}]}`))
+		case "/merchant/stock/add":
+			assert.Equal(t, float64(3), req["quantity"])
+			assert.Equal(t, "", req["sku_info"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success"}`))
+		case "/merchant/get":
+			w.Write([]byte(`{"errcode":0,"errmsg":"success","product_info":{"product_id":"pid","sku_list":[{"sku_id":"","quantity":10}]}}`))
+		case "/merchant/order/close":
+			assert.Equal(t, "oid", req["order_id"])
+			w.Write([]byte(`{"errcode":0,"errmsg":"success"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	result, err := client.Merchant.Category.GetCategory("1")
+	assert.NoError(t, err)
+	assert.Contains(t, result, "cate_list")
+	_, err = client.Merchant.Category.GetCategory("abc")
+	assert.Error(t, err)
+
+	assert.NoError(t, client.Merchant.Stock.UpdateStock("pid", 3))
+	info, err := client.Merchant.Stock.GetStockInfo("pid")
+	assert.NoError(t, err)
+	assert.Contains(t, info, "product_info")
+
+	assert.NoError(t, client.Merchant.Order.UpdateOrderStatus("oid", "close"))
+	assert.Error(t, client.Merchant.Order.UpdateOrderStatus("oid", "delivered"))
+
+	_, err = client.Merchant.Common.GetMerchantInfo()
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"/merchant/category/getsub", "/merchant/stock/add", "/merchant/get", "/merchant/order/close"}, paths)
+}
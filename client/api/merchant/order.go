@@ -2,34 +2,160 @@ package merchant
 
 import (
 	"fmt"
+	"time"
 )
 
+// 订单状态
+const (
+	OrderStatusAll       = 0 // 全部
+	OrderStatusToDeliver = 2 // 待发货
+	OrderStatusDelivered = 3 // 已发货
+	OrderStatusFinished  = 5 // 已完成
+	OrderStatusRefunding = 8 // 维权中
+)
+
+// 常用快递公司 ID，其他快递公司设置 IsOthers 后直接填写名称
+const (
+	DeliveryEMS   = "Fsearch_code"
+	DeliverySTO   = "002shentong"
+	DeliveryZTO   = "003zhongtong"
+	DeliveryYTO   = "004yuantong"
+	DeliveryTTK   = "005tiantian"
+	DeliverySF    = "007shunfeng"
+	DeliveryYunDa = "008yunda"
+	DeliveryZJS   = "009zhaijisong"
+)
+
+// Order 订单，金额单位为分
+type Order struct {
+	OrderID           string `json:"order_id"`
+	OrderStatus       int    `json:"order_status"`
+	OrderTotalPrice   int    `json:"order_total_price"`
+	OrderCreateTime   int64  `json:"order_create_time"`
+	OrderExpressPrice int    `json:"order_express_price"`
+	BuyerOpenID       string `json:"buyer_openid"`
+	BuyerNick         string `json:"buyer_nick"`
+	ReceiverName      string `json:"receiver_name"`
+	ReceiverProvince  string `json:"receiver_province"`
+	ReceiverCity      string `json:"receiver_city"`
+	ReceiverZone      string `json:"receiver_zone"`
+	ReceiverAddress   string `json:"receiver_address"`
+	ReceiverMobile    string `json:"receiver_mobile"`
+	ReceiverPhone     string `json:"receiver_phone"`
+	ProductID         string `json:"product_id"`
+	ProductName       string `json:"product_name"`
+	ProductPrice      int    `json:"product_price"`
+	ProductSKU        string `json:"product_sku"`
+	ProductCount      int    `json:"product_count"`
+	ProductImg        string `json:"product_img"`
+	DeliveryID        string `json:"delivery_id"`
+	DeliveryCompany   string `json:"delivery_company"`
+	TransID           string `json:"trans_id"`
+}
+
+// OrderDelivery 订单发货信息
+// NeedDelivery 为 false 时表示无需物流（如虚拟商品），此时忽略快递信息
+type OrderDelivery struct {
+	OrderID         string
+	DeliveryCompany string
+	DeliveryTrackNo string
+	NeedDelivery    bool
+	IsOthers        bool
+}
+
 // OrderAPI 订单相关API
 type OrderAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewOrderAPI 创建订单API
-func NewOrderAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *OrderAPI {
+func NewOrderAPI(client Client) *OrderAPI {
 	return &OrderAPI{
 		BaseAPI: client,
 	}
 }
 
 // GetOrder 获取订单详情
-func (api *OrderAPI) GetOrder(orderID string) (map[string]interface{}, error) {
-	// TODO: 实现获取订单详情逻辑
-	return nil, fmt.Errorf("not implemented")
+func (api *OrderAPI) GetOrder(orderID string) (*Order, error) {
+	result, err := post(api.BaseAPI, "/merchant/order/getbyid", map[string]string{"order_id": orderID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Order *Order `json:"order"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Order == nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return resp.Order, nil
+}
+
+// GetOrders 根据订单状态和创建时间获取订单列表
+// status 使用 OrderStatus 常量，begin/end 为零值时不限制
+func (api *OrderAPI) GetOrders(status int, begin, end time.Time) ([]Order, error) {
+	data := map[string]interface{}{}
+	if status != OrderStatusAll {
+		data["status"] = status
+	}
+	if !begin.IsZero() {
+		data["begintime"] = begin.Unix()
+	}
+	if !end.IsZero() {
+		data["endtime"] = end.Unix()
+	}
+
+	result, err := post(api.BaseAPI, "/merchant/order/getbyfilter", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		OrderList []Order `json:"order_list"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.OrderList, nil
 }
 
-// UpdateOrderStatus 更新订单状态
+// SetDelivery 设置订单发货信息，订单状态变为已发货
+func (api *OrderAPI) SetDelivery(delivery OrderDelivery) error {
+	data := map[string]interface{}{
+		"order_id":      delivery.OrderID,
+		"need_delivery": boolToInt(delivery.NeedDelivery),
+	}
+	if delivery.NeedDelivery {
+		data["delivery_company"] = delivery.DeliveryCompany
+		data["delivery_track_no"] = delivery.DeliveryTrackNo
+		data["is_others"] = boolToInt(delivery.IsOthers)
+	}
+	_, err := post(api.BaseAPI, "/merchant/order/setdelivery", data)
+	return err
+}
+
+// Close 关闭订单
+func (api *OrderAPI) Close(orderID string) error {
+	_, err := post(api.BaseAPI, "/merchant/order/close", map[string]string{"order_id": orderID})
+	return err
+}
+
+// UpdateOrderStatus 修改订单状态，仅支持关闭订单（status 为 "close"）
+//
+// Deprecated: 使用 Close 关闭订单，使用 SetDelivery 发货
 func (api *OrderAPI) UpdateOrderStatus(orderID, status string) error {
-	// TODO: 实现更新订单状态逻辑
-	return fmt.Errorf("not implemented")
+	if status != "close" {
+		return fmt.Errorf("unsupported order status %q, use SetDelivery or Close", status)
+	}
+	return api.Close(orderID)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
 }
@@ -0,0 +1,182 @@
+package merchant
+
+import (
+	"fmt"
+)
+
+// 商品状态
+const (
+	ProductStatusAll      = 0 // 全部
+	ProductStatusOnShelf  = 1 // 上架
+	ProductStatusOffShelf = 2 // 下架
+)
+
+// ProductProperty 商品属性，ID 和 VID 分别为属性 ID 和属性值 ID
+type ProductProperty struct {
+	ID  string `json:"id"`
+	VID string `json:"vid"`
+}
+
+// ProductSKUInfo 商品可选的 SKU 属性及属性值
+type ProductSKUInfo struct {
+	ID  string   `json:"id"`
+	VID []string `json:"vid"`
+}
+
+// ProductDetail 商品详情，每一项为一段文字或一张图片
+type ProductDetail struct {
+	Text string `json:"text,omitempty"`
+	Img  string `json:"img,omitempty"`
+}
+
+// ProductBase 商品基本属性
+type ProductBase struct {
+	CategoryID []string          `json:"category_id"`
+	Property   []ProductProperty `json:"property,omitempty"`
+	Name       string            `json:"name"`
+	SKUInfo    []ProductSKUInfo  `json:"sku_info,omitempty"`
+	MainImg    string            `json:"main_img"`
+	Img        []string          `json:"img,omitempty"`
+	Detail     []ProductDetail   `json:"detail,omitempty"`
+	BuyLimit   int               `json:"buy_limit,omitempty"`
+}
+
+// ProductSKU 商品 SKU，价格单位为分
+// SKUID 格式为 "属性ID:属性值ID;属性ID:属性值ID"，无 SKU 的商品为空字符串
+type ProductSKU struct {
+	SKUID       string `json:"sku_id"`
+	Price       int    `json:"price"`
+	IconURL     string `json:"icon_url,omitempty"`
+	ProductCode string `json:"product_code,omitempty"`
+	OriPrice    int    `json:"ori_price"`
+	Quantity    int    `json:"quantity"`
+}
+
+// ProductLocation 商品所在地
+type ProductLocation struct {
+	Country  string `json:"country"`
+	Province string `json:"province"`
+	City     string `json:"city"`
+	Address  string `json:"address"`
+}
+
+// ProductAttrExt 商品其他属性
+type ProductAttrExt struct {
+	Location         *ProductLocation `json:"location,omitempty"`
+	IsPostFree       int              `json:"isPostFree"`
+	IsHasReceipt     int              `json:"isHasReceipt"`
+	IsUnderGuaranty  int              `json:"isUnderGuaranty"`
+	IsSupportReplace int              `json:"isSupportReplace"`
+}
+
+// ProductExpress 商品运费，ID 为快递公司 ID，价格单位为分
+type ProductExpress struct {
+	ID    int64 `json:"id"`
+	Price int   `json:"price"`
+}
+
+// ProductDeliveryInfo 商品运费信息
+// DeliveryType 为 0 时使用 Express 中的运费，为 1 时使用 TemplateID 对应的邮费模板
+type ProductDeliveryInfo struct {
+	DeliveryType int              `json:"delivery_type"`
+	TemplateID   int64            `json:"template_id"`
+	Express      []ProductExpress `json:"express,omitempty"`
+}
+
+// Product 商品
+type Product struct {
+	ProductID    string               `json:"product_id,omitempty"`
+	ProductBase  ProductBase          `json:"product_base"`
+	SKUList      []ProductSKU         `json:"sku_list"`
+	AttrExt      *ProductAttrExt      `json:"attrext,omitempty"`
+	DeliveryInfo *ProductDeliveryInfo `json:"delivery_info,omitempty"`
+	Status       int                  `json:"status,omitempty"`
+}
+
+// ProductAPI 商品相关API
+type ProductAPI struct {
+	BaseAPI Client
+}
+
+// NewProductAPI 创建商品API
+func NewProductAPI(client Client) *ProductAPI {
+	return &ProductAPI{
+		BaseAPI: client,
+	}
+}
+
+// Create 增加商品，返回商品 ID
+func (api *ProductAPI) Create(product Product) (string, error) {
+	product.ProductID = ""
+	product.Status = 0
+	result, err := post(api.BaseAPI, "/merchant/create", product)
+	if err != nil {
+		return "", err
+	}
+
+	if productID, ok := result["product_id"].(string); ok {
+		return productID, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// Delete 删除商品
+func (api *ProductAPI) Delete(productID string) error {
+	_, err := post(api.BaseAPI, "/merchant/del", map[string]string{"product_id": productID})
+	return err
+}
+
+// Update 修改商品，product.ProductID 不能为空
+// 上架中的商品只能修改部分字段，详见微信小店接口文档
+func (api *ProductAPI) Update(product Product) error {
+	if product.ProductID == "" {
+		return fmt.Errorf("product_id is required")
+	}
+	product.Status = 0
+	_, err := post(api.BaseAPI, "/merchant/update", product)
+	return err
+}
+
+// Get 查询商品
+func (api *ProductAPI) Get(productID string) (*Product, error) {
+	result, err := post(api.BaseAPI, "/merchant/get", map[string]string{"product_id": productID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		ProductInfo *Product `json:"product_info"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ProductInfo == nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return resp.ProductInfo, nil
+}
+
+// GetByStatus 获取指定状态的所有商品，status 使用 ProductStatus 常量
+func (api *ProductAPI) GetByStatus(status int) ([]Product, error) {
+	result, err := post(api.BaseAPI, "/merchant/getbystatus", map[string]int{"status": status})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		ProductsInfo []Product `json:"products_info"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	return resp.ProductsInfo, nil
+}
+
+// UpdateStatus 商品上下架，status 为 ProductStatusOnShelf 或 ProductStatusOffShelf
+func (api *ProductAPI) UpdateStatus(productID string, status int) error {
+	_, err := post(api.BaseAPI, "/merchant/modproductstatus", map[string]interface{}{
+		"product_id": productID,
+		"status":     status,
+	})
+	return err
+}
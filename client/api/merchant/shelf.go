@@ -4,26 +4,136 @@ import (
 	"fmt"
 )
 
+// ShelfGroupFilter 货架控件展示的商品数量
+type ShelfGroupFilter struct {
+	Count int `json:"count"`
+}
+
+// ShelfGroupInfo 货架控件中的分组
+// 控件 1 需设置 Filter，控件 3 需设置 Img，控件 2、4 只需 GroupID
+type ShelfGroupInfo struct {
+	GroupID int64             `json:"group_id"`
+	Filter  *ShelfGroupFilter `json:"filter,omitempty"`
+	Img     string            `json:"img,omitempty"`
+}
+
+// ShelfGroupInfos 多分组货架控件
+type ShelfGroupInfos struct {
+	Groups        []ShelfGroupInfo `json:"groups"`
+	ImgBackground string           `json:"img_background,omitempty"`
+}
+
+// ShelfModule 货架控件，EID 为控件编号 1-5
+// 控件 1、3 使用 GroupInfo，控件 2、4、5 使用 GroupInfos
+type ShelfModule struct {
+	EID        int              `json:"eid"`
+	GroupInfo  *ShelfGroupInfo  `json:"group_info,omitempty"`
+	GroupInfos *ShelfGroupInfos `json:"group_infos,omitempty"`
+}
+
+// ShelfData 货架内容
+type ShelfData struct {
+	ModuleInfos []ShelfModule `json:"module_infos"`
+}
+
+// Shelf 货架
+type Shelf struct {
+	ShelfID     int64     `json:"shelf_id,omitempty"`
+	ShelfName   string    `json:"shelf_name"`
+	ShelfBanner string    `json:"shelf_banner"`
+	ShelfData   ShelfData `json:"shelf_data"`
+}
+
+// shelfResponse 查询接口返回的货架使用 shelf_info 字段
+type shelfResponse struct {
+	ShelfID     int64     `json:"shelf_id"`
+	ShelfName   string    `json:"shelf_name"`
+	ShelfBanner string    `json:"shelf_banner"`
+	ShelfInfo   ShelfData `json:"shelf_info"`
+}
+
+func (s shelfResponse) shelf() Shelf {
+	return Shelf{
+		ShelfID:     s.ShelfID,
+		ShelfName:   s.ShelfName,
+		ShelfBanner: s.ShelfBanner,
+		ShelfData:   s.ShelfInfo,
+	}
+}
+
 // ShelfAPI 货架相关API
 type ShelfAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewShelfAPI 创建货架API
-func NewShelfAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *ShelfAPI {
+func NewShelfAPI(client Client) *ShelfAPI {
 	return &ShelfAPI{
 		BaseAPI: client,
 	}
 }
 
-// GetShelves 获取货架列表
-func (api *ShelfAPI) GetShelves() ([]map[string]interface{}, error) {
-	// TODO: 实现获取货架列表逻辑
-	return nil, fmt.Errorf("not implemented")
+// AddShelf 增加货架，返回货架 ID
+func (api *ShelfAPI) AddShelf(shelf Shelf) (int64, error) {
+	shelf.ShelfID = 0
+	result, err := post(api.BaseAPI, "/merchant/shelf/add", shelf)
+	if err != nil {
+		return 0, err
+	}
+
+	if shelfID, ok := result["shelf_id"].(float64); ok {
+		return int64(shelfID), nil
+	}
+	return 0, fmt.Errorf("unexpected response format")
+}
+
+// DeleteShelf 删除货架
+func (api *ShelfAPI) DeleteShelf(shelfID int64) error {
+	_, err := post(api.BaseAPI, "/merchant/shelf/del", map[string]int64{"shelf_id": shelfID})
+	return err
+}
+
+// UpdateShelf 修改货架，shelf.ShelfID 不能为空
+func (api *ShelfAPI) UpdateShelf(shelf Shelf) error {
+	if shelf.ShelfID == 0 {
+		return fmt.Errorf("shelf_id is required")
+	}
+	_, err := post(api.BaseAPI, "/merchant/shelf/mod", shelf)
+	return err
+}
+
+// GetShelf 获取指定 ID 的货架
+func (api *ShelfAPI) GetShelf(shelfID int64) (*Shelf, error) {
+	result, err := post(api.BaseAPI, "/merchant/shelf/getbyid", map[string]int64{"shelf_id": shelfID})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp shelfResponse
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+	shelf := resp.shelf()
+	return &shelf, nil
+}
+
+// GetShelves 获取所有货架
+func (api *ShelfAPI) GetShelves() ([]Shelf, error) {
+	result, err := get(api.BaseAPI, "/merchant/shelf/getall")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Shelves []shelfResponse `json:"shelves"`
+	}
+	if err := decodeResult(result, &resp); err != nil {
+		return nil, err
+	}
+
+	shelves := make([]Shelf, 0, len(resp.Shelves))
+	for _, s := range resp.Shelves {
+		shelves = append(shelves, s.shelf())
+	}
+	return shelves, nil
 }
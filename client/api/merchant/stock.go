@@ -1,35 +1,58 @@
 package merchant
 
-import (
-	"fmt"
-)
-
 // StockAPI 库存相关API
 type StockAPI struct {
-	BaseAPI interface {
-		Post(url string, data interface{}) (map[string]interface{}, error)
-		Get(url string, params map[string]string) (map[string]interface{}, error)
-	}
+	BaseAPI Client
 }
 
 // NewStockAPI 创建库存API
-func NewStockAPI(client interface {
-	Post(url string, data interface{}) (map[string]interface{}, error)
-	Get(url string, params map[string]string) (map[string]interface{}, error)
-}) *StockAPI {
+func NewStockAPI(client Client) *StockAPI {
 	return &StockAPI{
 		BaseAPI: client,
 	}
 }
 
-// GetStockInfo 获取库存信息
-func (api *StockAPI) GetStockInfo(productID string) (map[string]interface{}, error) {
-	// TODO: 实现获取库存信息逻辑
-	return nil, fmt.Errorf("not implemented")
+// AddStock 增加库存，skuInfo 为 ProductSKU.SKUID，无 SKU 的商品传空字符串
+func (api *StockAPI) AddStock(productID, skuInfo string, quantity int) error {
+	return api.modify("/merchant/stock/add", productID, skuInfo, quantity)
 }
 
-// UpdateStock 更新库存
+// ReduceStock 减少库存
+func (api *StockAPI) ReduceStock(productID, skuInfo string, quantity int) error {
+	return api.modify("/merchant/stock/reduce", productID, skuInfo, quantity)
+}
+
+// ModifyStock 按变动量修改库存，delta 为正数时增加、负数时减少，为 0 时不发送请求
+func (api *StockAPI) ModifyStock(productID, skuInfo string, delta int) error {
+	switch {
+	case delta > 0:
+		return api.AddStock(productID, skuInfo, delta)
+	case delta < 0:
+		return api.ReduceStock(productID, skuInfo, -delta)
+	default:
+		return nil
+	}
+}
+
+// UpdateStock 按变动量修改无 SKU 商品的库存
+//
+// Deprecated: 使用 ModifyStock(productID, "", delta)
 func (api *StockAPI) UpdateStock(productID string, quantity int) error {
-	// TODO: 实现更新库存逻辑
-	return fmt.Errorf("not implemented")
+	return api.ModifyStock(productID, "", quantity)
+}
+
+// GetStockInfo 获取商品信息，库存位于返回的 sku_list 中
+//
+// Deprecated: 使用 ProductAPI.Get
+func (api *StockAPI) GetStockInfo(productID string) (map[string]interface{}, error) {
+	return post(api.BaseAPI, "/merchant/get", map[string]string{"product_id": productID})
+}
+
+func (api *StockAPI) modify(path, productID, skuInfo string, quantity int) error {
+	_, err := post(api.BaseAPI, path, map[string]interface{}{
+		"product_id": productID,
+		"sku_info":   skuInfo,
+		"quantity":   quantity,
+	})
+	return err
 }
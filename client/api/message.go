@@ -18,15 +18,13 @@ func NewMessageAPI(client interface {
 	}
 }
 
-// SendText 发送文本消息
+// sendCustom 发送客服消息，kfAccount 不为空时以指定客服帐号发送
 // https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Service_Center_messages.html
-func (api *MessageAPI) SendText(openID, content string, kfAccount string) (map[string]interface{}, error) {
+func (api *MessageAPI) sendCustom(openID, msgType string, body interface{}, kfAccount string) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"touser":  openID,
-		"msgtype": "text",
-		"text": map[string]string{
-			"content": content,
-		},
+		"msgtype": msgType,
+		msgType:   body,
 	}
 
 	if kfAccount != "" {
@@ -38,42 +36,26 @@ func (api *MessageAPI) SendText(openID, content string, kfAccount string) (map[s
 	return api.Post("/message/custom/send", data)
 }
 
+// SendText 发送文本消息
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Service_Center_messages.html
+func (api *MessageAPI) SendText(openID, content string, kfAccount string) (map[string]interface{}, error) {
+	return api.sendCustom(openID, "text", map[string]string{
+		"content": content,
+	}, kfAccount)
+}
+
 // SendImage 发送图片消息
 func (api *MessageAPI) SendImage(openID, mediaID string, kfAccount string) (map[string]interface{}, error) {
-	data := map[string]interface{}{
-		"touser":  openID,
-		"msgtype": "image",
-		"image": map[string]string{
-			"media_id": mediaID,
-		},
-	}
-
-	if kfAccount != "" {
-		data["customservice"] = map[string]string{
-			"kf_account": kfAccount,
-		}
-	}
-
-	return api.Post("/message/custom/send", data)
+	return api.sendCustom(openID, "image", map[string]string{
+		"media_id": mediaID,
+	}, kfAccount)
 }
 
 // SendVoice 发送语音消息
 func (api *MessageAPI) SendVoice(openID, mediaID string, kfAccount string) (map[string]interface{}, error) {
-	data := map[string]interface{}{
-		"touser":  openID,
-		"msgtype": "voice",
-		"voice": map[string]string{
-			"media_id": mediaID,
-		},
-	}
-
-	if kfAccount != "" {
-		data["customservice"] = map[string]string{
-			"kf_account": kfAccount,
-		}
-	}
-
-	return api.Post("/message/custom/send", data)
+	return api.sendCustom(openID, "voice", map[string]string{
+		"media_id": mediaID,
+	}, kfAccount)
 }
 
 // SendVideo 发送视频消息
@@ -91,19 +73,7 @@ func (api *MessageAPI) SendVideo(openID, mediaID, thumbMediaID, title, descripti
 		video["description"] = description
 	}
 
-	data := map[string]interface{}{
-		"touser":  openID,
-		"msgtype": "video",
-		"video":   video,
-	}
-
-	if kfAccount != "" {
-		data["customservice"] = map[string]string{
-			"kf_account": kfAccount,
-		}
-	}
-
-	return api.Post("/message/custom/send", data)
+	return api.sendCustom(openID, "video", video, kfAccount)
 }
 
 // NewsArticle 图文消息文章
@@ -114,23 +84,112 @@ type NewsArticle struct {
 	PicURL      string `json:"picurl"`
 }
 
-// SendNews 发送图文消息
+// SendNews 发送图文消息（点击跳转到外链）
 func (api *MessageAPI) SendNews(openID string, articles []NewsArticle, kfAccount string) (map[string]interface{}, error) {
-	data := map[string]interface{}{
-		"touser":  openID,
-		"msgtype": "news",
-		"news": map[string]interface{}{
-			"articles": articles,
-		},
+	return api.sendCustom(openID, "news", map[string]interface{}{
+		"articles": articles,
+	}, kfAccount)
+}
+
+// SendMPNews 发送图文消息（点击跳转到图文消息页面），mediaID 为草稿或素材的 media_id
+func (api *MessageAPI) SendMPNews(openID, mediaID string, kfAccount string) (map[string]interface{}, error) {
+	return api.sendCustom(openID, "mpnews", map[string]string{
+		"media_id": mediaID,
+	}, kfAccount)
+}
+
+// SendMPNewsArticle 发送已发布的图文消息，articleID 为发布接口返回的 article_id
+func (api *MessageAPI) SendMPNewsArticle(openID, articleID string, kfAccount string) (map[string]interface{}, error) {
+	return api.sendCustom(openID, "mpnewsarticle", map[string]string{
+		"article_id": articleID,
+	}, kfAccount)
+}
+
+// Music 音乐消息
+type Music struct {
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	MusicURL     string `json:"musicurl"`
+	HQMusicURL   string `json:"hqmusicurl"`
+	ThumbMediaID string `json:"thumb_media_id"`
+}
+
+// SendMusic 发送音乐消息
+func (api *MessageAPI) SendMusic(openID string, music Music, kfAccount string) (map[string]interface{}, error) {
+	return api.sendCustom(openID, "music", music, kfAccount)
+}
+
+// MsgMenuItem 菜单消息选项
+type MsgMenuItem struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+}
+
+// MsgMenu 菜单消息
+// 用户点击选项后会以文本消息回复选项内容，消息中的 BizMsgMenuID 为选项 ID
+type MsgMenu struct {
+	HeadContent string        `json:"head_content"`
+	List        []MsgMenuItem `json:"list"`
+	TailContent string        `json:"tail_content"`
+}
+
+// NewMsgMenu 创建菜单消息
+func NewMsgMenu(headContent, tailContent string) *MsgMenu {
+	return &MsgMenu{
+		HeadContent: headContent,
+		TailContent: tailContent,
 	}
+}
 
-	if kfAccount != "" {
-		data["customservice"] = map[string]string{
-			"kf_account": kfAccount,
-		}
+// AddItem 添加菜单选项
+func (m *MsgMenu) AddItem(id, content string) *MsgMenu {
+	m.List = append(m.List, MsgMenuItem{ID: id, Content: content})
+	return m
+}
+
+// SendMsgMenu 发送菜单消息
+func (api *MessageAPI) SendMsgMenu(openID string, menu *MsgMenu, kfAccount string) (map[string]interface{}, error) {
+	if menu == nil || len(menu.List) == 0 {
+		return nil, fmt.Errorf("msgmenu requires at least one item")
 	}
+	return api.sendCustom(openID, "msgmenu", *menu, kfAccount)
+}
 
-	return api.Post("/message/custom/send", data)
+// SendWxCard 发送卡券，卡券需已通过审核
+func (api *MessageAPI) SendWxCard(openID, cardID string, kfAccount string) (map[string]interface{}, error) {
+	return api.sendCustom(openID, "wxcard", map[string]string{
+		"card_id": cardID,
+	}, kfAccount)
+}
+
+// MiniProgramPage 小程序卡片消息，小程序需已关联公众号
+type MiniProgramPage struct {
+	Title        string `json:"title"`
+	AppID        string `json:"appid"`
+	PagePath     string `json:"pagepath"`
+	ThumbMediaID string `json:"thumb_media_id"`
+}
+
+// SendMiniProgramPage 发送小程序卡片消息
+func (api *MessageAPI) SendMiniProgramPage(openID string, page MiniProgramPage, kfAccount string) (map[string]interface{}, error) {
+	return api.sendCustom(openID, "miniprogrampage", page, kfAccount)
+}
+
+// 客服输入状态
+const (
+	TypingCommandTyping       = "Typing"       // 正在输入
+	TypingCommandCancelTyping = "CancelTyping" // 取消正在输入
+)
+
+// Typing 下发客服输入状态，command 使用 TypingCommand 常量
+// 正在输入状态持续 15 秒，发送客服消息后会自动取消
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Service_Center_messages.html
+func (api *MessageAPI) Typing(openID, command string) error {
+	_, err := api.Post("/message/custom/typing", map[string]string{
+		"touser":  openID,
+		"command": command,
+	})
+	return err
 }
 
 // DeleteMass 删除群发消息
@@ -193,6 +252,8 @@ func (api *MessageAPI) sendMassMessage(tagOrUsers interface{}, msgType string, m
 
 // SendMassText 群发文本消息
 // https://mp.weixin.qq.com/wiki?id=mp1481187827_i0l21
+//
+// Deprecated: 使用 SendMass 或 PreviewMass，它们接受类型化的群发对象并返回 msg_id
 func (api *MessageAPI) SendMassText(content string, tagOrUsers interface{}, isToAll, preview bool, sendIgnoreReprint int, clientMsgID *string) (map[string]interface{}, error) {
 	msg := map[string]interface{}{
 		"text": map[string]string{
@@ -204,6 +265,8 @@ func (api *MessageAPI) SendMassText(content string, tagOrUsers interface{}, isTo
 
 // SendMassImage 群发图片消息
 // https://mp.weixin.qq.com/wiki?id=mp1481187827_i0l21
+//
+// Deprecated: 使用 SendMass 或 PreviewMass，它们接受类型化的群发对象并返回 msg_id
 func (api *MessageAPI) SendMassImage(mediaID string, tagOrUsers interface{}, isToAll, preview bool, sendIgnoreReprint int, clientMsgID *string) (map[string]interface{}, error) {
 	msg := map[string]interface{}{
 		"image": map[string]string{
@@ -215,6 +278,8 @@ func (api *MessageAPI) SendMassImage(mediaID string, tagOrUsers interface{}, isT
 
 // SendMassVoice 群发语音消息
 // https://mp.weixin.qq.com/wiki?id=mp1481187827_i0l21
+//
+// Deprecated: 使用 SendMass 或 PreviewMass，它们接受类型化的群发对象并返回 msg_id
 func (api *MessageAPI) SendMassVoice(mediaID string, tagOrUsers interface{}, isToAll, preview bool, sendIgnoreReprint int, clientMsgID *string) (map[string]interface{}, error) {
 	msg := map[string]interface{}{
 		"voice": map[string]string{
@@ -226,6 +291,8 @@ func (api *MessageAPI) SendMassVoice(mediaID string, tagOrUsers interface{}, isT
 
 // SendMassVideo 群发视频消息
 // https://mp.weixin.qq.com/wiki?id=mp1481187827_i0l21
+//
+// Deprecated: 使用 SendMass 或 PreviewMass，它们接受类型化的群发对象并返回 msg_id
 func (api *MessageAPI) SendMassVideo(mediaID string, tagOrUsers interface{}, isToAll, preview bool, sendIgnoreReprint int, clientMsgID *string) (map[string]interface{}, error) {
 	msg := map[string]interface{}{
 		"mpvideo": map[string]string{
@@ -237,6 +304,8 @@ func (api *MessageAPI) SendMassVideo(mediaID string, tagOrUsers interface{}, isT
 
 // SendMassNews 群发图文消息
 // https://mp.weixin.qq.com/wiki?id=mp1481187827_i0l21
+//
+// Deprecated: 使用 SendMass 或 PreviewMass，它们接受类型化的群发对象并返回 msg_id
 func (api *MessageAPI) SendMassNews(mediaID string, tagOrUsers interface{}, isToAll, preview bool, sendIgnoreReprint int, clientMsgID *string) (map[string]interface{}, error) {
 	msg := map[string]interface{}{
 		"mpnews": map[string]string{
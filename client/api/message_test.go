@@ -0,0 +1,60 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestMessageCustomSend(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/message/custom/send", "/message/custom/typing":
+			req["path"] = r.URL.Path
+			requests = append(requests, req)
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	menu := api.NewMsgMenu("您对本次服务是否满意呢?", "欢迎再次光临").
+		AddItem("101", "满意").
+		AddItem("102", "不满意")
+	_, err := client.Message.SendMsgMenu("openid", menu, "test1@kftest")
+	assert.NoError(t, err)
+	_, err = client.Message.SendMPNewsArticle("openid", "ARTICLE_ID", "")
+	assert.NoError(t, err)
+	_, err = client.Message.SendMiniProgramPage("openid", api.MiniProgramPage{
+		Title:        "title",
+		AppID:        "appid",
+		PagePath:     "pages/index",
+		ThumbMediaID: "thumb",
+	}, "")
+	assert.NoError(t, err)
+	assert.NoError(t, client.Message.Typing("openid", api.TypingCommandTyping))
+
+	_, err = client.Message.SendMsgMenu("openid", api.NewMsgMenu("head", "tail"), "")
+	assert.Error(t, err)
+
+	assert.Len(t, requests, 4)
+	assert.Equal(t, "msgmenu", requests[0]["msgtype"])
+	assert.Len(t, requests[0]["msgmenu"].(map[string]interface{})["list"], 2)
+	assert.Equal(t, map[string]interface{}{"kf_account": "test1@kftest"}, requests[0]["customservice"])
+	assert.Equal(t, map[string]interface{}{"article_id": "ARTICLE_ID"}, requests[1]["mpnewsarticle"])
+	assert.NotContains(t, requests[1], "customservice")
+	assert.Equal(t, "pages/index", requests[2]["miniprogrampage"].(map[string]interface{})["pagepath"])
+	assert.Equal(t, "/message/custom/typing", requests[3]["path"])
+	assert.Equal(t, "Typing", requests[3]["command"])
+}
@@ -1,5 +1,12 @@
 package api
 
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
 // POIAPI 门店管理 API
 type POIAPI struct {
 	*BaseAPI
@@ -34,11 +41,15 @@ func (api *POIAPI) Get(poiID string) (map[string]interface{}, error) {
 // List 查询门店列表
 // https://developers.weixin.qq.com/doc/offiaccount/WeChat_Stores/WeChat_Store_Interface.html#10
 func (api *POIAPI) List(begin, limit int) (map[string]interface{}, error) {
+	return api.list(context.Background(), begin, limit)
+}
+
+func (api *POIAPI) list(ctx context.Context, begin, limit int) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"begin": begin,
 		"limit": limit,
 	}
-	return api.Post("/poi/getpoilist", data)
+	return api.PostContext(ctx, "/poi/getpoilist", data)
 }
 
 // Update 修改门店服务信息
@@ -105,3 +116,89 @@ func (api *POIAPI) GetCategories() ([]Category, error) {
 	}
 	return nil, err
 }
+
+// POIPhoto 门店图片
+type POIPhoto struct {
+	PhotoURL string `json:"photo_url"`
+}
+
+// POI 门店基础信息
+type POI struct {
+	PoiID          string     `json:"poi_id"`
+	SID            string     `json:"sid"`
+	BusinessName   string     `json:"business_name"`
+	BranchName     string     `json:"branch_name"`
+	Province       string     `json:"province"`
+	City           string     `json:"city"`
+	District       string     `json:"district"`
+	Address        string     `json:"address"`
+	Telephone      string     `json:"telephone"`
+	Categories     []string   `json:"categories"`
+	OffsetType     int        `json:"offset_type"`
+	Longitude      float64    `json:"longitude"`
+	Latitude       float64    `json:"latitude"`
+	PhotoList      []POIPhoto `json:"photo_list"`
+	Recommend      string     `json:"recommend"`
+	Special        string     `json:"special"`
+	Introduction   string     `json:"introduction"`
+	OpenTime       string     `json:"open_time"`
+	AvgPrice       int        `json:"avg_price"`
+	AvailableState int        `json:"available_state"` // 1 系统错误，2 审核中，3 审核通过，4 审核驳回
+	UpdateStatus   int        `json:"update_status"`   // 0 无更新，1 有更新正在审核中
+}
+
+// poiPageSize 查询门店列表时每页的最大数量
+const poiPageSize = 50
+
+// All 遍历全部门店的基础信息（business_list 中的 base_info），按需逐页拉取
+// 出错或 ctx 被取消时产生一个错误并结束遍历
+func (api *POIAPI) All(ctx context.Context) iter.Seq2[*POI, error] {
+	return func(yield func(*POI, error) bool) {
+		for begin := 0; ; {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			result, err := api.list(ctx, begin, poiPageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			businessList, _ := result["business_list"].([]interface{})
+			for _, item := range businessList {
+				business, _ := item.(map[string]interface{})
+				baseInfo, ok := business["base_info"].(map[string]interface{})
+				if !ok {
+					yield(nil, fmt.Errorf("unexpected response format"))
+					return
+				}
+				var poi POI
+				if err := DecodeResult(baseInfo, &poi); err != nil {
+					yield(nil, err)
+					return
+				}
+				if !yield(&poi, nil) {
+					return
+				}
+			}
+
+			begin += len(businessList)
+			if len(businessList) == 0 || begin >= poiTotalCount(result["total_count"]) {
+				return
+			}
+		}
+	}
+}
+
+// poiTotalCount 解析门店总数，接口可能以数字或字符串返回
+func poiTotalCount(v interface{}) int {
+	switch total := v.(type) {
+	case float64:
+		return int(total)
+	case string:
+		n, _ := strconv.Atoi(total)
+		return n
+	}
+	return 0
+}
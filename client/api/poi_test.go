@@ -0,0 +1,76 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestPOIAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/poi/getpoilist", r.URL.Path)
+		var req struct {
+			Begin int `json:"begin"`
+			Limit int `json:"limit"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		businesses := make([]string, 0, req.Limit)
+		for i := req.Begin; i < 70 && len(businesses) < req.Limit; i++ {
+			businesses = append(businesses, fmt.Sprintf(`{"base_info":{"poi_id":"%d","business_name":"store","categories":["美食,小吃快餐"],"longitude":115.32375,"latitude":25.097486,"photo_list":[{"photo_url":"https://example.com/1.jpg"}],"avg_price":35,"available_state":3}}`, i))
+		}
+		fmt.Fprintf(w, `{"errcode":0,"business_list":[%s],"total_count":"70"}`, strings.Join(businesses, ","))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var pois []*api.POI
+	for poi, err := range client.POI.All(context.Background()) {
+		assert.NoError(t, err)
+		pois = append(pois, poi)
+	}
+	assert.Len(t, pois, 70)
+	assert.Equal(t, "69", pois[69].PoiID)
+	assert.Equal(t, "store", pois[0].BusinessName)
+	assert.Equal(t, []string{"美食,小吃快餐"}, pois[0].Categories)
+	assert.Equal(t, 25.097486, pois[0].Latitude)
+	assert.Equal(t, "https://example.com/1.jpg", pois[0].PhotoList[0].PhotoURL)
+	assert.Equal(t, 35, pois[0].AvgPrice)
+	assert.Equal(t, 3, pois[0].AvailableState)
+}
+
+func TestPOIAll_CanceledDuringRequest(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	// ctx 会传递到正在进行的 HTTP 请求
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	var errs []error
+	for _, err := range client.POI.All(ctx) {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], context.Canceled)
+	}
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"iter"
 )
 
 // TagAPI 标签管理 API
@@ -86,21 +88,22 @@ func (api *TagAPI) Delete(tagID int) (map[string]interface{}, error) {
 // TagUser 批量为用户打标签
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/User_Tag_Management.html
 func (api *TagAPI) TagUser(tagID int, userIDs []string) (map[string]interface{}, error) {
-	data := map[string]interface{}{
-		"tagid":       tagID,
-		"openid_list": userIDs,
-	}
-	return api.Post("/tags/members/batchtagging", data)
+	return api.batchTagging(context.Background(), "/tags/members/batchtagging", tagID, userIDs)
 }
 
 // UntagUser 批量为用户取消标签
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/User_Tag_Management.html
 func (api *TagAPI) UntagUser(tagID int, userIDs []string) (map[string]interface{}, error) {
+	return api.batchTagging(context.Background(), "/tags/members/batchuntagging", tagID, userIDs)
+}
+
+// batchTagging 批量为用户打标签或取消标签
+func (api *TagAPI) batchTagging(ctx context.Context, endpoint string, tagID int, userIDs []string) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"tagid":       tagID,
 		"openid_list": userIDs,
 	}
-	return api.Post("/tags/members/batchuntagging", data)
+	return api.PostContext(ctx, endpoint, data)
 }
 
 // GetUserTag 获取用户身上的标签列表
@@ -124,39 +127,99 @@ func (api *TagAPI) GetUserTag(userID string) ([]int, error) {
 // GetTagUsers 获取标签下粉丝列表
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/User_Tag_Management.html
 func (api *TagAPI) GetTagUsers(tagID int, firstUserID string) (map[string]interface{}, error) {
+	return api.getTagUsers(context.Background(), tagID, firstUserID)
+}
+
+func (api *TagAPI) getTagUsers(ctx context.Context, tagID int, firstUserID string) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"tagid": tagID,
 	}
 	if firstUserID != "" {
 		data["next_openid"] = firstUserID
 	}
-	return api.Post("/user/tag/get", data)
+	return api.PostContext(ctx, "/user/tag/get", data)
 }
 
 // GetBlackList 获取公众号的黑名单列表
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/Manage_blacklist.html
 func (api *TagAPI) GetBlackList(beginOpenID string) (map[string]interface{}, error) {
+	return api.getBlackList(context.Background(), beginOpenID)
+}
+
+func (api *TagAPI) getBlackList(ctx context.Context, beginOpenID string) (map[string]interface{}, error) {
 	data := map[string]interface{}{}
 	if beginOpenID != "" {
 		data["begin_openid"] = beginOpenID
 	}
-	return api.Post("/tags/members/getblacklist", data)
+	return api.PostContext(ctx, "/tags/members/getblacklist", data)
 }
 
 // BatchBlackList 批量拉黑用户
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/Manage_blacklist.html
 func (api *TagAPI) BatchBlackList(openIDList []string) (map[string]interface{}, error) {
-	data := map[string]interface{}{
-		"openid_list": openIDList,
-	}
-	return api.Post("/tags/members/batchblacklist", data)
+	return api.batchBlackList(context.Background(), "/tags/members/batchblacklist", openIDList)
 }
 
 // BatchUnblackList 批量取消拉黑
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/Manage_blacklist.html
 func (api *TagAPI) BatchUnblackList(openIDList []string) (map[string]interface{}, error) {
+	return api.batchBlackList(context.Background(), "/tags/members/batchunblacklist", openIDList)
+}
+
+// batchBlackList 批量拉黑或取消拉黑
+func (api *TagAPI) batchBlackList(ctx context.Context, endpoint string, openIDList []string) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"openid_list": openIDList,
 	}
-	return api.Post("/tags/members/batchunblacklist", data)
+	return api.PostContext(ctx, endpoint, data)
+}
+
+// AllTagUsers 遍历标签下的全部粉丝 openid，按需逐页拉取
+// 出错或 ctx 被取消时产生一个错误并结束遍历
+func (api *TagAPI) AllTagUsers(ctx context.Context, tagID int) iter.Seq2[string, error] {
+	return iterateOpenIDs(ctx, func(ctx context.Context, next string) (map[string]interface{}, error) {
+		return api.getTagUsers(ctx, tagID, next)
+	})
+}
+
+// AllBlackList 遍历公众号黑名单中的全部 openid，按需逐页拉取
+// 出错或 ctx 被取消时产生一个错误并结束遍历
+func (api *TagAPI) AllBlackList(ctx context.Context) iter.Seq2[string, error] {
+	return iterateOpenIDs(ctx, api.getBlackList)
+}
+
+// BulkTagUser 为任意数量的用户打标签，按每次 50 个切分后并发请求
+// 部分分片失败时返回 *BulkError
+func (api *TagAPI) BulkTagUser(ctx context.Context, tagID int, openIDs []string, opts ...BulkOption) error {
+	return runChunks(ctx, openIDs, tagBatchSize, opts, func(_ int, chunk []string) error {
+		_, err := api.batchTagging(ctx, "/tags/members/batchtagging", tagID, chunk)
+		return err
+	})
+}
+
+// BulkUntagUser 为任意数量的用户取消标签，按每次 50 个切分后并发请求
+// 部分分片失败时返回 *BulkError
+func (api *TagAPI) BulkUntagUser(ctx context.Context, tagID int, openIDs []string, opts ...BulkOption) error {
+	return runChunks(ctx, openIDs, tagBatchSize, opts, func(_ int, chunk []string) error {
+		_, err := api.batchTagging(ctx, "/tags/members/batchuntagging", tagID, chunk)
+		return err
+	})
+}
+
+// BulkBlackList 拉黑任意数量的用户，按每次 50 个切分后并发请求
+// 部分分片失败时返回 *BulkError
+func (api *TagAPI) BulkBlackList(ctx context.Context, openIDs []string, opts ...BulkOption) error {
+	return runChunks(ctx, openIDs, tagBatchSize, opts, func(_ int, chunk []string) error {
+		_, err := api.batchBlackList(ctx, "/tags/members/batchblacklist", chunk)
+		return err
+	})
+}
+
+// BulkUnblackList 取消拉黑任意数量的用户，按每次 50 个切分后并发请求
+// 部分分片失败时返回 *BulkError
+func (api *TagAPI) BulkUnblackList(ctx context.Context, openIDs []string, opts ...BulkOption) error {
+	return runChunks(ctx, openIDs, tagBatchSize, opts, func(_ int, chunk []string) error {
+		_, err := api.batchBlackList(ctx, "/tags/members/batchunblacklist", chunk)
+		return err
+	})
 }
@@ -0,0 +1,69 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestTagAllBlackList_Error(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tags/members/getblacklist", r.URL.Path)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Write([]byte(`{"total":4,"count":2,"data":{"openid":["o1","o2"]},"next_openid":"o2"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":45009,"errmsg":"reach max api daily quota limit"}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var openIDs []string
+	var errs []error
+	for openID, err := range client.Tag.AllBlackList(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		openIDs = append(openIDs, openID)
+	}
+	assert.Equal(t, []string{"o1", "o2"}, openIDs)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestTagBulkTagUser_Canceled(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			OpenIDList []string `json:"openid_list"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Len(t, req.OpenIDList, 50)
+		atomic.AddInt32(&calls, 1)
+		cancel()
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	err := client.Tag.BulkTagUser(ctx, 134, make([]string, 150), api.WithConcurrency(1))
+	var bulkErr *api.BulkError
+	assert.True(t, errors.As(err, &bulkErr))
+	// ctx 会传递到 HTTP 请求，正在进行的分片也随之取消
+	assert.Len(t, bulkErr.Chunks, 3)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
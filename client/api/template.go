@@ -1,5 +1,9 @@
 package api
 
+import (
+	"fmt"
+)
+
 // TemplateAPI 模板消息和订阅通知 API
 type TemplateAPI struct {
 	*BaseAPI
@@ -240,3 +244,62 @@ func (api *TemplateAPI) GetSubscribeMessageTemplates() ([]SubscribeMessageTempla
 	}
 	return nil, err
 }
+
+// TemplateDataItem 模板消息关键词数据
+type TemplateDataItem struct {
+	Value string `json:"value"`
+	Color string `json:"color,omitempty"` // 字体颜色，如 #173177
+}
+
+// TemplateMiniProgram 模板消息跳转的小程序
+type TemplateMiniProgram struct {
+	AppID    string `json:"appid"`
+	PagePath string `json:"pagepath,omitempty"`
+}
+
+// TemplateMessage 模板消息
+// URL 和 MiniProgram 都设置时优先跳转小程序
+type TemplateMessage struct {
+	ToUser      string                      `json:"touser"`
+	TemplateID  string                      `json:"template_id"`
+	URL         string                      `json:"url,omitempty"`
+	MiniProgram *TemplateMiniProgram        `json:"miniprogram,omitempty"`
+	ClientMsgID string                      `json:"client_msg_id,omitempty"` // 防重入 ID
+	Data        map[string]TemplateDataItem `json:"data"`
+}
+
+// Send 发送模板消息
+// 返回的 msgid 与 TEMPLATESENDJOBFINISH 事件（wechatgo.TemplateSendJobFinishEvent）中的 MsgID 对应
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Template_Message_Interface.html#5
+func (api *TemplateAPI) Send(msg *TemplateMessage) (int64, error) {
+	result, err := api.Post("/message/template/send", msg)
+	if err != nil {
+		return 0, err
+	}
+
+	if msgID, ok := result["msgid"].(float64); ok {
+		return int64(msgID), nil
+	}
+	return 0, fmt.Errorf("unexpected response format")
+}
+
+// SubscribeDataItem 订阅通知关键词数据
+type SubscribeDataItem struct {
+	Value string `json:"value"`
+}
+
+// SubscribeMessage 订阅通知
+type SubscribeMessage struct {
+	ToUser      string                       `json:"touser"`
+	TemplateID  string                       `json:"template_id"`
+	Page        string                       `json:"page,omitempty"` // 跳转网页地址
+	MiniProgram *TemplateMiniProgram         `json:"miniprogram,omitempty"`
+	Data        map[string]SubscribeDataItem `json:"data"`
+}
+
+// SendSubscribeMessage 发送订阅通知
+// https://developers.weixin.qq.com/doc/offiaccount/Subscription_Messages/api.html#send发送订阅通知
+func (api *TemplateAPI) SendSubscribeMessage(msg *SubscribeMessage) error {
+	_, err := api.Post("/message/subscribe/bizsend", msg)
+	return err
+}
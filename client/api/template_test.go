@@ -0,0 +1,73 @@
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestTemplateSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/message/template/send", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"touser":"OPENID",
+			"template_id":"TEMPLATE_ID",
+			"url":"http://weixin.qq.com/download",
+			"miniprogram":{"appid":"xiaochengxuappid12345","pagepath":"index?foo=bar"},
+			"data":{"keyword1":{"value":"巧克力","color":"#173177"}}
+		}`, string(body))
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","msgid":200228332}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	msgID, err := client.Template.Send(&api.TemplateMessage{
+		ToUser:      "OPENID",
+		TemplateID:  "TEMPLATE_ID",
+		URL:         "http://weixin.qq.com/download",
+		MiniProgram: &api.TemplateMiniProgram{AppID: "xiaochengxuappid12345", PagePath: "index?foo=bar"},
+		Data: map[string]api.TemplateDataItem{
+			"keyword1": {Value: "巧克力", Color: "#173177"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200228332), msgID)
+}
+
+func TestTemplateSendSubscribeMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/message/subscribe/bizsend", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("access_token"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"touser":"OPENID",
+			"template_id":"TEMPLATE_ID",
+			"page":"mp.weixin.qq.com",
+			"miniprogram":{"appid":"APPID","pagepath":"index?foo=bar"},
+			"data":{"name1":{"value":"广州腾讯科技有限公司"},"thing8":{"value":"广州腾讯科技有限公司"}}
+		}`, string(body))
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	err := client.Template.SendSubscribeMessage(&api.SubscribeMessage{
+		ToUser:      "OPENID",
+		TemplateID:  "TEMPLATE_ID",
+		Page:        "mp.weixin.qq.com",
+		MiniProgram: &api.TemplateMiniProgram{AppID: "APPID", PagePath: "index?foo=bar"},
+		Data: map[string]api.SubscribeDataItem{
+			"name1":  {Value: "广州腾讯科技有限公司"},
+			"thing8": {Value: "广州腾讯科技有限公司"},
+		},
+	})
+	assert.NoError(t, err)
+}
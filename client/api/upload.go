@@ -0,0 +1,164 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wechatpy/wechatgo"
+)
+
+// 素材类型
+const (
+	MediaTypeImage = "image" // 图片
+	MediaTypeVoice = "voice" // 语音
+	MediaTypeVideo = "video" // 视频
+	MediaTypeThumb = "thumb" // 缩略图
+)
+
+// MediaLimit 素材大小与格式限制
+type MediaLimit struct {
+	MaxSize int64                    // 最大字节数
+	Formats []string                 // 允许的扩展名（小写，不含点）
+	SizeErr wechatgo.WeChatErrorCode // 超出大小时返回的错误码
+}
+
+// MediaLimits 各类型临时素材的限制
+// https://developers.weixin.qq.com/doc/offiaccount/Asset_Management/New_temporary_materials.html
+var MediaLimits = map[string]MediaLimit{
+	MediaTypeImage: {MaxSize: 10 << 20, Formats: []string{"bmp", "png", "jpeg", "jpg", "gif"}, SizeErr: wechatgo.InvalidImageSize},
+	MediaTypeVoice: {MaxSize: 2 << 20, Formats: []string{"amr", "mp3"}, SizeErr: wechatgo.InvalidVoiceSize},
+	MediaTypeVideo: {MaxSize: 10 << 20, Formats: []string{"mp4"}, SizeErr: wechatgo.InvalidVideoSize},
+	MediaTypeThumb: {MaxSize: 64 << 10, Formats: []string{"jpg", "jpeg"}, SizeErr: wechatgo.InvalidThumbSize},
+}
+
+// uploadImgLimit 图文消息内图片的限制
+var uploadImgLimit = MediaLimit{MaxSize: 1 << 20, Formats: []string{"jpg", "jpeg", "png"}, SizeErr: wechatgo.InvalidImageSize}
+
+// Validate 校验文件名格式和大小，size 小于 0 表示大小未知，仅校验格式
+func (l MediaLimit) Validate(fileName string, size int64) error {
+	if len(l.Formats) > 0 {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+		supported := false
+		for _, format := range l.Formats {
+			if ext == format {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return wechatgo.NewError(int(wechatgo.InvalidFileType),
+				fmt.Sprintf("unsupported file format %q, expected one of %s", ext, strings.Join(l.Formats, "/")))
+		}
+	}
+	if size >= 0 && l.MaxSize > 0 && size > l.MaxSize {
+		return l.sizeError(size)
+	}
+	return nil
+}
+
+// sizeError 构造超出大小限制的错误
+func (l MediaLimit) sizeError(size int64) error {
+	code := l.SizeErr
+	if code == 0 {
+		code = wechatgo.InvalidFileSize
+	}
+	return wechatgo.NewError(int(code), fmt.Sprintf("file size %d exceeds limit %d", size, l.MaxSize))
+}
+
+// ValidateMedia 按素材类型校验文件，未知类型不做校验
+func ValidateMedia(mediaType, fileName string, size int64) error {
+	limit, ok := MediaLimits[mediaType]
+	if !ok {
+		return nil
+	}
+	return limit.Validate(fileName, size)
+}
+
+// UploadFile multipart 上传的文件及表单内容
+type UploadFile struct {
+	FieldName string            // 文件字段名，默认 media
+	FileName  string            // 文件名
+	Reader    io.Reader         // 文件内容
+	Size      int64             // 文件大小，小于等于 0 表示未知
+	MaxSize   int64             // 最大字节数，上传过程中超出即中止，0 表示不限制
+	Fields    map[string]string // 额外表单字段，如视频素材的 description
+	Progress  func(written, total int64)
+
+	limit *MediaLimit
+}
+
+// UploadOption 上传选项
+type UploadOption func(*UploadFile)
+
+// WithProgress 设置上传进度回调，total 为 0 表示总大小未知
+func WithProgress(fn func(written, total int64)) UploadOption {
+	return func(f *UploadFile) {
+		f.Progress = fn
+	}
+}
+
+// WithFormField 添加额外表单字段
+func WithFormField(name, value string) UploadOption {
+	return func(f *UploadFile) {
+		if f.Fields == nil {
+			f.Fields = make(map[string]string)
+		}
+		f.Fields[name] = value
+	}
+}
+
+// WithFileSize 指定文件大小，用于提前校验和计算进度
+func WithFileSize(size int64) UploadOption {
+	return func(f *UploadFile) {
+		f.Size = size
+	}
+}
+
+// newUploadFile 根据素材限制构建上传内容并做前置校验
+func newUploadFile(fileName string, reader io.Reader, limit *MediaLimit, opts ...UploadOption) (*UploadFile, error) {
+	file := &UploadFile{
+		FileName: fileName,
+		Reader:   reader,
+		limit:    limit,
+	}
+	file.Size = readerSize(reader)
+	for _, opt := range opts {
+		opt(file)
+	}
+
+	if limit != nil {
+		size := file.Size
+		if size <= 0 {
+			size = -1
+		}
+		if err := limit.Validate(fileName, size); err != nil {
+			return nil, err
+		}
+		file.MaxSize = limit.MaxSize
+	}
+	return file, nil
+}
+
+// SizeError 返回超出 MaxSize 时的错误
+func (f *UploadFile) SizeError(size int64) error {
+	if f.limit != nil {
+		return f.limit.sizeError(size)
+	}
+	return wechatgo.NewError(int(wechatgo.InvalidFileSize), fmt.Sprintf("file size %d exceeds limit %d", size, f.MaxSize))
+}
+
+// readerSize 尝试获取 Reader 的总大小，无法获取时返回 0
+func readerSize(reader io.Reader) int64 {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		if info, err := r.Stat(); err == nil && info.Mode().IsRegular() {
+			return info.Size()
+		}
+	}
+	return 0
+}
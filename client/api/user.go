@@ -1,5 +1,10 @@
 package api
 
+import (
+	"context"
+	"iter"
+)
+
 // UserAPI 用户管理 API
 type UserAPI struct {
 	*BaseAPI
@@ -31,11 +36,15 @@ func (api *UserAPI) Get(openID string, lang string) (map[string]interface{}, err
 // GetFollowers 获取用户列表
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/Getting_a_User_List.html
 func (api *UserAPI) GetFollowers(nextOpenID string) (map[string]interface{}, error) {
+	return api.getFollowers(context.Background(), nextOpenID)
+}
+
+func (api *UserAPI) getFollowers(ctx context.Context, nextOpenID string) (map[string]interface{}, error) {
 	params := make(map[string]string)
 	if nextOpenID != "" {
 		params["next_openid"] = nextOpenID
 	}
-	return api.BaseAPI.Get("/user/get", params)
+	return api.BaseAPI.GetContext(ctx, "/user/get", params)
 }
 
 // UpdateRemark 设置用户备注名
@@ -50,6 +59,10 @@ func (api *UserAPI) UpdateRemark(openID, remark string) (map[string]interface{},
 // GetBatch 批量获取用户基本信息
 // https://developers.weixin.qq.com/doc/offiaccount/User_Management/Get_users_basic_information_UnionID.html
 func (api *UserAPI) GetBatch(openIDs []string, lang string) (map[string]interface{}, error) {
+	return api.getBatch(context.Background(), openIDs, lang)
+}
+
+func (api *UserAPI) getBatch(ctx context.Context, openIDs []string, lang string) (map[string]interface{}, error) {
 	if lang == "" {
 		lang = "zh_CN"
 	}
@@ -62,7 +75,105 @@ func (api *UserAPI) GetBatch(openIDs []string, lang string) (map[string]interfac
 		}
 	}
 
-	return api.Post("/user/info/batchget", map[string]interface{}{
+	return api.PostContext(ctx, "/user/info/batchget", map[string]interface{}{
 		"user_list": userList,
 	})
 }
+
+// 批量接口单次调用的最大 openid 数量
+const (
+	userBatchGetSize = 100
+	tagBatchSize     = 50
+)
+
+// UserInfo 用户基本信息
+type UserInfo struct {
+	Subscribe      int    `json:"subscribe"`
+	OpenID         string `json:"openid"`
+	Language       string `json:"language"`
+	SubscribeTime  int64  `json:"subscribe_time"`
+	UnionID        string `json:"unionid,omitempty"`
+	Remark         string `json:"remark"`
+	GroupID        int    `json:"groupid"`
+	TagIDList      []int  `json:"tagid_list"`
+	SubscribeScene string `json:"subscribe_scene"`
+	QRScene        int    `json:"qr_scene"`
+	QRSceneStr     string `json:"qr_scene_str"`
+}
+
+// BulkGet 批量获取任意数量用户的基本信息
+// openIDs 按每次 100 个切分后并发请求，返回成功分片的用户信息（保持输入顺序）
+// 部分分片失败时同时返回 *BulkError，其中列出失败分片的 openid 以便重试
+func (api *UserAPI) BulkGet(ctx context.Context, openIDs []string, lang string, opts ...BulkOption) ([]UserInfo, error) {
+	results := make([][]UserInfo, (len(openIDs)+userBatchGetSize-1)/userBatchGetSize)
+	err := runChunks(ctx, openIDs, userBatchGetSize, opts, func(index int, chunk []string) error {
+		result, err := api.getBatch(ctx, chunk, lang)
+		if err != nil {
+			return err
+		}
+		var batch struct {
+			UserInfoList []UserInfo `json:"user_info_list"`
+		}
+		if err := DecodeResult(result, &batch); err != nil {
+			return err
+		}
+		results[index] = batch.UserInfoList
+		return nil
+	})
+
+	users := make([]UserInfo, 0, len(openIDs))
+	for _, batch := range results {
+		users = append(users, batch...)
+	}
+	return users, err
+}
+
+// OpenIDPage 按 next_openid 分页返回的 openid 列表
+type OpenIDPage struct {
+	Total int `json:"total"`
+	Count int `json:"count"`
+	Data  struct {
+		OpenID []string `json:"openid"`
+	} `json:"data"`
+	NextOpenID string `json:"next_openid"`
+}
+
+// AllFollowers 遍历公众号的全部关注者 openid，按需逐页拉取
+// 出错或 ctx 被取消时产生一个错误并结束遍历
+func (api *UserAPI) AllFollowers(ctx context.Context) iter.Seq2[string, error] {
+	return iterateOpenIDs(ctx, api.getFollowers)
+}
+
+// iterateOpenIDs 按 next_openid 游标遍历 openid 列表
+// 当返回数量为 0 或没有下一页游标时结束
+func iterateOpenIDs(ctx context.Context, fetch func(ctx context.Context, next string) (map[string]interface{}, error)) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for next := ""; ; {
+			if err := ctx.Err(); err != nil {
+				yield("", err)
+				return
+			}
+
+			result, err := fetch(ctx, next)
+			if err != nil {
+				yield("", err)
+				return
+			}
+			var page OpenIDPage
+			if err := DecodeResult(result, &page); err != nil {
+				yield("", err)
+				return
+			}
+			for _, openID := range page.Data.OpenID {
+				if !yield(openID, nil) {
+					return
+				}
+			}
+
+			if page.Count == 0 || len(page.Data.OpenID) == 0 || page.NextOpenID == "" || page.NextOpenID == next {
+				return
+			}
+			next = page.NextOpenID
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/internal/testclient"
+)
+
+func TestUserAllFollowers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/get", r.URL.Path)
+		switch r.URL.Query().Get("next_openid") {
+		case "":
+			w.Write([]byte(`{"total":3,"count":2,"data":{"openid":["o1","o2"]},"next_openid":"o2"}`))
+		case "o2":
+			w.Write([]byte(`{"total":3,"count":1,"data":{"openid":["o3"]},"next_openid":"o3"}`))
+		default:
+			w.Write([]byte(`{"total":3,"count":0,"next_openid":""}`))
+		}
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	var openIDs []string
+	for openID, err := range client.User.AllFollowers(context.Background()) {
+		assert.NoError(t, err)
+		openIDs = append(openIDs, openID)
+	}
+	assert.Equal(t, []string{"o1", "o2", "o3"}, openIDs)
+}
+
+func TestUserBulkGet(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/info/batchget", r.URL.Path)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		var req struct {
+			UserList []struct {
+				OpenID string `json:"openid"`
+			} `json:"user_list"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.LessOrEqual(t, len(req.UserList), 100)
+		if req.UserList[0].OpenID == "o100" {
+			w.Write([]byte(`{"errcode":45009,"errmsg":"reach max api daily quota limit"}`))
+			return
+		}
+
+		users := make([]string, len(req.UserList))
+		for i, user := range req.UserList {
+			users[i] = fmt.Sprintf(`{"subscribe":1,"openid":"%s","tagid_list":[2]}`, user.OpenID)
+		}
+		fmt.Fprintf(w, `{"user_info_list":[%s]}`, strings.Join(users, ","))
+	}))
+	defer server.Close()
+
+	client := testclient.New(server.URL, nil)
+
+	openIDs := make([]string, 350)
+	for i := range openIDs {
+		openIDs[i] = fmt.Sprintf("o%d", i)
+	}
+	users, err := client.User.BulkGet(context.Background(), openIDs, "", api.WithConcurrency(2))
+
+	var bulkErr *api.BulkError
+	assert.True(t, errors.As(err, &bulkErr))
+	assert.Equal(t, 4, bulkErr.Total)
+	assert.Len(t, bulkErr.Chunks, 1)
+	assert.Equal(t, 1, bulkErr.Chunks[0].Index)
+	assert.Equal(t, openIDs[100:200], bulkErr.Chunks[0].Items)
+	assert.True(t, errors.Is(err, wechatgo.OutOfAPIFreqLimit))
+
+	assert.Len(t, users, 250)
+	assert.Equal(t, "o0", users[0].OpenID)
+	assert.Equal(t, "o200", users[100].OpenID)
+	assert.Equal(t, []int{2}, users[249].TagIDList)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestUserBulkGet_Context(t *testing.T) {
+	var started int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&started, 1)
+		// 读完请求体后服务端才能感知连接关闭，随后阻塞到客户端取消请求
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c := testclient.New(server.URL, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.User.BulkGet(ctx, []string{"openid1", "openid2"}, "")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&started))
+	case <-time.After(5 * time.Second):
+		t.Fatal("BulkGet did not return after ctx was done")
+	}
+
+	// 限流等待同样受 ctx 控制
+	limiter := client.NewRateLimiter(0.001, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+	limited := testclient.New(server.URL, nil, client.WithRateLimiter(limiter))
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := limited.Tag.BulkTagUser(ctx, 1, []string{"openid1"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started))
+}
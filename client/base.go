@@ -2,11 +2,14 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -48,13 +51,22 @@ type BaseClient struct {
 	apiBaseURL string
 	logger     logger.Logger
 
-	// 性能优化：缓存常用数据
-	mu               sync.RWMutex
-	jsonMarshalCache map[string][]byte // JSON序列化缓存
+	// customBaseURL 是否通过 WithBaseURL 指定了基础 URL
+	customBaseURL bool
+	// apiRootURL 通过 WithRootURL 指定的 API 根地址
+	apiRootURL string
+	// keyPrefix 会话存储键前缀
+	keyPrefix string
+	// tokenSource access token 来源，为空时不自动刷新
+	tokenSource TokenSource
+	// tokenMu 保证同一时刻只有一个 goroutine 刷新 token
+	tokenMu sync.Mutex
+	// rateLimiter 请求限流器，为空时不限流
+	rateLimiter RateLimiter
 }
 
 // NewBaseClient 创建基础客户端
-func NewBaseClient(appID string, storage session.Storage, apiBaseURL string) *BaseClient {
+func NewBaseClient(appID string, storage session.Storage, apiBaseURL string, opts ...ClientOption) *BaseClient {
 	if storage == nil {
 		storage = session.NewMemoryStorage()
 	}
@@ -64,15 +76,34 @@ func NewBaseClient(appID string, storage session.Storage, apiBaseURL string) *Ba
 		// 全局客户端已配置好Transport
 	})
 
-	return &BaseClient{
-		AppID:            appID,
-		httpClient:       defaultHTTPClient,
-		session:          storage,
-		autoRetry:        true,
-		apiBaseURL:       apiBaseURL,
-		logger:           logger.New(),
-		jsonMarshalCache: make(map[string][]byte, 100), // 缓存100个JSON序列化结果
+	o := &clientOptions{}
+	for _, opt := range opts {
+		opt(o)
 	}
+
+	c := &BaseClient{
+		AppID:       appID,
+		httpClient:  o.buildHTTPClient(),
+		session:     storage,
+		autoRetry:   true,
+		apiBaseURL:  apiBaseURL,
+		logger:      logger.New(),
+		tokenSource: o.tokenSource,
+		rateLimiter: o.rateLimiter,
+		apiRootURL:  o.rootURL,
+		keyPrefix:   o.keyPrefix,
+	}
+	if o.baseURL != "" {
+		c.apiBaseURL = o.baseURL
+		c.customBaseURL = true
+	}
+	if o.logger != nil {
+		c.logger = o.logger
+	}
+	if o.autoRetry != nil {
+		c.autoRetry = *o.autoRetry
+	}
+	return c
 }
 
 // WithLogger 设置logger
@@ -81,41 +112,124 @@ func (c *BaseClient) WithLogger(logger logger.Logger) *BaseClient {
 	return c
 }
 
+// Session 返回会话存储
+func (c *BaseClient) Session() session.Storage {
+	return c.session
+}
+
+// Logger 返回客户端使用的 logger
+func (c *BaseClient) Logger() logger.Logger {
+	return c.logger
+}
+
+// HTTPClient 返回底层 HTTP 客户端
+func (c *BaseClient) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// BuildURL 将以 / 开头的接口路径拼接为完整 URL，完整 URL 原样返回
+func (c *BaseClient) BuildURL(urlOrEndpoint string) string {
+	if urlOrEndpoint == "" || urlOrEndpoint[0] != '/' {
+		return urlOrEndpoint
+	}
+	return strings.TrimSuffix(c.apiBaseURL, "/") + urlOrEndpoint
+}
+
+// TokenURL 返回 token 获取地址
+// 通过 WithBaseURL 指定了基础 URL 时，改为该地址下的 path，便于指向本地模拟服务
+func (c *BaseClient) TokenURL(defaultURL, path string) string {
+	if !c.customBaseURL {
+		return defaultURL
+	}
+	return c.BuildURL(path)
+}
+
+// KeyPrefix 返回通过 WithKeyPrefix 设置的会话存储键前缀
+func (c *BaseClient) KeyPrefix() string {
+	return c.keyPrefix
+}
+
 // accessTokenKey 获取 access token 存储键
 func (c *BaseClient) accessTokenKey() string {
-	return fmt.Sprintf("%s_access_token", c.AppID)
+	return fmt.Sprintf("%s%s_access_token", c.keyPrefix, c.AppID)
 }
 
 // expiresAtKey 获取过期时间存储键
 func (c *BaseClient) expiresAtKey() string {
-	return fmt.Sprintf("%s_access_token_expires_at", c.AppID)
+	return fmt.Sprintf("%s%s_access_token_expires_at", c.keyPrefix, c.AppID)
 }
 
 // GetAccessToken 获取 access token
+// 缓存中的 token 不存在或即将过期时，通过 TokenSource 刷新
 func (c *BaseClient) GetAccessToken() (string, error) {
-	token, err := c.session.Get(c.accessTokenKey())
-	if err != nil {
+	if token, ok := c.cachedAccessToken(); ok {
+		return token, nil
+	}
+	if c.tokenSource == nil {
+		// Token 不存在或已过期，需要刷新
+		return "", wechatgo.ErrAccessTokenExpired
+	}
+
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	// 等待锁期间可能已被其他 goroutine 刷新
+	if token, ok := c.cachedAccessToken(); ok {
+		return token, nil
+	}
+	if err := c.refreshAccessToken(); err != nil {
 		return "", err
 	}
+	if token, ok := c.cachedAccessToken(); ok {
+		return token, nil
+	}
+	return "", wechatgo.ErrAccessTokenExpired
+}
+
+// RefreshAccessToken 通过 TokenSource 强制刷新 access token
+func (c *BaseClient) RefreshAccessToken() error {
+	if c.tokenSource == nil {
+		return fmt.Errorf("token source not configured")
+	}
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.refreshAccessToken()
+}
+
+// refreshAccessToken 获取新 token 并写入缓存，调用方需持有 tokenMu
+func (c *BaseClient) refreshAccessToken() error {
+	token, expiresIn, err := c.tokenSource.Token()
+	if err != nil {
+		c.logger.Error("刷新access token失败", err)
+		return err
+	}
+	if expiresIn <= 0 {
+		expiresIn = 7200
+	}
+	return c.SetAccessToken(token, expiresIn)
+}
+
+// cachedAccessToken 从会话存储读取未过期的 access token
+func (c *BaseClient) cachedAccessToken() (string, bool) {
+	token, err := c.session.Get(c.accessTokenKey())
+	if err != nil || token == "" {
+		return "", false
+	}
 
-	if token != "" {
-		// 检查是否过期
-		expiresAtStr, err := c.session.Get(c.expiresAtKey())
-		if err == nil && expiresAtStr != "" {
-			var expiresAt int64
-			if err := json.Unmarshal([]byte(expiresAtStr), &expiresAt); err == nil {
-				if time.Now().Unix() < expiresAt-60 {
-					return token, nil
-				}
+	// 检查是否过期
+	expiresAtStr, err := c.session.Get(c.expiresAtKey())
+	if err == nil && expiresAtStr != "" {
+		var expiresAt int64
+		if err := json.Unmarshal([]byte(expiresAtStr), &expiresAt); err == nil {
+			if time.Now().Unix() < expiresAt-60 {
+				return token, true
 			}
-		} else {
-			// 用户提供的 token，直接返回
-			return token, nil
 		}
+		return "", false
 	}
 
-	// Token 不存在或已过期，需要刷新
-	return "", fmt.Errorf("access token expired or not found")
+	// 用户提供的 token，直接返回
+	return token, true
 }
 
 // SetAccessToken 设置 access token
@@ -132,12 +246,27 @@ func (c *BaseClient) SetAccessToken(token string, expiresIn int) error {
 	return c.session.Set(c.expiresAtKey(), string(expiresAtData), time.Duration(expiresIn)*time.Second)
 }
 
+// ClearAccessToken 从会话存储中删除 access token，下次调用接口时重新获取
+func (c *BaseClient) ClearAccessToken() error {
+	if err := c.session.Delete(c.accessTokenKey()); err != nil {
+		return err
+	}
+	return c.session.Delete(c.expiresAtKey())
+}
+
 // Request 发送 HTTP 请求
 func (c *BaseClient) Request(method, urlOrEndpoint string, params map[string]string, data interface{}) (map[string]interface{}, error) {
-	url := urlOrEndpoint
-	if urlOrEndpoint[0] == '/' {
-		url = c.apiBaseURL + urlOrEndpoint
-	}
+	return c.RequestContext(context.Background(), method, urlOrEndpoint, params, data)
+}
+
+// RequestContext 发送 HTTP 请求，ctx 用于限流等待和 HTTP 请求本身
+func (c *BaseClient) RequestContext(ctx context.Context, method, urlOrEndpoint string, params map[string]string, data interface{}) (map[string]interface{}, error) {
+	return c.request(ctx, method, urlOrEndpoint, params, data, c.autoRetry)
+}
+
+// request 发送 HTTP 请求，retry 表示 token 失效时是否刷新后重试
+func (c *BaseClient) request(ctx context.Context, method, urlOrEndpoint string, params map[string]string, data interface{}, retry bool) (map[string]interface{}, error) {
+	url := c.BuildURL(urlOrEndpoint)
 
 	// 记录请求开始
 	timer := logger.StartTimer()
@@ -151,7 +280,10 @@ func (c *BaseClient) Request(method, urlOrEndpoint string, params map[string]str
 	if params == nil {
 		params = make(map[string]string)
 	}
-	if _, ok := params["access_token"]; !ok {
+	if _, ok := params["access_token"]; ok {
+		// 调用方自行提供的 token 无法由本客户端刷新
+		retry = false
+	} else {
 		token, err := c.GetAccessToken()
 		if err != nil {
 			c.logger.Error("获取access token失败", err)
@@ -160,18 +292,21 @@ func (c *BaseClient) Request(method, urlOrEndpoint string, params map[string]str
 		params["access_token"] = token
 	}
 
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	// 构建请求
 	var body io.Reader
 	if data != nil {
-		// 性能优化：使用JSON缓存减少重复序列化
-		jsonData, err := c.marshalJSON(data)
+		jsonData, err := json.Marshal(data)
 		if err != nil {
 			return nil, err
 		}
 		body = bytes.NewReader(jsonData)
 	}
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +342,7 @@ func (c *BaseClient) Request(method, urlOrEndpoint string, params map[string]str
 	}
 
 	// 处理错误
-	response, err := c.handleResult(result, method, urlOrEndpoint, params, data)
+	response, err := c.handleResult(ctx, result, resp, respBody, method, urlOrEndpoint, params, data, retry)
 	if err != nil {
 		timer(logger.Fields{"duration_field": "duration"})
 		c.logger.Error("API调用失败", err,
@@ -225,52 +360,82 @@ func (c *BaseClient) Request(method, urlOrEndpoint string, params map[string]str
 }
 
 // handleResult 处理响应结果
-func (c *BaseClient) handleResult(result map[string]interface{}, method, url string, params map[string]string, data interface{}) (map[string]interface{}, error) {
-	// 检查错误码
-	if errcode, ok := result["errcode"]; ok {
-		errcodeFloat, ok := errcode.(float64)
-		if !ok {
-			return nil, fmt.Errorf("invalid errcode type: %T", errcode)
-		}
-		errcodeInt := int(errcodeFloat)
-		if errcodeInt != 0 {
-			errmsg := ""
-			if msg, ok := result["errmsg"]; ok {
-				if errmsgStr, ok := msg.(string); ok {
-					errmsg = errmsgStr
-				}
-			}
+func (c *BaseClient) handleResult(ctx context.Context, result map[string]interface{}, resp *http.Response, body []byte, method, url string, params map[string]string, data interface{}, retry bool) (map[string]interface{}, error) {
+	err := wechatgo.CheckResponse(result, resp, body)
+	if err == nil {
+		return result, nil
+	}
 
-			// 自动重试 token 过期错误
-			if c.autoRetry && (errcodeInt == int(wechatgo.InvalidCredential) ||
-				errcodeInt == int(wechatgo.InvalidAccessToken) ||
-				errcodeInt == int(wechatgo.ExpiredAccessToken)) {
-				// Token 过期，清除缓存并重试
-				c.logger.Warn("AccessToken过期，正在刷新",
-					logger.Int("errcode", errcodeInt),
-					logger.String("errmsg", errmsg),
-				)
-				c.session.Delete(c.accessTokenKey())
-				c.session.Delete(c.expiresAtKey())
-				return c.Request(method, url, params, data)
-			}
+	var clientErr *wechatgo.ClientError
+	if !errors.As(err, &clientErr) {
+		return nil, err
+	}
 
-			// API 频率限制
-			if errcodeInt == int(wechatgo.OutOfAPIFreqLimit) {
-				c.logger.Warn("API调用频率受限",
-					logger.Int("errcode", errcodeInt),
-					logger.String("errmsg", errmsg),
-				)
-				return nil, wechatgo.NewAPILimitedError(errcodeInt, errmsg, nil, nil)
-			}
+	// 自动重试 token 过期错误
+	if retry && (errors.Is(err, wechatgo.ErrInvalidCredential) ||
+		errors.Is(err, wechatgo.ErrInvalidAccessToken) ||
+		errors.Is(err, wechatgo.ErrExpiredAccessToken)) {
+		// Token 过期，清除缓存并重试
+		c.logger.Warn("AccessToken过期，正在刷新",
+			logger.Int("errcode", clientErr.ErrCode),
+			logger.String("errmsg", clientErr.ErrMsg),
+		)
+		c.ClearAccessToken()
+		delete(params, "access_token")
+		return c.request(ctx, method, url, params, data, false)
+	}
 
-			c.logger.Error("API返回错误",
-				fmt.Errorf("%d: %s", errcodeInt, errmsg),
-				logger.Int("errcode", errcodeInt),
-				logger.String("errmsg", errmsg),
-			)
-			return nil, wechatgo.NewClientError(errcodeInt, errmsg, nil, nil)
-		}
+	// API 频率限制
+	if errors.Is(err, wechatgo.ErrOutOfAPIFreqLimit) {
+		c.logger.Warn("API调用频率受限",
+			logger.Int("errcode", clientErr.ErrCode),
+			logger.String("errmsg", clientErr.ErrMsg),
+		)
+		return nil, err
+	}
+
+	c.logger.Error("API返回错误", err,
+		logger.Int("errcode", clientErr.ErrCode),
+		logger.String("errmsg", clientErr.ErrMsg),
+		logger.String("endpoint", clientErr.Endpoint),
+		logger.String("request_id", clientErr.RequestID),
+	)
+	return nil, err
+}
+
+// FetchToken 请求凭证类接口（不自动添加 access_token 参数）
+func (c *BaseClient) FetchToken(url string, params map[string]string) (map[string]interface{}, error) {
+	// 构建 URL 参数
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	for k, v := range params {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	// 检查错误
+	if err := wechatgo.CheckResponse(result, resp, body); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -286,6 +451,47 @@ func (c *BaseClient) Post(url string, data interface{}) (map[string]interface{},
 	return c.Request("POST", url, nil, data)
 }
 
+// GetContext 发送 GET 请求，ctx 用于限流等待和 HTTP 请求本身
+func (c *BaseClient) GetContext(ctx context.Context, url string, params map[string]string) (map[string]interface{}, error) {
+	return c.RequestContext(ctx, "GET", url, params, nil)
+}
+
+// PostContext 发送 POST 请求，ctx 用于限流等待和 HTTP 请求本身
+func (c *BaseClient) PostContext(ctx context.Context, url string, data interface{}) (map[string]interface{}, error) {
+	return c.RequestContext(ctx, "POST", url, nil, data)
+}
+
+// RawRequest 发送原始 HTTP 请求并返回未读取的响应，调用方负责关闭响应体
+// 不会自动添加 access_token，适用于下载等非 JSON 响应的接口
+func (c *BaseClient) RawRequest(ctx context.Context, method, urlOrEndpoint string, params map[string]string, body io.Reader) (*http.Response, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BuildURL(urlOrEndpoint), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(params) > 0 {
+		q := req.URL.Query()
+		for k, v := range params {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// wait 等待限流器放行
+func (c *BaseClient) wait(ctx context.Context) error {
+	if c.rateLimiter == nil {
+		return nil
+	}
+	return c.rateLimiter.Wait(ctx)
+}
+
 // GetRaw 发送原始 HTTP GET 请求
 func (c *BaseClient) GetRaw(url string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", url, nil)
@@ -326,51 +532,65 @@ func (c *BaseClient) AsHTTPClient() api.HTTPClient {
 
 // Upload 上传文件（实现API接口）
 func (c *BaseClient) Upload(url, fileName string, file io.Reader) (map[string]interface{}, error) {
-	// 构建完整的URL
-	fullURL := url
-	if url[0] == '/' {
-		fullURL = c.apiBaseURL + url
-	}
+	return c.UploadStream(url, nil, &api.UploadFile{FileName: fileName, Reader: file})
+}
 
-	// 创建multipart表单
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// UploadStream 以流式 multipart 上传文件
+// 请求体通过 io.Pipe 边读边发，不会把整个文件读入内存
+func (c *BaseClient) UploadStream(url string, params map[string]string, file *api.UploadFile) (map[string]interface{}, error) {
+	return c.UploadStreamContext(context.Background(), url, params, file)
+}
 
-	// 添加文件
-	part, err := writer.CreateFormFile("media", fileName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
-	}
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file: %w", err)
-	}
+// UploadStreamContext 以流式 multipart 上传文件，ctx 用于限流等待和 HTTP 请求本身
+func (c *BaseClient) UploadStreamContext(ctx context.Context, url string, params map[string]string, file *api.UploadFile) (map[string]interface{}, error) {
+	// 构建完整的URL
+	fullURL := c.BuildURL(url)
 
-	// 添加access_token参数
-	if token, err := c.GetAccessToken(); err == nil {
-		if err := writer.WriteField("access_token", token); err != nil {
-			return nil, fmt.Errorf("failed to write access_token: %w", err)
+	// 添加 access_token 参数
+	if params == nil {
+		params = make(map[string]string)
+	}
+	if _, ok := params["access_token"]; !ok {
+		token, err := c.GetAccessToken()
+		if err != nil {
+			return nil, err
 		}
+		params["access_token"] = token
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, err
 	}
 
-	writer.Close()
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, file))
+	}()
 
 	// 创建请求
-	req, err := http.NewRequest("POST", fullURL, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, pr)
 	if err != nil {
+		pr.Close()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	q := req.URL.Query()
+	for k, v := range params {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
 	// 发送请求
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		// 校验失败等写入错误会经由管道传递到这里
+		var wxErr *wechatgo.Error
+		if errors.As(err, &wxErr) {
+			return nil, wxErr
+		}
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
-	defer func() {
-		if resp != nil && resp.Body != nil {
-			resp.Body.Close()
-		}
-	}()
+	defer resp.Body.Close()
 
 	// 读取响应
 	respBody, err := io.ReadAll(resp.Body)
@@ -385,49 +605,53 @@ func (c *BaseClient) Upload(url, fileName string, file io.Reader) (map[string]in
 	}
 
 	// 检查错误
-	return c.handleResult(result, "POST", url, nil, nil)
+	return c.handleResult(ctx, result, resp, respBody, "POST", url, nil, nil, false)
 }
 
-// marshalJSON 带缓存的JSON序列化
-func (c *BaseClient) marshalJSON(data interface{}) ([]byte, error) {
-	// 对于简单数据类型，不使用缓存
-	if _, ok := data.(string); ok {
-		return json.Marshal(data)
+// writeMultipart 将表单字段和文件写入 multipart writer
+func writeMultipart(writer *multipart.Writer, file *api.UploadFile) error {
+	for name, value := range file.Fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return fmt.Errorf("failed to write field %s: %w", name, err)
+		}
 	}
 
-	// 生成缓存键
-	cacheKey := fmt.Sprintf("%T:%v", data, data)
-
-	// 尝试从缓存获取
-	c.mu.RLock()
-	if cached, exists := c.jsonMarshalCache[cacheKey]; exists {
-		c.mu.RUnlock()
-		return cached, nil
+	fieldName := file.FieldName
+	if fieldName == "" {
+		fieldName = "media"
 	}
-	c.mu.RUnlock()
-
-	// 序列化
-	result, err := json.Marshal(data)
+	part, err := writer.CreateFormFile(fieldName, file.FileName)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to create form file: %w", err)
 	}
 
-	// 添加到缓存（带写锁）
-	c.mu.Lock()
-	// 检查缓存大小，超过限制则清理
-	if len(c.jsonMarshalCache) >= 100 {
-		// 清理一半缓存
-		clearCount := 0
-		for key := range c.jsonMarshalCache {
-			delete(c.jsonMarshalCache, key)
-			clearCount++
-			if clearCount >= 50 {
-				break
+	src := &uploadReader{file: file}
+	if _, err := io.Copy(part, src); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// uploadReader 统计已读取字节数，负责进度回调和大小限制
+type uploadReader struct {
+	file    *api.UploadFile
+	written int64
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	n, err := r.file.Reader.Read(p)
+	if n > 0 {
+		r.written += int64(n)
+		if r.file.MaxSize > 0 && r.written > r.file.MaxSize {
+			return n, r.file.SizeError(r.written)
+		}
+		if r.file.Progress != nil {
+			total := r.file.Size
+			if total < 0 {
+				total = 0
 			}
+			r.file.Progress(r.written, total)
 		}
 	}
-	c.jsonMarshalCache[cacheKey] = result
-	c.mu.Unlock()
-
-	return result, nil
+	return n, err
 }
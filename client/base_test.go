@@ -1,9 +1,17 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client/api"
 	"github.com/wechatpy/wechatgo/session"
 )
 
@@ -89,64 +97,116 @@ func TestSetAccessToken_EmptyValue(t *testing.T) {
 	assert.Empty(t, storedToken)
 }
 
-func TestMarshalJSON_Cache(t *testing.T) {
-	storage := session.NewMemoryStorage()
-	client := NewBaseClient("test_appid", storage, "https://api.example.com")
-
-	type TestStruct struct {
-		Name string `json:"name"`
-		Age  int    `json:"age"`
-	}
-
-	data := TestStruct{Name: "test", Age: 25}
-
-	// 第一次序列化
-	result1, err := client.marshalJSON(data)
-	assert.NoError(t, err)
-	assert.NotEmpty(t, result1)
-
-	// 第二次序列化应该从缓存获取
-	result2, err := client.marshalJSON(data)
-	assert.NoError(t, err)
-	assert.Equal(t, result1, result2)
+func TestRequest_ClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Write([]byte(`{"errcode":40013,"errmsg":"invalid appid rid: 6a1b2c3d-4e5f6a7b-8c9d0e1f"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":40003,"errmsg":"invalid openid"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_appid", "test_secret", nil, WithBaseURL(server.URL))
+
+	err := client.FetchAccessToken()
+	var clientErr *wechatgo.ClientError
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, "/token", clientErr.Endpoint)
+	assert.Equal(t, "6a1b2c3d-4e5f6a7b-8c9d0e1f", clientErr.RequestID)
+
+	client.SetAccessToken("token", 7200)
+	_, err = client.User.Get("openid", "")
+	assert.True(t, errors.Is(err, wechatgo.ErrInvalidOpenID))
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, "/user/info", clientErr.Endpoint)
+	assert.JSONEq(t, `{"errcode":40003,"errmsg":"invalid openid"}`, string(clientErr.RawBody))
 }
 
-func TestMarshalJSON_SimpleString(t *testing.T) {
-	storage := session.NewMemoryStorage()
-	client := NewBaseClient("test_appid", storage, "https://api.example.com")
+func TestUploadStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/media/upload", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("access_token"))
+		assert.Equal(t, "image", r.URL.Query().Get("type"))
 
-	// 简单字符串不使用缓存
-	result, err := client.marshalJSON("simple_string")
+		file, header, err := r.FormFile("media")
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "photo.jpg", header.Filename)
+		assert.Equal(t, 1024, len(data))
+		assert.Equal(t, "extra", r.FormValue("description"))
+		w.Write([]byte(`{"type":"image","media_id":"media_id","created_at":1234567890}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_appid", "test_secret", nil, WithBaseURL(server.URL))
+	client.SetAccessToken("token", 7200)
+
+	var lastWritten, lastTotal int64
+	result, err := client.Media.UploadReader("image", "photo.jpg", bytes.NewReader(make([]byte, 1024)),
+		api.WithFormField("description", "extra"),
+		api.WithProgress(func(written, total int64) {
+			lastWritten, lastTotal = written, total
+		}),
+	)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, result)
+	assert.Equal(t, "media_id", result["media_id"])
+	assert.Equal(t, int64(1024), lastWritten)
+	assert.Equal(t, int64(1024), lastTotal)
 }
 
-func TestMarshalJSON_Error(t *testing.T) {
-	storage := session.NewMemoryStorage()
-	client := NewBaseClient("test_appid", storage, "https://api.example.com")
+func TestUploadStream_Validation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"media_id":"media_id"}`))
+	}))
+	defer server.Close()
 
-	// 测试无效JSON
-	type InvalidStruct struct {
-		Channel chan int `json:"channel"`
-	}
+	client := NewClient("test_appid", "test_secret", nil, WithBaseURL(server.URL))
+	client.SetAccessToken("token", 7200)
 
-	data := InvalidStruct{Channel: make(chan int)}
+	// 格式不支持
+	_, err := client.Media.UploadReader("voice", "voice.wav", bytes.NewReader([]byte("data")))
+	assert.True(t, errors.Is(err, wechatgo.InvalidFileType))
 
-	_, err := client.marshalJSON(data)
-	assert.Error(t, err)
+	// 已知大小，上传前校验
+	_, err = client.Media.UploadReader("thumb", "thumb.jpg", bytes.NewReader(make([]byte, 65*1024)))
+	assert.True(t, errors.Is(err, wechatgo.InvalidThumbSize))
+
+	// 未知大小，上传过程中超出限制
+	reader := io.LimitReader(zeroReader{}, 65*1024)
+	_, err = client.Media.UploadReader("thumb", "thumb.jpg", reader)
+	assert.True(t, errors.Is(err, wechatgo.InvalidThumbSize))
 }
 
-func TestMarshalJSON_CacheSizeLimit(t *testing.T) {
-	storage := session.NewMemoryStorage()
-	client := NewBaseClient("test_appid", storage, "https://api.example.com")
+type zeroReader struct{}
 
-	// 添加超过缓存限制的数据
-	for i := 0; i < 105; i++ {
-		data := map[string]int{"key": i}
-		_, err := client.marshalJSON(data)
-		assert.NoError(t, err)
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
 	}
+	return len(p), nil
+}
 
-	// 验证缓存大小不超过限制
-	assert.LessOrEqual(t, len(client.jsonMarshalCache), 100)
+func TestRequest_BodyReflectsMutation(t *testing.T) {
+	var appIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg api.TemplateMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		appIDs = append(appIDs, msg.MiniProgram.AppID)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","msgid":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_appid", "test_secret", nil, WithBaseURL(server.URL))
+	client.SetAccessToken("token", 7200)
+
+	// 指针字段指向的值变化后，请求体也应随之变化
+	msg := &api.TemplateMessage{ToUser: "openid", TemplateID: "tid", MiniProgram: &api.TemplateMiniProgram{AppID: "wx1"}}
+	_, err := client.Template.Send(msg)
+	assert.NoError(t, err)
+	msg.MiniProgram.AppID = "wx2"
+	_, err = client.Template.Send(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wx1", "wx2"}, appIDs)
 }
@@ -1,12 +1,12 @@
 package client
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"time"
+	"strings"
 
+	"github.com/wechatpy/wechatgo"
 	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/client/api/merchant"
 	"github.com/wechatpy/wechatgo/session"
 )
 
@@ -15,6 +15,8 @@ const (
 	APIBaseURL = "https://api.weixin.qq.com/cgi-bin/"
 	// TokenURL 获取 access token 的 URL
 	TokenURL = "https://api.weixin.qq.com/cgi-bin/token"
+	// APIRootURL 微信 API 根地址，卡券、发票、小店等接口不在 /cgi-bin 路径下
+	APIRootURL = "https://api.weixin.qq.com"
 )
 
 // Client 微信客户端
@@ -27,6 +29,11 @@ type Client struct {
 	Message       *api.MessageAPI
 	Menu          *api.MenuAPI
 	Media         *api.MediaAPI
+	Material      *api.MaterialAPI
+	Draft         *api.DraftAPI
+	FreePublish   *api.FreePublishAPI
+	Comment       *api.CommentAPI
+	JSAPI         *api.JSAPI
 	Template      *api.TemplateAPI
 	QRCode        *api.QRCodeAPI
 	Tag           *api.TagAPI
@@ -36,22 +43,31 @@ type Client struct {
 	POI           *api.POIAPI
 	WiFi          *api.WiFiAPI
 	Misc          *api.MiscAPI
+	Card          *api.CardAPI
+	Invoice       *api.InvoiceAPI
+	Merchant      *merchant.API
 }
 
 // NewClient 创建微信客户端
-func NewClient(appID, secret string, storage session.Storage) *Client {
-	baseClient := NewBaseClient(appID, storage, APIBaseURL)
-
+func NewClient(appID, secret string, storage session.Storage, opts ...ClientOption) *Client {
 	client := &Client{
-		BaseClient: baseClient,
-		Secret:     secret,
+		Secret: secret,
 	}
 
+	// 默认通过 AppSecret 向微信获取 token，可被 WithTokenSource 覆盖
+	opts = append([]ClientOption{WithTokenSource(TokenSourceFunc(client.requestAccessToken))}, opts...)
+	client.BaseClient = NewBaseClient(appID, storage, APIBaseURL, opts...)
+
 	// 初始化 API 模块
 	client.User = api.NewUserAPI(client)
 	client.Message = api.NewMessageAPI(client)
 	client.Menu = api.NewMenuAPI(client)
 	client.Media = api.NewMediaAPI(client)
+	client.Material = api.NewMaterialAPI(client)
+	client.Draft = api.NewDraftAPI(client)
+	client.FreePublish = api.NewFreePublishAPI(client)
+	client.Comment = api.NewCommentAPI(client)
+	client.JSAPI = api.NewJSAPI(client, appID)
 	client.Template = api.NewTemplateAPI(client)
 	client.QRCode = api.NewQRCodeAPI(client)
 	client.Tag = api.NewTagAPI(client)
@@ -61,12 +77,34 @@ func NewClient(appID, secret string, storage session.Storage) *Client {
 	client.POI = api.NewPOIAPI(client)
 	client.WiFi = api.NewWiFiAPI(client)
 	client.Misc = api.NewMiscAPI(client)
+	client.Card = api.NewCardAPI(client)
+	client.Invoice = api.NewInvoiceAPI(client, client.JSAPI)
+	client.Merchant = merchant.NewAPI(client)
 
 	return client
 }
 
 // FetchAccessToken 获取 access token
 func (c *Client) FetchAccessToken() error {
+	return c.RefreshAccessToken()
+}
+
+// RootURL 返回 API 根地址下的接口地址，用于不在 /cgi-bin 路径下的接口
+// 优先使用 WithRootURL 指定的地址；通过 WithBaseURL 指定基础 URL 时，去掉其末尾的 /cgi-bin/ 作为根地址
+func (c *Client) RootURL(path string) string {
+	if c.apiRootURL != "" {
+		return strings.TrimSuffix(c.apiRootURL, "/") + path
+	}
+	if !c.customBaseURL {
+		return APIRootURL + path
+	}
+	root := strings.TrimSuffix(c.apiBaseURL, "/")
+	root = strings.TrimSuffix(root, "/cgi-bin")
+	return root + path
+}
+
+// requestAccessToken 使用 AppID 和 AppSecret 向微信请求 access token
+func (c *Client) requestAccessToken() (string, int, error) {
 	params := map[string]string{
 		"grant_type": "client_credential",
 		"appid":      c.AppID,
@@ -74,18 +112,18 @@ func (c *Client) FetchAccessToken() error {
 	}
 
 	// 不使用 BaseClient.Get，因为它会自动添加 access_token 参数
-	result, err := c.fetchToken(TokenURL, params)
+	result, err := c.FetchToken(c.TokenURL(TokenURL, "/token"), params)
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 
 	accessTokenIf, ok := result["access_token"]
 	if !ok {
-		return fmt.Errorf("access_token not found in response")
+		return "", 0, wechatgo.ErrAccessTokenNotFound
 	}
 	accessToken, ok := accessTokenIf.(string)
 	if !ok {
-		return fmt.Errorf("access_token is not a string, got: %T", accessTokenIf)
+		return "", 0, fmt.Errorf("%w, got: %T", wechatgo.ErrAccessTokenInvalidType, accessTokenIf)
 	}
 
 	expiresIn := 7200
@@ -93,66 +131,5 @@ func (c *Client) FetchAccessToken() error {
 		expiresIn = int(exp)
 	}
 
-	return c.SetAccessToken(accessToken, expiresIn)
-}
-
-// fetchToken 获取 token（内部方法，不自动添加 access_token 参数）
-func (c *Client) fetchToken(url string, params map[string]string) (map[string]interface{}, error) {
-	// 构建 URL 参数
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	q := req.URL.Query()
-	for k, v := range params {
-		q.Add(k, v)
-	}
-	req.URL.RawQuery = q.Encode()
-
-	// 创建新的HTTP客户端
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if resp != nil && resp.Body != nil {
-			resp.Body.Close()
-		}
-	}()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	// 检查错误
-	if errcode, ok := result["errcode"]; ok {
-		errcodeInt := int(errcode.(float64))
-		if errcodeInt != 0 {
-			errmsg := ""
-			if msg, ok := result["errmsg"]; ok {
-				errmsg = msg.(string)
-			}
-			return nil, fmt.Errorf("fetch token error: %d - %s", errcodeInt, errmsg)
-		}
-	}
-
-	return result, nil
-}
-
-// GetAccessToken 获取 access token（重写以支持自动刷新）
-func (c *Client) GetAccessToken() (string, error) {
-	token, err := c.BaseClient.GetAccessToken()
-	if err != nil {
-		// Token 不存在或已过期，刷新
-		if err := c.FetchAccessToken(); err != nil {
-			return "", err
-		}
-		return c.BaseClient.GetAccessToken()
-	}
-	return token, nil
+	return accessToken, expiresIn, nil
 }
@@ -0,0 +1,16 @@
+// Package testclient 提供 client 及其子包测试共用的客户端构造方法
+package testclient
+
+import (
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+// New 创建请求发往 baseURL 且已设置 access_token 为 "token" 的客户端
+// baseURL 通常为 httptest.Server 的地址，storage 为 nil 时使用内存存储
+func New(baseURL string, storage session.Storage, opts ...client.ClientOption) *client.Client {
+	opts = append([]client.ClientOption{client.WithBaseURL(baseURL)}, opts...)
+	c := client.NewClient("test_appid", "test_secret", storage, opts...)
+	c.SetAccessToken("token", 7200)
+	return c
+}
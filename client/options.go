@@ -0,0 +1,147 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wechatpy/wechatgo/logger"
+)
+
+// TokenSource access token 来源
+// 可用于接入中控服务器等外部 token 管理方，替代直接向微信请求 token
+type TokenSource interface {
+	// Token 返回新的 access token 及其有效期（秒）
+	Token() (string, int, error)
+}
+
+// TokenSourceFunc 将普通函数适配为 TokenSource
+type TokenSourceFunc func() (string, int, error)
+
+// Token 实现 TokenSource 接口
+func (f TokenSourceFunc) Token() (string, int, error) {
+	return f()
+}
+
+// ClientOption 客户端配置选项
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	httpClient  *http.Client
+	timeout     time.Duration
+	baseURL     string
+	rootURL     string
+	keyPrefix   string
+	proxy       *url.URL
+	logger      logger.Logger
+	autoRetry   *bool
+	tokenSource TokenSource
+	rateLimiter RateLimiter
+}
+
+// WithHTTPClient 设置 HTTP 客户端（同时用于 API 调用和 token 获取）
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTimeout 设置请求超时时间
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithBaseURL 设置 API 基础 URL，token 获取地址也会随之指向该地址
+func WithBaseURL(baseURL string) ClientOption {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithRootURL 设置 API 根地址，卡券、发票、小店等不在 /cgi-bin 路径下的接口使用该地址
+// 未设置时由 WithBaseURL 去掉末尾的 /cgi-bin/ 得到
+func WithRootURL(rootURL string) ClientOption {
+	return func(o *clientOptions) {
+		o.rootURL = rootURL
+	}
+}
+
+// WithKeyPrefix 设置会话存储键的前缀，用于区分共用存储的不同客户端
+func WithKeyPrefix(prefix string) ClientOption {
+	return func(o *clientOptions) {
+		o.keyPrefix = prefix
+	}
+}
+
+// WithProxy 设置 HTTP 代理
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(o *clientOptions) {
+		o.proxy = proxyURL
+	}
+}
+
+// WithLogger 设置 logger
+func WithLogger(l logger.Logger) ClientOption {
+	return func(o *clientOptions) {
+		o.logger = l
+	}
+}
+
+// WithAutoRetry 设置 access token 失效时是否自动刷新并重试
+func WithAutoRetry(autoRetry bool) ClientOption {
+	return func(o *clientOptions) {
+		o.autoRetry = &autoRetry
+	}
+}
+
+// WithTokenSource 设置 access token 来源
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(o *clientOptions) {
+		o.tokenSource = source
+	}
+}
+
+// WithRateLimiter 设置请求限流器，所有接口调用（包括批量操作的各个分片）共享同一个限流器
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(o *clientOptions) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithRateLimit 按每秒请求数 rps 和突发数 burst 设置令牌桶限流，rps 不大于 0 时不限流
+func WithRateLimit(rps float64, burst int) ClientOption {
+	if rps <= 0 {
+		return WithRateLimiter(nil)
+	}
+	return WithRateLimiter(NewRateLimiter(rps, burst))
+}
+
+// buildHTTPClient 根据选项构建 HTTP 客户端
+// 未指定超时和代理时直接复用传入的客户端（默认为全局客户端），否则复制一份再修改，避免影响其他实例
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	if o.timeout <= 0 && o.proxy == nil {
+		return httpClient
+	}
+
+	hc := *httpClient
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+	if o.proxy != nil {
+		var transport *http.Transport
+		if t, ok := hc.Transport.(*http.Transport); ok && t != nil {
+			transport = t.Clone()
+		} else {
+			transport = defaultTransport.Clone()
+		}
+		transport.Proxy = http.ProxyURL(o.proxy)
+		hc.Transport = transport
+	}
+	return &hc
+}
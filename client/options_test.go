@@ -0,0 +1,167 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+func TestNewBaseClient_DefaultOptions(t *testing.T) {
+	client := NewBaseClient("test_appid", nil, "https://api.example.com")
+
+	assert.Same(t, defaultHTTPClient, client.httpClient)
+	assert.True(t, client.autoRetry)
+	assert.Nil(t, client.tokenSource)
+}
+
+func TestNewBaseClient_WithOptions(t *testing.T) {
+	custom := &http.Client{}
+	proxyURL, _ := url.Parse("http://127.0.0.1:8888")
+
+	client := NewBaseClient("test_appid", nil, "https://api.example.com",
+		WithHTTPClient(custom),
+		WithTimeout(5*time.Second),
+		WithProxy(proxyURL),
+		WithBaseURL("http://127.0.0.1:9000/"),
+		WithAutoRetry(false),
+	)
+
+	assert.NotSame(t, custom, client.httpClient)
+	assert.Equal(t, time.Duration(0), custom.Timeout)
+	assert.Equal(t, 5*time.Second, client.httpClient.Timeout)
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	assert.True(t, ok)
+	if ok {
+		got, err := transport.Proxy(httptest.NewRequest("GET", "https://api.weixin.qq.com", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, proxyURL, got)
+	}
+	assert.Equal(t, "http://127.0.0.1:9000/user/info", client.BuildURL("/user/info"))
+	assert.Equal(t, "http://127.0.0.1:9000/token", client.TokenURL(TokenURL, "/token"))
+	assert.False(t, client.autoRetry)
+}
+
+func TestNewBaseClient_WithHTTPClientOnly(t *testing.T) {
+	custom := &http.Client{}
+	client := NewBaseClient("test_appid", nil, "https://api.example.com", WithHTTPClient(custom))
+
+	assert.Same(t, custom, client.httpClient)
+	assert.Equal(t, TokenURL, client.TokenURL(TokenURL, "/token"))
+}
+
+func TestNewClient_WithBaseURL(t *testing.T) {
+	var tokenCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			atomic.AddInt32(&tokenCalls, 1)
+			assert.Equal(t, "test_appid", r.URL.Query().Get("appid"))
+			w.Write([]byte(`{"access_token":"fake_token","expires_in":7200}`))
+		case "/user/info":
+			assert.Equal(t, "fake_token", r.URL.Query().Get("access_token"))
+			w.Write([]byte(`{"openid":"openid","nickname":"nick"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_appid", "test_secret", session.NewMemoryStorage(), WithBaseURL(server.URL))
+
+	result, err := client.User.Get("openid", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "nick", result["nickname"])
+
+	_, err = client.User.Get("openid", "")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenCalls))
+}
+
+func TestNewClient_WithTokenSource(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") == "stale_token" {
+			w.Write([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	source := TokenSourceFunc(func() (string, int, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return "stale_token", 7200, nil
+		}
+		return "fresh_token", 7200, nil
+	})
+	client := NewClient("test_appid", "", nil, WithBaseURL(server.URL), WithTokenSource(source))
+
+	_, err := client.Get("/menu/get", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	token, err := client.GetAccessToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh_token", token)
+}
+
+func TestNewClient_WithAutoRetryDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`))
+	}))
+	defer server.Close()
+
+	var calls int32
+	source := TokenSourceFunc(func() (string, int, error) {
+		atomic.AddInt32(&calls, 1)
+		return "token", 7200, nil
+	})
+	client := NewClient("test_appid", "", nil,
+		WithBaseURL(server.URL), WithTokenSource(source), WithAutoRetry(false))
+
+	_, err := client.Get("/menu/get", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClient_RootURL(t *testing.T) {
+	client := NewClient("test_appid", "test_secret", nil)
+	assert.Equal(t, "https://api.weixin.qq.com/card/create", client.RootURL("/card/create"))
+
+	// 基础 URL 带 /cgi-bin/ 时，根地址去掉该后缀
+	client = NewClient("test_appid", "test_secret", nil, WithBaseURL("https://proxy.example.com/cgi-bin/"))
+	assert.Equal(t, "https://proxy.example.com/cgi-bin/user/info", client.BuildURL("/user/info"))
+	assert.Equal(t, "https://proxy.example.com/card/create", client.RootURL("/card/create"))
+
+	client = NewClient("test_appid", "test_secret", nil,
+		WithBaseURL("https://proxy.example.com/wx/cgi-bin/"),
+		WithRootURL("https://root.example.com/wx/"),
+	)
+	assert.Equal(t, "https://root.example.com/wx/merchant/get", client.RootURL("/merchant/get"))
+}
+
+func TestClient_RootURLRequest(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","card_id":"card_id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_appid", "test_secret", session.NewMemoryStorage(), WithBaseURL(server.URL+"/cgi-bin/"))
+	client.SetAccessToken("token", 7200)
+
+	_, err := client.User.Get("openid", "")
+	assert.NoError(t, err)
+	cardID, err := client.Card.Create(&api.Card{})
+	assert.NoError(t, err)
+	assert.Equal(t, "card_id", cardID)
+	assert.Equal(t, []string{"/cgi-bin/user/info", "/card/create"}, paths)
+}
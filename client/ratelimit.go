@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter 请求限流器，每次调用微信接口前都会先调用 Wait
+type RateLimiter interface {
+	// Wait 阻塞直到允许发送下一个请求，ctx 结束时返回其错误
+	Wait(ctx context.Context) error
+}
+
+// tokenBucket 令牌桶限流器
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒补充的令牌数
+	burst  float64 // 桶容量
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建令牌桶限流器，rps 为每秒允许的请求数，burst 为允许的突发请求数
+// burst 小于 1 时按 1 处理；rps 不大于 0 时返回不做限制的限流器
+func NewRateLimiter(rps float64, burst int) RateLimiter {
+	if rps <= 0 {
+		return unlimited{}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// unlimited 不做限制的限流器
+type unlimited struct{}
+
+// Wait 实现 RateLimiter 接口
+func (unlimited) Wait(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Wait 实现 RateLimiter 接口
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve 预占一个令牌，返回需要等待的时间
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel 归还未使用的令牌
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
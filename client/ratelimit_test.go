@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(100, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	// 突发 2 个，之后每 10ms 一个
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}
+
+func TestRateLimiter_NonPositiveRate(t *testing.T) {
+	limiter := NewRateLimiter(0, 1)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	// rps 不大于 0 时不限流
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}
@@ -0,0 +1,389 @@
+// Package component 实现微信开放平台第三方平台（代公众号调用接口）
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+const (
+	// APIBaseURL 第三方平台接口基础 URL
+	APIBaseURL = "https://api.weixin.qq.com/cgi-bin/"
+	// LoginPageURL PC 端授权页地址
+	LoginPageURL = "https://mp.weixin.qq.com/cgi-bin/componentloginpage"
+	// BindComponentURL 移动端授权页地址
+	BindComponentURL = "https://open.weixin.qq.com/wxaopen/safe/bindcomponent"
+
+	// verifyTicketTTL component_verify_ticket 有效期为 12 小时
+	verifyTicketTTL = 12 * time.Hour
+)
+
+// 授权账号类型
+const (
+	AuthTypeOfficialAccount = 1 // 仅展示公众号
+	AuthTypeMiniProgram     = 2 // 仅展示小程序
+	AuthTypeAll             = 3 // 公众号和小程序都展示
+)
+
+// ErrVerifyTicketNotFound 尚未收到 component_verify_ticket 推送
+var ErrVerifyTicketNotFound = errors.New("component_verify_ticket not found")
+
+// ErrRefreshTokenNotFound 授权方的 authorizer_refresh_token 不存在，需要重新授权
+var ErrRefreshTokenNotFound = errors.New("authorizer_refresh_token not found")
+
+// FuncscopeCategory 权限集
+type FuncscopeCategory struct {
+	ID int `json:"id"`
+}
+
+// FuncInfo 授权给第三方平台的权限集
+type FuncInfo struct {
+	FuncscopeCategory FuncscopeCategory `json:"funcscope_category"`
+}
+
+// AuthorizationInfo 授权信息
+type AuthorizationInfo struct {
+	AuthorizerAppID        string     `json:"authorizer_appid"`
+	AuthorizerAccessToken  string     `json:"authorizer_access_token"`
+	ExpiresIn              int        `json:"expires_in"`
+	AuthorizerRefreshToken string     `json:"authorizer_refresh_token"`
+	FuncInfo               []FuncInfo `json:"func_info"`
+}
+
+// Component 第三方平台客户端
+// component_access_token 和授权方的 token 都保存在会话存储中，多实例部署时应使用共享存储
+type Component struct {
+	AppID          string
+	Secret         string
+	Token          string // 消息校验 Token
+	EncodingAESKey string // 消息加解密 Key
+
+	base *client.BaseClient
+	opts []client.ClientOption
+
+	// clients 按授权方 AppID 缓存的客户端
+	clientsMu sync.Mutex
+	clients   map[string]*client.Client
+}
+
+// NewComponent 创建第三方平台客户端，opts 同时用于 GetClient 返回的授权方客户端
+func NewComponent(appID, secret, token, encodingAESKey string, storage session.Storage, opts ...client.ClientOption) *Component {
+	if storage == nil {
+		storage = session.NewMemoryStorage()
+	}
+	c := &Component{
+		AppID:          appID,
+		Secret:         secret,
+		Token:          token,
+		EncodingAESKey: encodingAESKey,
+		opts:           opts,
+		clients:        make(map[string]*client.Client),
+	}
+
+	// component_access_token 通过 component_verify_ticket 获取，可被 WithTokenSource 覆盖
+	baseOpts := append([]client.ClientOption{client.WithTokenSource(client.TokenSourceFunc(c.requestAccessToken))}, opts...)
+	baseOpts = append(baseOpts, client.WithKeyPrefix("component_"))
+	c.base = client.NewBaseClient(appID, storage, APIBaseURL, baseOpts...)
+	return c
+}
+
+// verifyTicketKey 获取 component_verify_ticket 存储键
+func (c *Component) verifyTicketKey() string {
+	return fmt.Sprintf("%s_component_verify_ticket", c.AppID)
+}
+
+// refreshTokenKey 获取授权方 refresh token 存储键
+func (c *Component) refreshTokenKey(authorizerAppID string) string {
+	return fmt.Sprintf("%s_%s_authorizer_refresh_token", c.AppID, authorizerAppID)
+}
+
+// SetVerifyTicket 保存微信推送的 component_verify_ticket
+func (c *Component) SetVerifyTicket(ticket string) error {
+	return c.base.Session().Set(c.verifyTicketKey(), ticket, verifyTicketTTL)
+}
+
+// GetVerifyTicket 获取最近一次推送的 component_verify_ticket
+func (c *Component) GetVerifyTicket() (string, error) {
+	ticket, err := c.base.Session().Get(c.verifyTicketKey())
+	if err != nil || ticket == "" {
+		return "", ErrVerifyTicketNotFound
+	}
+	return ticket, nil
+}
+
+// GetAccessToken 获取 component_access_token，过期时自动刷新
+func (c *Component) GetAccessToken() (string, error) {
+	return c.base.GetAccessToken()
+}
+
+// requestAccessToken 使用 component_verify_ticket 请求 component_access_token
+// https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/component_access_token.html
+func (c *Component) requestAccessToken() (string, int, error) {
+	ticket, err := c.GetVerifyTicket()
+	if err != nil {
+		return "", 0, err
+	}
+
+	var result struct {
+		ComponentAccessToken string `json:"component_access_token"`
+		ExpiresIn            int    `json:"expires_in"`
+	}
+	err = c.do(c.base.BuildURL("/component/api_component_token"), map[string]string{
+		"component_appid":         c.AppID,
+		"component_appsecret":     c.Secret,
+		"component_verify_ticket": ticket,
+	}, &result)
+	if err != nil {
+		return "", 0, err
+	}
+	if result.ComponentAccessToken == "" {
+		return "", 0, wechatgo.ErrAccessTokenNotFound
+	}
+	return result.ComponentAccessToken, result.ExpiresIn, nil
+}
+
+// CreatePreAuthCode 获取预授权码
+// https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/pre_auth_code.html
+func (c *Component) CreatePreAuthCode() (string, error) {
+	var result struct {
+		PreAuthCode string `json:"pre_auth_code"`
+	}
+	err := c.Post("/component/api_create_preauthcode", map[string]string{
+		"component_appid": c.AppID,
+	}, &result)
+	if err != nil {
+		return "", err
+	}
+	if result.PreAuthCode == "" {
+		return "", fmt.Errorf("unexpected response format")
+	}
+	return result.PreAuthCode, nil
+}
+
+// GetAuthURL 生成 PC 端授权页地址
+// https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/operation/thirdparty/Before_Develop/Authorization_Process_Technical_Description.html
+func (c *Component) GetAuthURL(redirectURI string, authType int) (string, error) {
+	preAuthCode, err := c.CreatePreAuthCode()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s?component_appid=%s&pre_auth_code=%s&redirect_uri=%s&auth_type=%d",
+		LoginPageURL, c.AppID, preAuthCode, url.QueryEscape(redirectURI), authType), nil
+}
+
+// GetMobileAuthURL 生成移动端授权页地址，bizAppID 不为空时指定授权的账号
+func (c *Component) GetMobileAuthURL(redirectURI string, authType int, bizAppID string) (string, error) {
+	preAuthCode, err := c.CreatePreAuthCode()
+	if err != nil {
+		return "", err
+	}
+	authURL := fmt.Sprintf("%s?action=bindcomponent&no_scan=1&component_appid=%s&pre_auth_code=%s&redirect_uri=%s&auth_type=%d",
+		BindComponentURL, c.AppID, preAuthCode, url.QueryEscape(redirectURI), authType)
+	if bizAppID != "" {
+		authURL += "&biz_appid=" + bizAppID
+	}
+	return authURL + "#wechat_redirect", nil
+}
+
+// QueryAuth 使用授权码获取授权信息，并保存授权方的 access token 和 refresh token
+// https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/authorization_info.html
+func (c *Component) QueryAuth(authorizationCode string) (*AuthorizationInfo, error) {
+	var result struct {
+		AuthorizationInfo AuthorizationInfo `json:"authorization_info"`
+	}
+	err := c.Post("/component/api_query_auth", map[string]string{
+		"component_appid":    c.AppID,
+		"authorization_code": authorizationCode,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &result.AuthorizationInfo
+	if err := c.saveAuthorizerToken(info.AuthorizerAppID, info.AuthorizerAccessToken, info.ExpiresIn, info.AuthorizerRefreshToken); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// RefreshAuthorizerToken 使用 refresh token 获取授权方新的 access token，新的 refresh token 会被保存
+// https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/api_authorizer_token.html
+func (c *Component) RefreshAuthorizerToken(authorizerAppID string) (string, int, error) {
+	refreshToken, err := c.GetRefreshToken(authorizerAppID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var result struct {
+		AuthorizerAccessToken  string `json:"authorizer_access_token"`
+		ExpiresIn              int    `json:"expires_in"`
+		AuthorizerRefreshToken string `json:"authorizer_refresh_token"`
+	}
+	err = c.Post("/component/api_authorizer_token", map[string]string{
+		"component_appid":          c.AppID,
+		"authorizer_appid":         authorizerAppID,
+		"authorizer_refresh_token": refreshToken,
+	}, &result)
+	if err != nil {
+		return "", 0, err
+	}
+	if result.AuthorizerAccessToken == "" {
+		return "", 0, wechatgo.ErrAccessTokenNotFound
+	}
+
+	// 刷新接口可能返回新的 refresh token
+	if result.AuthorizerRefreshToken != "" && result.AuthorizerRefreshToken != refreshToken {
+		if err := c.SetRefreshToken(authorizerAppID, result.AuthorizerRefreshToken); err != nil {
+			return "", 0, err
+		}
+	}
+	return result.AuthorizerAccessToken, result.ExpiresIn, nil
+}
+
+// GetRefreshToken 获取授权方的 authorizer_refresh_token
+func (c *Component) GetRefreshToken(authorizerAppID string) (string, error) {
+	refreshToken, err := c.base.Session().Get(c.refreshTokenKey(authorizerAppID))
+	if err != nil || refreshToken == "" {
+		return "", ErrRefreshTokenNotFound
+	}
+	return refreshToken, nil
+}
+
+// SetRefreshToken 保存授权方的 authorizer_refresh_token，可用于从数据库恢复授权关系
+func (c *Component) SetRefreshToken(authorizerAppID, refreshToken string) error {
+	return c.base.Session().Set(c.refreshTokenKey(authorizerAppID), refreshToken, 0)
+}
+
+// RemoveAuthorizer 删除授权方的 refresh token、access token 和缓存的客户端
+func (c *Component) RemoveAuthorizer(authorizerAppID string) error {
+	if err := c.base.Session().Delete(c.refreshTokenKey(authorizerAppID)); err != nil {
+		return err
+	}
+	if err := c.GetClient(authorizerAppID).ClearAccessToken(); err != nil {
+		return err
+	}
+
+	c.clientsMu.Lock()
+	delete(c.clients, authorizerAppID)
+	c.clientsMu.Unlock()
+	return nil
+}
+
+// saveAuthorizerToken 保存授权方的 access token 和 refresh token
+func (c *Component) saveAuthorizerToken(authorizerAppID, accessToken string, expiresIn int, refreshToken string) error {
+	if authorizerAppID == "" {
+		return fmt.Errorf("unexpected response format")
+	}
+	if refreshToken != "" {
+		if err := c.SetRefreshToken(authorizerAppID, refreshToken); err != nil {
+			return err
+		}
+	}
+	if accessToken != "" {
+		return c.GetClient(authorizerAppID).SetAccessToken(accessToken, expiresIn)
+	}
+	return nil
+}
+
+// GetClient 返回代授权方调用接口的公众号客户端，同一授权方复用同一个客户端
+// 客户端的 access token 过期时使用保存的 refresh token 自动刷新
+// access token 的存储键以第三方平台 AppID 为前缀，不会与直接使用该公众号的客户端冲突
+func (c *Component) GetClient(authorizerAppID string) *client.Client {
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	if cli, ok := c.clients[authorizerAppID]; ok {
+		return cli
+	}
+
+	source := client.TokenSourceFunc(func() (string, int, error) {
+		return c.RefreshAuthorizerToken(authorizerAppID)
+	})
+	opts := append(append([]client.ClientOption{}, c.opts...),
+		client.WithTokenSource(source),
+		client.WithKeyPrefix(c.AppID+"_"),
+	)
+	cli := client.NewClient(authorizerAppID, "", c.base.Session(), opts...)
+	c.clients[authorizerAppID] = cli
+	return cli
+}
+
+// GetAuthorizerInfo 获取授权方的账号基本信息
+// https://developers.weixin.qq.com/doc/oplatform/Third-party_Platforms/2.0/api/ThirdParty/token/api_get_authorizer_info.html
+func (c *Component) GetAuthorizerInfo(authorizerAppID string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.Post("/component/api_get_authorizer_info", map[string]string{
+		"component_appid":  c.AppID,
+		"authorizer_appid": authorizerAppID,
+	}, &result)
+	return result, err
+}
+
+// Post 使用 component_access_token 调用第三方平台接口，结果解析到 v
+// component_access_token 失效时刷新后重试一次
+func (c *Component) Post(endpoint string, data interface{}, v interface{}) error {
+	token, err := c.GetAccessToken()
+	if err != nil {
+		return err
+	}
+
+	err = c.do(c.tokenURL(endpoint, token), data, v)
+	if errors.Is(err, wechatgo.ErrInvalidCredential) || errors.Is(err, wechatgo.ErrInvalidAccessToken) ||
+		errors.Is(err, wechatgo.ErrExpiredAccessToken) {
+		if err := c.base.RefreshAccessToken(); err != nil {
+			return err
+		}
+		if token, err = c.GetAccessToken(); err != nil {
+			return err
+		}
+		return c.do(c.tokenURL(endpoint, token), data, v)
+	}
+	return err
+}
+
+// tokenURL 构建带 component_access_token 参数的地址
+func (c *Component) tokenURL(endpoint, token string) string {
+	return c.base.BuildURL(endpoint) + "?component_access_token=" + url.QueryEscape(token)
+}
+
+// do 发送 POST 请求并检查 errcode
+func (c *Component) do(reqURL string, data interface{}, v interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.base.HTTPClient().Post(reqURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+	if err := wechatgo.CheckResponse(result, resp, body); err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
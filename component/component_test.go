@@ -0,0 +1,151 @@
+package component
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/crypto"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+const (
+	testAppID          = "wx_component"
+	testToken          = "token"
+	testEncodingAESKey = "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFG"
+)
+
+// encryptMessage 模拟微信服务器加密回调通知
+func encryptMessage(t *testing.T, plaintext string) (string, string, string, []byte) {
+	key, err := base64.StdEncoding.DecodeString(testEncodingAESKey + "=")
+	assert.NoError(t, err)
+	prp, err := crypto.NewPrpCrypto(key)
+	assert.NoError(t, err)
+	encrypted, err := prp.Encrypt(plaintext, testAppID)
+	assert.NoError(t, err)
+
+	timestamp, nonce := "1413192605", "nonce"
+	signer := wechatgo.NewSigner("")
+	signer.AddData(testToken, timestamp, nonce, encrypted)
+	body := fmt.Sprintf("<xml><AppId>%s</AppId><Encrypt><![CDATA[%s]]></Encrypt></xml>", testAppID, encrypted)
+	return signer.Signature(), timestamp, nonce, []byte(body)
+}
+
+func TestComponentFlow(t *testing.T) {
+	var componentTokenCalls, refreshCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&req)
+		}
+		switch r.URL.Path {
+		case "/component/api_component_token":
+			atomic.AddInt32(&componentTokenCalls, 1)
+			assert.Equal(t, "TICKET", req["component_verify_ticket"])
+			w.Write([]byte(`{"component_access_token":"COMPONENT_TOKEN","expires_in":7200}`))
+		case "/component/api_create_preauthcode":
+			assert.Equal(t, "COMPONENT_TOKEN", r.URL.Query().Get("component_access_token"))
+			w.Write([]byte(`{"pre_auth_code":"PRE_AUTH_CODE","expires_in":600}`))
+		case "/component/api_query_auth":
+			assert.Equal(t, "AUTH_CODE", req["authorization_code"])
+			w.Write([]byte(`{"authorization_info":{"authorizer_appid":"wx_authorizer","authorizer_access_token":"AUTHORIZER_TOKEN","expires_in":7200,"authorizer_refresh_token":"REFRESH_TOKEN","func_info":[{"funcscope_category":{"id":1}}]}}`))
+		case "/component/api_authorizer_token":
+			atomic.AddInt32(&refreshCalls, 1)
+			assert.Equal(t, "REFRESH_TOKEN", req["authorizer_refresh_token"])
+			w.Write([]byte(`{"authorizer_access_token":"NEW_AUTHORIZER_TOKEN","expires_in":7200,"authorizer_refresh_token":"NEW_REFRESH_TOKEN"}`))
+		case "/menu/get":
+			token := r.URL.Query().Get("access_token")
+			if token == "AUTHORIZER_TOKEN" {
+				w.Write([]byte(`{"errcode":42001,"errmsg":"access_token expired"}`))
+				return
+			}
+			assert.Equal(t, "NEW_AUTHORIZER_TOKEN", token)
+			w.Write([]byte(`{"menu":{"button":[]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	storage := session.NewMemoryStorage()
+	c := NewComponent(testAppID, "secret", testToken, testEncodingAESKey, storage, client.WithBaseURL(server.URL))
+
+	_, err := c.GetAccessToken()
+	assert.ErrorIs(t, err, ErrVerifyTicketNotFound)
+
+	// 验证票据推送
+	msg, err := c.HandleMessage(encryptMessage(t, `<xml><AppId>wx_component</AppId><CreateTime>1413192605</CreateTime><InfoType><![CDATA[component_verify_ticket]]></InfoType><ComponentVerifyTicket><![CDATA[TICKET]]></ComponentVerifyTicket></xml>`))
+	assert.NoError(t, err)
+	assert.IsType(t, &VerifyTicketMessage{}, msg)
+
+	authURL, err := c.GetAuthURL("https://example.com/auth", AuthTypeAll)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(authURL, "pre_auth_code=PRE_AUTH_CODE"))
+	assert.True(t, strings.Contains(authURL, "redirect_uri=https%3A%2F%2Fexample.com%2Fauth"))
+
+	// 授权成功通知
+	msg, err = c.HandleMessage(encryptMessage(t, `<xml><AppId>wx_component</AppId><CreateTime>1413192760</CreateTime><InfoType>authorized</InfoType><AuthorizerAppid>wx_authorizer</AuthorizerAppid><AuthorizationCode>AUTH_CODE</AuthorizationCode><AuthorizationCodeExpiredTime>1413196360</AuthorizationCodeExpiredTime><PreAuthCode>PRE_AUTH_CODE</PreAuthCode></xml>`))
+	assert.NoError(t, err)
+	authorized := msg.(*AuthorizedMessage)
+	assert.Equal(t, "wx_authorizer", authorized.AuthorizerAppID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&componentTokenCalls))
+
+	// 授权方客户端在 token 过期时使用 refresh token 刷新
+	authorizer := c.GetClient("wx_authorizer")
+	_, err = authorizer.Get("/menu/get", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshCalls))
+
+	refreshToken, err := c.GetRefreshToken("wx_authorizer")
+	assert.NoError(t, err)
+	assert.Equal(t, "NEW_REFRESH_TOKEN", refreshToken)
+
+	// 取消授权通知
+	msg, err = c.HandleMessage(encryptMessage(t, `<xml><AppId>wx_component</AppId><CreateTime>1413192760</CreateTime><InfoType>unauthorized</InfoType><AuthorizerAppid>wx_authorizer</AuthorizerAppid></xml>`))
+	assert.NoError(t, err)
+	assert.IsType(t, &UnauthorizedMessage{}, msg)
+	_, err = c.GetRefreshToken("wx_authorizer")
+	assert.ErrorIs(t, err, ErrRefreshTokenNotFound)
+}
+
+func TestDecryptMessage_InvalidSignature(t *testing.T) {
+	c := NewComponent(testAppID, "secret", testToken, testEncodingAESKey, nil)
+
+	_, timestamp, nonce, body := encryptMessage(t, `<xml><InfoType>component_verify_ticket</InfoType></xml>`)
+	_, err := c.DecryptMessage("bad_signature", timestamp, nonce, body)
+	assert.IsType(t, &wechatgo.InvalidSignatureError{}, err)
+}
+
+func TestGetClient_Cache(t *testing.T) {
+	storage := session.NewMemoryStorage()
+	c := NewComponent(testAppID, "secret", testToken, testEncodingAESKey, storage)
+
+	authorizer := c.GetClient("wx_authorizer")
+	assert.Same(t, authorizer, c.GetClient("wx_authorizer"))
+	assert.NotSame(t, authorizer, c.GetClient("wx_other"))
+
+	// 授权方 token 以第三方平台 AppID 为前缀存储，不影响直接使用该公众号的客户端
+	assert.NoError(t, authorizer.SetAccessToken("AUTHORIZER_TOKEN", 7200))
+	token, _ := storage.Get("wx_authorizer_access_token")
+	assert.Empty(t, token)
+	direct := client.NewClient("wx_authorizer", "secret", storage)
+	assert.NoError(t, direct.SetAccessToken("DIRECT_TOKEN", 7200))
+
+	assert.NoError(t, c.SetRefreshToken("wx_authorizer", "REFRESH_TOKEN"))
+	assert.NoError(t, c.RemoveAuthorizer("wx_authorizer"))
+	assert.NotSame(t, authorizer, c.GetClient("wx_authorizer"))
+	token, _ = storage.Get(testAppID + "_wx_authorizer_access_token")
+	assert.Empty(t, token)
+
+	token, err := direct.GetAccessToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "DIRECT_TOKEN", token)
+}
@@ -0,0 +1,151 @@
+package component
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/crypto"
+)
+
+// InfoType 第三方平台回调通知类型
+type InfoType string
+
+// 通知类型
+const (
+	InfoTypeVerifyTicket     InfoType = "component_verify_ticket" // 验证票据
+	InfoTypeAuthorized       InfoType = "authorized"              // 授权成功
+	InfoTypeUnauthorized     InfoType = "unauthorized"            // 取消授权
+	InfoTypeUpdateAuthorized InfoType = "updateauthorized"        // 授权更新
+)
+
+// BaseMessage 第三方平台回调通知
+type BaseMessage struct {
+	XMLName    xml.Name `xml:"xml"`
+	AppID      string   `xml:"AppId"`
+	CreateTime int64    `xml:"CreateTime"`
+	InfoType   string   `xml:"InfoType"`
+}
+
+// VerifyTicketMessage 验证票据推送
+type VerifyTicketMessage struct {
+	BaseMessage
+	ComponentVerifyTicket string `xml:"ComponentVerifyTicket"`
+}
+
+// AuthorizedMessage 授权成功通知
+type AuthorizedMessage struct {
+	BaseMessage
+	AuthorizerAppID              string `xml:"AuthorizerAppid"`
+	AuthorizationCode            string `xml:"AuthorizationCode"`
+	AuthorizationCodeExpiredTime int64  `xml:"AuthorizationCodeExpiredTime"`
+	PreAuthCode                  string `xml:"PreAuthCode"`
+}
+
+// UpdateAuthorizedMessage 授权更新通知
+type UpdateAuthorizedMessage struct {
+	AuthorizedMessage
+}
+
+// UnauthorizedMessage 取消授权通知
+type UnauthorizedMessage struct {
+	BaseMessage
+	AuthorizerAppID string `xml:"AuthorizerAppid"`
+}
+
+// ParseMessage 解析已解密的第三方平台回调通知
+// 未知类型返回 *BaseMessage
+func ParseMessage(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty message data")
+	}
+
+	var base BaseMessage
+	if err := xml.Unmarshal(data, &base); err != nil {
+		return nil, &wechatgo.ParseError{RawData: data, Err: err}
+	}
+
+	var msg interface{}
+	switch InfoType(base.InfoType) {
+	case InfoTypeVerifyTicket:
+		msg = &VerifyTicketMessage{}
+	case InfoTypeAuthorized:
+		msg = &AuthorizedMessage{}
+	case InfoTypeUpdateAuthorized:
+		msg = &UpdateAuthorizedMessage{}
+	case InfoTypeUnauthorized:
+		msg = &UnauthorizedMessage{}
+	default:
+		return &base, nil
+	}
+	if err := xml.Unmarshal(data, msg); err != nil {
+		return nil, &wechatgo.ParseError{RawData: data, Err: err}
+	}
+	return msg, nil
+}
+
+// encryptedMessage 加密的回调通知
+type encryptedMessage struct {
+	XMLName xml.Name `xml:"xml"`
+	AppID   string   `xml:"AppId"`
+	Encrypt string   `xml:"Encrypt"`
+}
+
+// DecryptMessage 校验签名并解密回调通知
+// msgSignature、timestamp、nonce 为回调 URL 中的 msg_signature、timestamp、nonce 参数
+func (c *Component) DecryptMessage(msgSignature, timestamp, nonce string, body []byte) ([]byte, error) {
+	var encrypted encryptedMessage
+	if err := xml.Unmarshal(body, &encrypted); err != nil {
+		return nil, &wechatgo.ParseError{RawData: body, Err: err}
+	}
+
+	signer := wechatgo.NewSigner("")
+	signer.AddData(c.Token, timestamp, nonce, encrypted.Encrypt)
+	if signer.Signature() != msgSignature {
+		return nil, wechatgo.NewInvalidSignatureError()
+	}
+
+	key, err := base64.StdEncoding.DecodeString(c.EncodingAESKey + "=")
+	if err != nil {
+		return nil, fmt.Errorf("invalid EncodingAESKey: %w", err)
+	}
+	prp, err := crypto.NewPrpCrypto(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := prp.Decrypt(encrypted.Encrypt, c.AppID)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(plaintext), nil
+}
+
+// HandleMessage 校验、解密并处理回调通知，返回解析后的通知
+// 验证票据会被保存；授权成功和授权更新时使用授权码换取并保存授权方 token；取消授权时删除授权方 token
+// 处理成功后应向微信返回 "success"
+func (c *Component) HandleMessage(msgSignature, timestamp, nonce string, body []byte) (interface{}, error) {
+	data, err := c.DecryptMessage(msgSignature, timestamp, nonce, body)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := ParseMessage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m := msg.(type) {
+	case *VerifyTicketMessage:
+		err = c.SetVerifyTicket(m.ComponentVerifyTicket)
+	case *AuthorizedMessage:
+		_, err = c.QueryAuth(m.AuthorizationCode)
+	case *UpdateAuthorizedMessage:
+		_, err = c.QueryAuth(m.AuthorizationCode)
+	case *UnauthorizedMessage:
+		err = c.RemoveAuthorizer(m.AuthorizerAppID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -51,7 +51,7 @@ const (
 	// 请开发者确认 OpenID 是否已关注公众号，或是否是其他公众号的 OpenID
 	InvalidOpenID WeChatErrorCode = 40003
 
-	// 不支持��媒体文件类型
+	// 不支持的媒体文件类型
 	InvalidMediaType WeChatErrorCode = 40004
 
 	// 不支持的文件类型
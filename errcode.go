@@ -0,0 +1,235 @@
+package wechatgo
+
+import "fmt"
+
+// errCodeDescriptions 微信全局返回码说明
+var errCodeDescriptions = map[WeChatErrorCode]string{
+	SystemError:                  "系统错误",
+	SystemBusy:                   "系统繁忙，此时请开发者稍候再试",
+	Success:                      "请求成功",
+	InvalidCredential:            "AppSecret 错误，或是 Access Token 无效",
+	InvalidCredentialType:        "错误的凭证类型",
+	InvalidOpenID:                "错误的 OpenID",
+	InvalidMediaType:             "不支持的媒体文件类型",
+	InvalidFileType:              "不支持的文件类型",
+	InvalidFileSize:              "不支持的文件大小",
+	InvalidMediaID:               "错误的 MediaID",
+	InvalidMessageType:           "错误的消息类型",
+	InvalidImageSize:             "不支持的图片大小，图片格式不对有时也会报这个错",
+	InvalidVoiceSize:             "不支持的语音文件大小",
+	InvalidVideoSize:             "不支持的视频文件大小",
+	InvalidThumbSize:             "不支持的缩略图大小",
+	InvalidAppID:                 `错误的 AppID，目前 AppID 格式都是 /^wx\d{16}$/`,
+	InvalidAccessToken:           "不合法的 Access Token",
+	InvalidButtonType:            "错误的按钮类型",
+	InvalidButtonSize:            "不支持的主菜单按钮个数，微信自定义菜单按钮个数应该在 1~3 个之间",
+	InvalidSubButtonSize:         "不支持的子菜单按钮个数，微信自定义子菜单按钮个数应该在 1~5 个之间",
+	InvalidButtonNameSize:        "不支持的按钮名字长度",
+	InvalidButtonKeySize:         "不支持的按钮 key 长度",
+	InvalidButtonURLSize:         "不支持的按钮 url 长度",
+	InvalidMenuVersion:           "不合法的菜单版本号",
+	InvalidSubButtonLevel:        "不合法的子菜单级数",
+	InvalidSubButtonCount:        "不合法的子菜单按钮个数",
+	InvalidSubButtonType:         "不合法的子菜单按钮类型",
+	InvalidSubButtonNameSize:     "不合法的子菜单按钮名字长度",
+	InvalidSubButtonKeySize:      "不合法的子菜单按钮 key 长度",
+	InvalidSubButtonURLSize:      "不合法的子菜单按钮 url 长度",
+	InvalidMenuUser:              "不合法的自定义菜单使用用户",
+	InvalidOAuthCode:             "错误的 OAuth Code",
+	InvalidRefreshToken:          "错误的 Refresh Token",
+	InvalidOpenIDList:            "错误的 OpenID 列表",
+	InvalidOpenIDListSize:        "错误的 OpenID 列表长度，列表内最多10000个 OpenID",
+	InvalidRequestCharset:        `不支持的请求字符，不能包含 \uxxxx 格式的字符`,
+	InvalidParameter:             "不合法的参数",
+	InvalidTemplate:              "错误的模板消息 ID，Template ID 失效了，请重新刷新一次 Template ID",
+	InvalidRequestFormat:         "不合法的请求格式",
+	InvalidURLSize:               "不合法的 url 长度",
+	InvalidURLDomain:             "无效的 url",
+	InvalidGroupID:               "不合法的分组 ID",
+	InvalidGroupName:             "不合法的分组名字，40117 也是这个错误",
+	InvalidActionInfo:            "不支持的操作，可能是该公众号已经申请完了十万个二维码",
+	InvalidButtonDomain:          "自定义菜单的按钮里，网址有误",
+	InvalidSubButtonDomain:       "自定义子菜单的按钮里，网址有误",
+	InvalidDeleteArticleID:       "删除单篇图文时，指定的 article_idx 不合法",
+	InvalidIndustryID:            "错误的行业号，有一些模板消息只会在特定的行业下申请",
+	InvalidMediaIDSize:           "不支持的 MediaID 长度",
+	InvalidUseButtonType:         "button 类型错误",
+	InvalidUseSubButtonType:      "子 button 类型错误",
+	InvalidMediaIDType:           "不支持的 MediaID 类型",
+	InvalidAppSecret:             "无效的 AppSecret",
+	InvalidWeChatID:              "微信号不合法",
+	InvalidImageFormat:           "不支持的图片格式",
+	ContainOtherHomePageURL:      "请勿添加其他公众号的主页链接",
+	CodeBeenUsed:                 "OAuth Code 已使用",
+	MissingAccessToken:           "缺少 Access Token 参数",
+	MissingAppID:                 "缺少 AppID 参数",
+	MissingRefreshToken:          "缺少 Refresh Token 参数",
+	MissingAppSecret:             "缺少 AppSecret 参数",
+	MissingMediaData:             "缺少多媒体文件数据",
+	MissingMediaID:               "缺少 MediaID 参数",
+	MissingSubButtons:            "缺少子菜单数据",
+	MissingOAuthCode:             "缺少 OAuth Code",
+	MissingOpenID:                "缺少 OpenID",
+	InvalidPage:                  "page 路径不正确，需要保证在现网版本小程序中存在，与 app.json 保持一致",
+	ExpiredAccessToken:           "Access Token 已失效，请检查 Access Token 的有效期，重新刷新 Access Token",
+	ExpiredRefreshToken:          "Refresh Token 已失效",
+	ExpiredOAuthCode:             "OAuth Code 已失效",
+	ExpiredAuthorization:         "授权已失效，用户修改微信密码，Access Token, Refresh Token 均已失效，需要重新授权",
+	RequireGet:                   "需要 Get 请求",
+	RequirePost:                  "需要 Post 请求",
+	RequireHTTPS:                 "需要 Https 请求",
+	RequireSubscribe:             "用户没有关注公众号",
+	RequireFriend:                "需要好友关系",
+	RequireUnblockUser:           "用户被拉黑，需要公众号把该用户从黑名单里移除",
+	OutOfChangeIndustryLimit:     "超过了更换行业的限制，一个月最多换一次",
+	UserRefuseToAcceptTheMessage: "用户拒绝接受消息，如果用户之前曾经订阅过，则表示用户取消了订阅关系",
+	EmptyMediaData:               "多媒体文件为空",
+	EmptyPostData:                "POST 的数据包为空",
+	EmptyNewsData:                "图文消息内容为空",
+	EmptyContent:                 "文本消息内容为空",
+	OutOfMediaSizeLimit:          "多媒体文件大小超过限制，最大允许 1MB",
+	OutOfContentSizeLimit:        "消息内容超过限制",
+	OutOfTitleSizeLimit:          "标题长度超过限制，最长允许 64 字符长度",
+	OutOfDescriptionSizeLimit:    "描述字段超过限制",
+	OutOfURLSizeLimit:            "链接字段超过限制",
+	OutOfPicURLSizeLimit:         "图片链接字段超过限制",
+	OutOfVoiceTimeLimit:          "语音播放时间超过限制，最长允许 60 秒",
+	OutOfArticleSizeLimit:        "图文消息数量超过限制，最多 10 条图文消息",
+	OutOfAPIFreqLimit:            "接口调用频率超过限制",
+	OutOfMenuSizeLimit:           "创建菜单个数超过限制",
+	APIMinuteQuotaReachLimit:     "API 调用太频繁，请稍候再试",
+	OutOfResponseTimeLimit:       "回复时间超过限制",
+	SystemGroupCannotChange:      "系统分组，不允许修改",
+	OutOfGroupNameSizeLimit:      "分组名字过长",
+	OutOfGroupSizeLimit:          "分组数量超过上限",
+	OutOfTemplateSizeLimit:       "模板消息数量超过限制",
+	TemplateConflictWithIndustry: "模板消息与行业信息冲突",
+	OutOfResponseCountLimit:      "客服接口下行条数超过上限",
+	NoPermissionToUseWeappInMenu: "创建菜单包含未关联的小程序",
+	ClientMsgIDExist:             "相同 clientmsgid 已存在群发记录，返回数据中带有已存在的群发任务的 msgid",
+	OutOfClientMsgIDAPIFreqLimit: "相同 clientmsgid 重试速度过快，请间隔1分钟重试",
+	ClientMsgIDSizeOutOfLimit:    "clientmsgid 长度超过限制",
+	InvalidContent:               "不支持的图文消息内容，请确认 content 里没有超链接标签",
+	MediaDataNoExist:             "不存在媒体数据",
+	MenuVersionNotExist:          "不存在的菜单版本",
+	MenuNoExist:                  "不存在的菜单数据",
+	UserNotExist:                 "不存在的用户",
+	DataFormatError:              "解析 JSON/XML 内容错误",
+	InvalidTemplateArgument:      "模板参数不准确，可能为空或者不满足规则，errmsg 会提示具体是哪个字段出错",
+	UnauthorizedAPI:              "API 功能未授权，请确认公众号已获得该接口，可以在公众平台官网-开发者中心页中查看接口权限",
+	UserBlockMessage:             `用户拒收公众号消息 (在公众号选项中，关闭了"接收消息")`,
+	UserNotAgreeProtocol:         "公众号管理员没有同意微信群发协议，请登录公众号后台点一下同意",
+	APIBanned:                    "API 接口被封禁，请登录公众号后台查看详情",
+	APIDeleteProhibited:          "API 禁止删除被自动回复和自定义菜单引用的素材",
+	OutOfResetLimit:              "API 清零次数失败，因为清零次数达到上限",
+	NoPermissionForThisMsgType:   "没有该类型消息的发送权限",
+	UserUnauthorized:             "用户未授权该 API",
+	UserLimited:                  "用户受限，可能是违规后接口被封禁",
+	UnsubscribeOfficialAccount:   "用户未关注的公众号",
+	PublishLimited:               "发布功能被封禁",
+	OutOfPublishLimit:            "频繁请求发布",
+	InvalidPublishID:             "Publish ID 无效",
+	InvalidArticleID:             "Article ID 无效",
+	UnauthorizedComponent:        "公众号未授权给开放平台",
+	UnauthorizedComponentAPI:     "公众号未授权该 API 给开放平台",
+	InvalidComponentRefreshToken: "错误的开放平台 Refresh Token",
+	ErrorParameter:               "参数错误",
+	InvalidKFAccount:             "无效客服账号",
+	KFAccountExisted:             "客服帐号已存在",
+	InvalidKFAccountLength:       "客服帐号名长度超过限制 ( 仅允许 10 个英文字符，不包括 @ 及 @ 后的公众号的微信号 )",
+	IllegalCharterInKFAccount:    "客服帐号名包含非法字符 ( 仅允许英文 + 数字 )",
+	KFAccountExceeded:            "客服帐号个数超过限制 (10 个客服账号 )",
+	InvalidAvatarFileType:        "无效头像文件类型",
+	DateFormatError:              "日期格式错误",
+	MissingParameter:             "部分参数为空",
+	InvalidJSSDKSignature:        "无效的 JS SDK 签名",
+	InvalidMenuID:                "不存在此 menuid 对应的个性化菜单",
+	ThereIsNoSelfMenu:            "没有默认菜单，不能创建个性化菜单",
+	MatchRuleEmpty:               "MatchRule 信息为空",
+	MenuCountLimit:               "个性化菜单数量受限",
+	InvalidAccountForMenu:        "不支持个性化菜单的帐号",
+	EmptyMenu:                    "个性化菜单信息为空",
+	ButtonMissingResponse:        "包含没有响应类型的 button",
+	DisableMenu:                  "个性化菜单开关处于关闭状态",
+	MissingCountry:               "填写了省份或城市信息，国家信息不能为空",
+	MissingProvince:              "填写了城市信息，省份信息不能为空",
+	InvalidCountry:               "不合法的国家信息",
+	InvalidProvince:              "不合法的省份信息",
+	InvalidCityInfo:              "不合法的城市信息",
+	DomainCountReachLimit:        "该公众号的菜单设置了过多的域名外跳（最多跳转到 3 个域名的链接）",
+	InvalidURL:                   "不合法的 URL",
+	InvalidSignature:             "无效的签名",
+	RiskyContent:                 "内容可能潜在风险",
+	InvalidPostData:              "POST 数据参数不合法",
+	RemoteServiceUnavailable:     "远端服务不可用",
+	InvalidTicket:                "Ticket 不合法",
+	GetUserFailed:                "获取摇周边用户信息失败",
+	GetMerchantFailed:            "获取商户信息失败",
+	GetOpenIDFailed:              "获取 OpenID 失败",
+	UploadFileMissing:            "上传文件缺失",
+	UploadFileTypeError:          "上传素材的文件类型不合法",
+	UploadFileSizeError:          "上传素材的文件尺寸不合法",
+	UploadFailed:                 "上传失败",
+	InvalidAccount:               "帐号不合法",
+	ActiveDeviceLessThanHalf:     "已有设备激活率低于 50% ，不能新增设备",
+	InvalidDeviceCount:           "设备申请数不合法，必须为大于 0 的数字",
+	DeviceIDExisted:              "已存在审核中的设备 ID 申请",
+	OutOfDeviceQueryLimit:        "一次查询设备 ID 数量不能超过 50",
+	InvalidDeviceID:              "设备 ID 不合法",
+	InvalidPageID:                "页面 ID 不合法",
+	InvalidPageParameter:         "页面参数不合法",
+	OutOfPageDeleteLimit:         "一次删除页面 ID 数量不能超过 10",
+	PageAppliedInDevice:          "页面已应用在设备中，请先解除应用关系再删除",
+	OutOfPageQueryLimit:          "一次查询页面 ID 数量不能超过 50",
+	InvalidTimeRange:             "时间区间不合法",
+	BindDevicePageParameterError: "保存设备与页面的绑定关系参数错误",
+	InvalidLocationID:            "门店 ID 不合法",
+	OutOfDeviceDescLengthLimit:   "设备备注信息过长",
+	InvalidDeviceParameter:       "设备申请参数不合法",
+	InvalidBegin:                 "查询起始值 begin 不合法",
+}
+
+// retryableErrCodes 可重试的返回码
+// 包括系统繁忙、频率限制以及可通过刷新 access token 恢复的错误
+var retryableErrCodes = map[WeChatErrorCode]bool{
+	SystemError:              true,
+	SystemBusy:               true,
+	InvalidCredential:        true,
+	InvalidAccessToken:       true,
+	ExpiredAccessToken:       true,
+	OutOfAPIFreqLimit:        true,
+	APIMinuteQuotaReachLimit: true,
+	RemoteServiceUnavailable: true,
+}
+
+// Description 返回码说明
+func (c WeChatErrorCode) Description() string {
+	if desc, ok := errCodeDescriptions[c]; ok {
+		return desc
+	}
+	return "未知错误"
+}
+
+// Retryable 该返回码对应的错误是否可以重试
+func (c WeChatErrorCode) Retryable() bool {
+	return retryableErrCodes[c]
+}
+
+// String 实现 fmt.Stringer 接口
+func (c WeChatErrorCode) String() string {
+	return fmt.Sprintf("%d: %s", int(c), c.Description())
+}
+
+// Error 实现 error 接口，使返回码可直接用作 errors.Is 的比较目标
+func (c WeChatErrorCode) Error() string {
+	return c.String()
+}
+
+// ErrCodes 返回所有已知的返回码
+func ErrCodes() []WeChatErrorCode {
+	codes := make([]WeChatErrorCode, 0, len(errCodeDescriptions))
+	for code := range errCodeDescriptions {
+		codes = append(codes, code)
+	}
+	return codes
+}
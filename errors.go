@@ -1,10 +1,36 @@
 package wechatgo
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 )
 
+// 常用返回码的哨兵错误，可配合 errors.Is 使用
+// 所有 WeChatErrorCode 均实现了 error 接口，也可直接作为 errors.Is 的比较目标
+var (
+	ErrSystemBusy         error = SystemBusy
+	ErrInvalidCredential  error = InvalidCredential
+	ErrInvalidAccessToken error = InvalidAccessToken
+	ErrExpiredAccessToken error = ExpiredAccessToken
+	ErrOutOfAPIFreqLimit  error = OutOfAPIFreqLimit
+	ErrInvalidOpenID      error = InvalidOpenID
+	ErrInvalidMediaID     error = InvalidMediaID
+)
+
+// access token 获取相关错误
+var (
+	ErrAccessTokenNotFound    = errors.New("access_token not found in response")
+	ErrAccessTokenInvalidType = errors.New("access_token is not a string")
+	ErrAccessTokenExpired     = errors.New("access token expired or not found")
+	ErrOAuthTokenNotCached    = errors.New("oauth access token not cached for openid")
+)
+
+// ridPattern 匹配微信 errmsg 末尾的请求 ID，如 "invalid credential rid: 5f5f0e0a-1c2b3d4e-5f6a7b8c"
+var ridPattern = regexp.MustCompile(`rid:\s*([0-9A-Za-z-]+)`)
+
 // Error 微信错误基类
 type Error struct {
 	ErrCode int
@@ -15,6 +41,12 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("Error code: %d, message: %s", e.ErrCode, e.ErrMsg)
 }
 
+// Is 支持 errors.Is(err, WeChatErrorCode)
+func (e *Error) Is(target error) bool {
+	code, ok := target.(WeChatErrorCode)
+	return ok && int(code) == e.ErrCode
+}
+
 // NewError 创建新的微信错误
 func NewError(errcode int, errmsg string) *Error {
 	return &Error{
@@ -25,24 +57,108 @@ func NewError(errcode int, errmsg string) *Error {
 
 // ClientError 微信 API 客户端错误
 type ClientError struct {
-	ErrCode  int
-	ErrMsg   string
-	Request  *http.Request
-	Response *http.Response
+	ErrCode   int
+	ErrMsg    string
+	Endpoint  string // 接口路径
+	RequestID string // 微信返回的请求 ID（errmsg 中的 rid 或 Request-ID 响应头）
+	RawBody   []byte // 原始响应体
+	Request   *http.Request
+	Response  *http.Response
 }
 
 func (e *ClientError) Error() string {
 	return fmt.Sprintf("Error code: %d, message: %s", e.ErrCode, e.ErrMsg)
 }
 
+// Is 支持 errors.Is(err, WeChatErrorCode)
+func (e *ClientError) Is(target error) bool {
+	code, ok := target.(WeChatErrorCode)
+	return ok && int(code) == e.ErrCode
+}
+
+// Code 返回微信返回码
+func (e *ClientError) Code() WeChatErrorCode {
+	return WeChatErrorCode(e.ErrCode)
+}
+
+// Retryable 该错误是否可以重试
+func (e *ClientError) Retryable() bool {
+	return e.Code().Retryable()
+}
+
 // NewClientError 创建新的客户端错误
+// 接口路径取自 req，请求 ID 取自 errmsg 或 resp 的响应头
 func NewClientError(errcode int, errmsg string, req *http.Request, resp *http.Response) *ClientError {
-	return &ClientError{
+	e := &ClientError{
 		ErrCode:  errcode,
 		ErrMsg:   errmsg,
 		Request:  req,
 		Response: resp,
 	}
+	if req == nil && resp != nil {
+		req = resp.Request
+	}
+	if req != nil && req.URL != nil {
+		e.Endpoint = req.URL.Path
+	}
+	if m := ridPattern.FindStringSubmatch(errmsg); m != nil {
+		e.RequestID = m[1]
+	} else if resp != nil {
+		e.RequestID = resp.Header.Get("Request-ID")
+	}
+	return e
+}
+
+// CheckResponse 检查已解析的 JSON 响应中的 errcode
+// errcode 不存在或为 0 时返回 nil，频率受限时返回 *APILimitedError，其余返回 *ClientError
+func CheckResponse(result map[string]interface{}, resp *http.Response, body []byte) error {
+	errcode, ok := result["errcode"]
+	if !ok {
+		return nil
+	}
+	var code int
+	switch v := errcode.(type) {
+	case float64:
+		code = int(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return fmt.Errorf("invalid errcode: %v", v)
+		}
+		code = int(n)
+	default:
+		return fmt.Errorf("invalid errcode type: %T", errcode)
+	}
+	if code == 0 {
+		return nil
+	}
+	errmsg, _ := result["errmsg"].(string)
+
+	var req *http.Request
+	if resp != nil {
+		req = resp.Request
+	}
+	if code == int(OutOfAPIFreqLimit) {
+		e := NewAPILimitedError(code, errmsg, req, resp)
+		e.RawBody = body
+		return e
+	}
+	e := NewClientError(code, errmsg, req, resp)
+	e.RawBody = body
+	return e
+}
+
+// IsRetryable 判断错误链中的微信错误是否可以重试
+func IsRetryable(err error) bool {
+	var clientErr *ClientError
+	if errors.As(err, &clientErr) {
+		return clientErr.Retryable()
+	}
+	var code WeChatErrorCode
+	if errors.As(err, &code) {
+		return code.Retryable()
+	}
+	return false
 }
 
 // InvalidSignatureError 无效签名错误
@@ -68,6 +184,11 @@ type APILimitedError struct {
 	ClientError
 }
 
+// Unwrap 支持 errors.As(err, **ClientError)
+func (e *APILimitedError) Unwrap() error {
+	return &e.ClientError
+}
+
 // NewAPILimitedError 创建 API 受限错误
 func NewAPILimitedError(errcode int, errmsg string, req *http.Request, resp *http.Response) *APILimitedError {
 	return &APILimitedError{
@@ -116,6 +237,11 @@ type OAuthError struct {
 	ClientError
 }
 
+// Unwrap 支持 errors.As(err, **ClientError)
+func (e *OAuthError) Unwrap() error {
+	return &e.ClientError
+}
+
 // NewOAuthError 创建 OAuth 错误
 func NewOAuthError(errcode int, errmsg string, req *http.Request, resp *http.Response) *OAuthError {
 	return &OAuthError{
@@ -128,6 +254,11 @@ type ComponentOAuthError struct {
 	ClientError
 }
 
+// Unwrap 支持 errors.As(err, **ClientError)
+func (e *ComponentOAuthError) Unwrap() error {
+	return &e.ClientError
+}
+
 // NewComponentOAuthError 创建第三方平台 OAuth 错误
 func NewComponentOAuthError(errcode int, errmsg string, req *http.Request, resp *http.Response) *ComponentOAuthError {
 	return &ComponentOAuthError{
@@ -141,13 +272,31 @@ type PayError struct {
 	ReturnCode string
 	ResultCode string
 	ReturnMsg  string
+	Code       string // 业务错误码（err_code），如 ORDERPAID
 }
 
 func (e *PayError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("Error code: %s, message: %s. Pay Error code: %s, message: %s",
+			e.ReturnCode, e.ReturnMsg, e.Code, e.ErrMsg)
+	}
 	return fmt.Sprintf("Error code: %s, message: %s. Pay Error code: %d, message: %s",
 		e.ReturnCode, e.ReturnMsg, e.ErrCode, e.ErrMsg)
 }
 
+// Is 支持与仅设置了 Code 的 *PayError 哨兵比较
+func (e *PayError) Is(target error) bool {
+	if t, ok := target.(*PayError); ok && t.Code != "" {
+		return t.Code == e.Code
+	}
+	return e.ClientError.Is(target)
+}
+
+// Unwrap 支持 errors.As(err, **ClientError)
+func (e *PayError) Unwrap() error {
+	return &e.ClientError
+}
+
 // NewPayError 创建支付错误
 func NewPayError(returnCode, resultCode, returnMsg string, errcode int, errmsg string,
 	req *http.Request, resp *http.Response) *PayError {
@@ -170,6 +319,11 @@ func (e *PayV3Error) Error() string {
 	return fmt.Sprintf("Error code: %s, message: %s", e.Code, e.Message)
 }
 
+// Unwrap 支持 errors.As(err, **ClientError)
+func (e *PayV3Error) Unwrap() error {
+	return &e.ClientError
+}
+
 // NewPayV3Error 创建支付 V3 错误
 func NewPayV3Error(code, message string, req *http.Request, resp *http.Response) *PayV3Error {
 	return &PayV3Error{
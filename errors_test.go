@@ -0,0 +1,99 @@
+package wechatgo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCheckResponse_Success(t *testing.T) {
+	if err := CheckResponse(map[string]interface{}{"errcode": float64(0), "errmsg": "ok"}, nil, nil); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if err := CheckResponse(map[string]interface{}{"access_token": "token"}, nil, nil); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+}
+
+func TestCheckResponse_ClientError(t *testing.T) {
+	body := []byte(`{"errcode":40001,"errmsg":"invalid credential rid: 5f5f0e0a-1c2b3d4e-5f6a7b8c"}`)
+	resp := &http.Response{
+		Header:  http.Header{},
+		Request: &http.Request{URL: &url.URL{Path: "/cgi-bin/user/info", RawQuery: "access_token=x"}},
+	}
+	err := CheckResponse(map[string]interface{}{
+		"errcode": float64(40001),
+		"errmsg":  "invalid credential rid: 5f5f0e0a-1c2b3d4e-5f6a7b8c",
+	}, resp, body)
+
+	if !errors.Is(err, ErrInvalidCredential) {
+		t.Fatalf("Expected errors.Is ErrInvalidCredential, got %v", err)
+	}
+	if errors.Is(err, InvalidOpenID) {
+		t.Fatalf("Expected errors.Is InvalidOpenID to be false")
+	}
+
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) {
+		t.Fatalf("Expected *ClientError, got %T", err)
+	}
+	if clientErr.Endpoint != "/cgi-bin/user/info" {
+		t.Fatalf("Expected endpoint '/cgi-bin/user/info', got '%s'", clientErr.Endpoint)
+	}
+	if clientErr.RequestID != "5f5f0e0a-1c2b3d4e-5f6a7b8c" {
+		t.Fatalf("Expected request id '5f5f0e0a-1c2b3d4e-5f6a7b8c', got '%s'", clientErr.RequestID)
+	}
+	if string(clientErr.RawBody) != string(body) {
+		t.Fatalf("Expected raw body to be kept, got '%s'", clientErr.RawBody)
+	}
+	if !clientErr.Retryable() {
+		t.Fatalf("Expected invalid credential to be retryable")
+	}
+}
+
+func TestCheckResponse_APILimited(t *testing.T) {
+	err := CheckResponse(map[string]interface{}{"errcode": float64(45009), "errmsg": "reach max api daily quota limit"}, nil, nil)
+
+	var limitedErr *APILimitedError
+	if !errors.As(err, &limitedErr) {
+		t.Fatalf("Expected *APILimitedError, got %T", err)
+	}
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) {
+		t.Fatalf("Expected *APILimitedError to unwrap to *ClientError")
+	}
+	if !errors.Is(err, ErrOutOfAPIFreqLimit) {
+		t.Fatalf("Expected errors.Is ErrOutOfAPIFreqLimit")
+	}
+	if !IsRetryable(err) {
+		t.Fatalf("Expected rate limit error to be retryable")
+	}
+}
+
+func TestPayError_Is(t *testing.T) {
+	err := NewPayError("SUCCESS", "FAIL", "OK", 0, "订单已支付", nil, nil)
+	err.Code = "ORDERPAID"
+
+	if !errors.Is(err, &PayError{Code: "ORDERPAID"}) {
+		t.Fatalf("Expected errors.Is to match pay error code")
+	}
+	if errors.Is(err, &PayError{Code: "ORDERCLOSED"}) {
+		t.Fatalf("Expected errors.Is not to match other pay error code")
+	}
+}
+
+func TestWeChatErrorCode_Catalog(t *testing.T) {
+	if len(ErrCodes()) != 183 {
+		t.Fatalf("Expected 183 error codes, got %d", len(ErrCodes()))
+	}
+	if InvalidMediaType.Description() != "不支持的媒体文件类型" {
+		t.Fatalf("Unexpected description: %s", InvalidMediaType.Description())
+	}
+	if WeChatErrorCode(123456).Description() != "未知错误" {
+		t.Fatalf("Expected unknown description for unknown code")
+	}
+	if InvalidOpenID.Retryable() || !SystemBusy.Retryable() {
+		t.Fatalf("Unexpected retryable classification")
+	}
+}
@@ -12,6 +12,27 @@ const (
 	EventView                  EventType = "VIEW"
 	EventMassSendJobFinish     EventType = "MASSSENDJOBFINISH"
 	EventTemplateSendJobFinish EventType = "TEMPLATESENDJOBFINISH"
+	EventPublishJobFinish      EventType = "PUBLISHJOBFINISH"
+
+	// 卡券事件
+	EventCardPassCheck            EventType = "card_pass_check"
+	EventCardNotPassCheck         EventType = "card_not_pass_check"
+	EventUserGetCard              EventType = "user_get_card"
+	EventUserGiftingCard          EventType = "user_gifting_card"
+	EventUserDelCard              EventType = "user_del_card"
+	EventUserConsumeCard          EventType = "user_consume_card"
+	EventUserPayFromPayCell       EventType = "user_pay_from_pay_cell"
+	EventUserViewCard             EventType = "user_view_card"
+	EventUserEnterSessionFromCard EventType = "user_enter_session_from_card"
+	EventUpdateMemberCard         EventType = "update_member_card"
+	EventCardSkuRemind            EventType = "card_sku_remind"
+	EventSubmitMemberCardUserInfo EventType = "submit_membercard_user_info"
+
+	// 电子发票事件
+	EventUserAuthorizeInvoice EventType = "user_authorize_invoice"
+
+	// 微信小店事件
+	EventMerchantOrder EventType = "merchant_order"
 )
 
 // BaseEvent 基础事件
@@ -59,15 +80,44 @@ type ViewEvent struct {
 	EventKey string `xml:"EventKey"`
 }
 
+// CopyrightCheckItem 单篇图文的原创校验结果
+type CopyrightCheckItem struct {
+	ArticleIdx            int    `xml:"ArticleIdx"`
+	UserDeclareState      int    `xml:"UserDeclareState"`
+	AuditState            int    `xml:"AuditState"`
+	OriginalArticleURL    string `xml:"OriginalArticleUrl"`
+	OriginalArticleType   int    `xml:"OriginalArticleType"`
+	CanReprint            int    `xml:"CanReprint"`
+	NeedReplaceContent    int    `xml:"NeedReplaceContent"`
+	NeedShowReprintSource int    `xml:"NeedShowReprintSource"`
+}
+
+// CopyrightCheckResult 群发图文的原创校验结果
+// CheckState 为 1 表示未被判为转载可以群发，2 表示被判为转载可以群发，3 表示被判为转载不能群发
+type CopyrightCheckResult struct {
+	Count      int                  `xml:"Count"`
+	ResultList []CopyrightCheckItem `xml:"ResultList>item"`
+	CheckState int                  `xml:"CheckState"`
+}
+
+// ArticleURLItem 群发成功的图文链接
+type ArticleURLItem struct {
+	ArticleIdx int    `xml:"ArticleIdx"`
+	ArticleURL string `xml:"ArticleUrl"`
+}
+
 // MassSendJobFinishEvent 群发消息任务完成事件
+// MsgID 与群发接口返回的 msg_id 一致，可用于关联群发任务
 type MassSendJobFinishEvent struct {
 	BaseEvent
-	MsgID       int64  `xml:"MsgID"`
-	Status      string `xml:"Status"`
-	TotalCount  int    `xml:"TotalCount"`
-	FilterCount int    `xml:"FilterCount"`
-	SentCount   int    `xml:"SentCount"`
-	ErrorCount  int    `xml:"ErrorCount"`
+	MsgID                int64                `xml:"MsgID"`
+	Status               string               `xml:"Status"`
+	TotalCount           int                  `xml:"TotalCount"`
+	FilterCount          int                  `xml:"FilterCount"`
+	SentCount            int                  `xml:"SentCount"`
+	ErrorCount           int                  `xml:"ErrorCount"`
+	CopyrightCheckResult CopyrightCheckResult `xml:"CopyrightCheckResult"`
+	ArticleURLResult     []ArticleURLItem     `xml:"ArticleUrlResult>ResultList>item"`
 }
 
 // TemplateSendJobFinishEvent 模板消息任务完成事件
@@ -76,3 +126,153 @@ type TemplateSendJobFinishEvent struct {
 	MsgID  int64  `xml:"MsgID"`
 	Status string `xml:"Status"`
 }
+
+// PublishArticleItem 发布成功的文章
+// 同时用于 PUBLISHJOBFINISH 事件和发布状态查询接口
+type PublishArticleItem struct {
+	Idx        int    `xml:"idx" json:"idx"`
+	ArticleURL string `xml:"article_url" json:"article_url"`
+}
+
+// PublishArticleDetail 发布成功的文章详情
+type PublishArticleDetail struct {
+	Count int                  `xml:"count" json:"count"`
+	Item  []PublishArticleItem `xml:"item" json:"item"`
+}
+
+// PublishEventInfo 发布结果
+// PublishStatus 取值见 api.PublishStatus，0 表示成功
+type PublishEventInfo struct {
+	PublishID     string               `xml:"publish_id"`
+	PublishStatus int                  `xml:"publish_status"`
+	ArticleID     string               `xml:"article_id"`
+	ArticleDetail PublishArticleDetail `xml:"article_detail"`
+	FailIdx       []int                `xml:"fail_idx"`
+}
+
+// PublishJobFinishEvent 发布任务完成事件
+type PublishJobFinishEvent struct {
+	BaseEvent
+	PublishEventInfo PublishEventInfo `xml:"PublishEventInfo"`
+}
+
+// CardCheckEvent 卡券审核事件，审核通过为 card_pass_check，未通过为 card_not_pass_check
+type CardCheckEvent struct {
+	BaseEvent
+	CardID       string `xml:"CardId"`
+	RefuseReason string `xml:"RefuseReason"`
+}
+
+// UserGetCardEvent 用户领取卡券事件
+type UserGetCardEvent struct {
+	BaseEvent
+	CardID              string `xml:"CardId"`
+	IsGiveByFriend      int    `xml:"IsGiveByFriend"`
+	UserCardCode        string `xml:"UserCardCode"`
+	FriendUserName      string `xml:"FriendUserName"`
+	OuterID             int    `xml:"OuterId"`
+	OldUserCardCode     string `xml:"OldUserCardCode"`
+	OuterStr            string `xml:"OuterStr"`
+	IsRestoreMemberCard int    `xml:"IsRestoreMemberCard"`
+	UnionID             string `xml:"UnionId"`
+}
+
+// UserGiftingCardEvent 用户转赠卡券事件
+type UserGiftingCardEvent struct {
+	BaseEvent
+	CardID         string `xml:"CardId"`
+	UserCardCode   string `xml:"UserCardCode"`
+	IsReturnBack   int    `xml:"IsReturnBack"`
+	FriendUserName string `xml:"FriendUserName"`
+	IsChatRoom     int    `xml:"IsChatRoom"`
+}
+
+// UserDelCardEvent 用户删除卡券事件
+type UserDelCardEvent struct {
+	BaseEvent
+	CardID       string `xml:"CardId"`
+	UserCardCode string `xml:"UserCardCode"`
+}
+
+// UserConsumeCardEvent 卡券核销事件
+// ConsumeSource 为核销来源，如 FROM_API、FROM_MOBILE_HELPER、FROM_SELF_CONSUME
+type UserConsumeCardEvent struct {
+	BaseEvent
+	CardID        string `xml:"CardId"`
+	UserCardCode  string `xml:"UserCardCode"`
+	ConsumeSource string `xml:"ConsumeSource"`
+	LocationName  string `xml:"LocationName"`
+	StaffOpenID   string `xml:"StaffOpenId"`
+	VerifyCode    string `xml:"VerifyCode"`
+	RemarkAmount  string `xml:"RemarkAmount"`
+	OuterStr      string `xml:"OuterStr"`
+}
+
+// UserPayFromPayCellEvent 卡券买单事件
+type UserPayFromPayCellEvent struct {
+	BaseEvent
+	CardID       string `xml:"CardId"`
+	UserCardCode string `xml:"UserCardCode"`
+	TransID      string `xml:"TransId"`
+	LocationID   int64  `xml:"LocationId"`
+	Fee          int    `xml:"Fee"`
+	OriginalFee  int    `xml:"OriginalFee"`
+}
+
+// UserViewCardEvent 用户进入会员卡事件
+type UserViewCardEvent struct {
+	BaseEvent
+	CardID       string `xml:"CardId"`
+	UserCardCode string `xml:"UserCardCode"`
+	OuterStr     string `xml:"OuterStr"`
+}
+
+// UserEnterSessionFromCardEvent 用户从卡券进入公众号会话事件
+type UserEnterSessionFromCardEvent struct {
+	BaseEvent
+	CardID       string `xml:"CardId"`
+	UserCardCode string `xml:"UserCardCode"`
+}
+
+// UpdateMemberCardEvent 会员卡积分、余额变更事件
+type UpdateMemberCardEvent struct {
+	BaseEvent
+	CardID        string `xml:"CardId"`
+	UserCardCode  string `xml:"UserCardCode"`
+	ModifyBonus   int    `xml:"ModifyBonus"`
+	ModifyBalance int    `xml:"ModifyBalance"`
+}
+
+// CardSkuRemindEvent 卡券库存报警事件
+type CardSkuRemindEvent struct {
+	BaseEvent
+	CardID string `xml:"CardId"`
+	Detail string `xml:"Detail"`
+}
+
+// SubmitMemberCardUserInfoEvent 用户提交会员卡开卡资料事件
+// 收到后可调用 CardAPI.GetMemberCardUserInfo 拉取用户填写的资料
+type SubmitMemberCardUserInfoEvent struct {
+	BaseEvent
+	CardID       string `xml:"CardId"`
+	UserCardCode string `xml:"UserCardCode"`
+}
+
+// UserAuthorizeInvoiceEvent 用户完成开票授权事件
+// SuccOrderID 为授权成功的订单号，FailOrderID 为授权失败的订单号，Source 为授权来源（app、web、wxa、wap）
+type UserAuthorizeInvoiceEvent struct {
+	BaseEvent
+	SuccOrderID string `xml:"SuccOrderId"`
+	FailOrderID string `xml:"FailOrderId"`
+	AuthAppID   string `xml:"AppId"`
+	Source      string `xml:"Source"`
+}
+
+// MerchantOrderEvent 微信小店订单付款通知事件
+type MerchantOrderEvent struct {
+	BaseEvent
+	OrderID     string `xml:"OrderId"`
+	OrderStatus int    `xml:"OrderStatus"`
+	ProductID   string `xml:"ProductId"`
+	SKUInfo     string `xml:"SkuInfo"`
+}
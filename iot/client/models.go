@@ -1,6 +1,11 @@
 package client
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/wechatpy/wechatgo"
+)
 
 // ErrorResponse 错误响应
 type ErrorResponse struct {
@@ -169,20 +174,34 @@ type UnbindDeviceResponse struct {
 // ==================== 错误定义 ====================
 
 var (
-	ErrAccessTokenNotFound    = NewError(40001, "access_token not found")
-	ErrAccessTokenInvalidType = NewError(40002, "access_token is not a string")
+	ErrAccessTokenNotFound    = &Error{Code: 40001, Message: "access_token not found", err: wechatgo.ErrAccessTokenNotFound}
+	ErrAccessTokenInvalidType = &Error{Code: 40002, Message: "access_token is not a string", err: wechatgo.ErrAccessTokenInvalidType}
 )
 
 // Error 错误
+// 可以通过 errors.As 转换为 *wechatgo.ClientError，或通过 errors.Is 与 wechatgo 中的错误比较
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	RawBody []byte `json:"-"` // 原始响应体
+
+	err error // 对应的 wechatgo 错误
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// Unwrap 返回对应的 wechatgo 错误，业务错误返回 *wechatgo.ClientError
+func (e *Error) Unwrap() error {
+	if e.err != nil {
+		return e.err
+	}
+	clientErr := wechatgo.NewClientError(e.Code, e.Message, nil, nil)
+	clientErr.RawBody = e.RawBody
+	return clientErr
+}
+
 // NewError 创建错误
 func NewError(code int, message string) *Error {
 	return &Error{
@@ -192,7 +211,22 @@ func NewError(code int, message string) *Error {
 }
 
 // parseResponse 解析响应
+// IoT 接口以 code/message 返回业务错误，非 0 时转换为 *Error
 func parseResponse(result map[string]interface{}, target interface{}) error {
-	// TODO: 实现响应解析逻辑
-	return nil
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var base BaseResponse
+	if err := json.Unmarshal(data, &base); err != nil {
+		return err
+	}
+	if base.Code != 0 {
+		e := NewError(base.Code, base.Message)
+		e.RawBody = data
+		return e
+	}
+
+	return json.Unmarshal(data, target)
 }
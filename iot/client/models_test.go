@@ -0,0 +1,26 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+)
+
+func TestParseResponse_Error(t *testing.T) {
+	err := parseResponse(map[string]interface{}{"code": float64(40001), "message": "invalid credential"}, &BaseResponse{})
+
+	var iotErr *Error
+	assert.True(t, errors.As(err, &iotErr))
+	assert.Equal(t, 40001, iotErr.Code)
+	assert.Equal(t, "invalid credential", iotErr.Message)
+	assert.NotEmpty(t, iotErr.RawBody)
+
+	var clientErr *wechatgo.ClientError
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, 40001, clientErr.ErrCode)
+
+	assert.True(t, errors.Is(ErrAccessTokenNotFound, wechatgo.ErrAccessTokenNotFound))
+	assert.Equal(t, 40001, ErrAccessTokenNotFound.Code)
+}
@@ -28,9 +28,11 @@ type BaseMessage struct {
 }
 
 // TextMessage 文本消息
+// 用户点击客服菜单消息的选项时，BizMsgMenuID 为被点击的选项 ID
 type TextMessage struct {
 	BaseMessage
-	Content string `xml:"Content"`
+	Content      string `xml:"Content"`
+	BizMsgMenuID string `xml:"bizmsgmenuid,omitempty"`
 }
 
 // ImageMessage 图片消息
@@ -0,0 +1,321 @@
+// Package oauth 实现公众号网页授权和网站应用微信登录
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+const (
+	// APIBaseURL 网页授权接口基础 URL
+	APIBaseURL = "https://api.weixin.qq.com/"
+	// AuthorizeURL 公众号网页授权地址
+	AuthorizeURL = "https://open.weixin.qq.com/connect/oauth2/authorize"
+	// QRConnectURL 网站应用扫码登录地址
+	QRConnectURL = "https://open.weixin.qq.com/connect/qrconnect"
+
+	// refreshTokenTTL refresh_token 有效期为 30 天
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// 授权作用域
+const (
+	ScopeBase     = "snsapi_base"     // 静默授权，仅获取 openid
+	ScopeUserInfo = "snsapi_userinfo" // 弹出授权页面，可获取用户信息
+	ScopeLogin    = "snsapi_login"    // 网站应用扫码登录
+)
+
+// AccessToken 网页授权 access token
+type AccessToken struct {
+	AccessToken    string `json:"access_token"`
+	ExpiresIn      int    `json:"expires_in"`
+	RefreshToken   string `json:"refresh_token"`
+	OpenID         string `json:"openid"`
+	Scope          string `json:"scope"`
+	UnionID        string `json:"unionid,omitempty"`
+	IsSnapshotUser int    `json:"is_snapshotuser,omitempty"`
+	// ExpiresAt access token 过期时间（Unix 秒），由本包在获取时计算
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+// Expired access token 是否已过期或即将过期
+func (t *AccessToken) Expired() bool {
+	return time.Now().Unix() >= t.ExpiresAt-60
+}
+
+// UserInfo 网页授权获取的用户信息
+type UserInfo struct {
+	OpenID     string   `json:"openid"`
+	Nickname   string   `json:"nickname"`
+	Sex        int      `json:"sex"`
+	Province   string   `json:"province"`
+	City       string   `json:"city"`
+	Country    string   `json:"country"`
+	HeadImgURL string   `json:"headimgurl"`
+	Privilege  []string `json:"privilege"`
+	UnionID    string   `json:"unionid,omitempty"`
+}
+
+// OAuth 微信网页授权
+// 用户的 access token 按 openid 缓存在会话存储中，过期后使用 refresh_token 自动刷新
+type OAuth struct {
+	AppID       string
+	Secret      string
+	RedirectURI string
+
+	base         *client.BaseClient
+	refreshLocks keyedMutex
+}
+
+// keyedMutex 按 key 加锁，不再使用的锁会被释放
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*refMutex
+}
+
+type refMutex struct {
+	sync.Mutex
+	refs int
+}
+
+// lock 对 key 加锁，返回解锁函数
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*refMutex)
+	}
+	m, ok := k.locks[key]
+	if !ok {
+		m = &refMutex{}
+		k.locks[key] = m
+	}
+	m.refs++
+	k.mu.Unlock()
+
+	m.Lock()
+	return func() {
+		m.Unlock()
+		k.mu.Lock()
+		m.refs--
+		if m.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// NewOAuth 创建网页授权客户端，opts 与公众号客户端的选项相同
+func NewOAuth(appID, secret, redirectURI string, storage session.Storage, opts ...client.ClientOption) *OAuth {
+	return &OAuth{
+		AppID:       appID,
+		Secret:      secret,
+		RedirectURI: redirectURI,
+		base:        client.NewBaseClient(appID, storage, APIBaseURL, opts...),
+	}
+}
+
+// AuthorizeURL 生成公众号网页授权地址
+// https://developers.weixin.qq.com/doc/offiaccount/OA_Web_Apps/Wechat_webpage_authorization.html
+func (o *OAuth) AuthorizeURL(scope, state string) string {
+	return o.buildURL(AuthorizeURL, scope, state)
+}
+
+// QRConnectURL 生成网站应用扫码登录地址
+// https://developers.weixin.qq.com/doc/oplatform/Website_App/WeChat_Login/Wechat_Login.html
+func (o *OAuth) QRConnectURL(state string) string {
+	return o.buildURL(QRConnectURL, ScopeLogin, state)
+}
+
+// buildURL 按微信要求的参数顺序拼接授权地址
+func (o *OAuth) buildURL(baseURL, scope, state string) string {
+	return fmt.Sprintf("%s?appid=%s&redirect_uri=%s&response_type=code&scope=%s&state=%s#wechat_redirect",
+		baseURL, o.AppID, url.QueryEscape(o.RedirectURI), scope, url.QueryEscape(state))
+}
+
+// FetchAccessToken 通过 code 换取网页授权 access token 并缓存
+func (o *OAuth) FetchAccessToken(code string) (*AccessToken, error) {
+	var token AccessToken
+	err := o.get("/sns/oauth2/access_token", map[string]string{
+		"appid":      o.AppID,
+		"secret":     o.Secret,
+		"code":       code,
+		"grant_type": "authorization_code",
+	}, &token)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.SetAccessToken(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RefreshAccessToken 使用 refresh_token 刷新网页授权 access token 并缓存
+func (o *OAuth) RefreshAccessToken(refreshToken string) (*AccessToken, error) {
+	var token AccessToken
+	err := o.get("/sns/oauth2/refresh_token", map[string]string{
+		"appid":         o.AppID,
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	}, &token)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.SetAccessToken(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// CheckAccessToken 检验网页授权 access token 是否有效
+func (o *OAuth) CheckAccessToken(accessToken, openID string) error {
+	return o.get("/sns/auth", map[string]string{
+		"access_token": accessToken,
+		"openid":       openID,
+	}, nil)
+}
+
+// GetUserInfo 拉取用户信息，需要 snsapi_userinfo 作用域
+// lang 可选 zh_CN、zh_TW、en，为空时使用 zh_CN
+func (o *OAuth) GetUserInfo(accessToken, openID, lang string) (*UserInfo, error) {
+	if lang == "" {
+		lang = "zh_CN"
+	}
+
+	var info UserInfo
+	err := o.get("/sns/userinfo", map[string]string{
+		"access_token": accessToken,
+		"openid":       openID,
+		"lang":         lang,
+	}, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetAccessToken 获取缓存的用户 access token，过期时使用 refresh_token 自动刷新
+// 未缓存时返回 wechatgo.ErrOAuthTokenNotCached，存储错误原样返回
+// 同一 openid 的并发刷新会被串行化，只有一个请求调用刷新接口
+func (o *OAuth) GetAccessToken(openID string) (*AccessToken, error) {
+	token, err := o.cachedAccessToken(openID)
+	if err != nil || !token.Expired() {
+		return token, err
+	}
+
+	unlock := o.refreshLocks.lock(openID)
+	defer unlock()
+
+	// 等待期间其他请求可能已经完成刷新
+	token, err = o.cachedAccessToken(openID)
+	if err != nil || !token.Expired() {
+		return token, err
+	}
+	return o.RefreshAccessToken(token.RefreshToken)
+}
+
+// cachedAccessToken 读取缓存的用户 access token
+func (o *OAuth) cachedAccessToken(openID string) (*AccessToken, error) {
+	data, err := o.base.Session().Get(o.tokenKey(openID))
+	if err != nil {
+		return nil, err
+	}
+	if data == "" {
+		return nil, wechatgo.ErrOAuthTokenNotCached
+	}
+
+	var token AccessToken
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// UserInfo 使用缓存的 access token 拉取用户信息
+func (o *OAuth) UserInfo(openID, lang string) (*UserInfo, error) {
+	token, err := o.GetAccessToken(openID)
+	if err != nil {
+		return nil, err
+	}
+	return o.GetUserInfo(token.AccessToken, openID, lang)
+}
+
+// SetAccessToken 缓存用户 access token，缓存时间与 refresh_token 有效期一致
+func (o *OAuth) SetAccessToken(token *AccessToken) error {
+	if token.OpenID == "" {
+		return fmt.Errorf("openid is empty")
+	}
+	if token.ExpiresAt == 0 {
+		token.ExpiresAt = time.Now().Unix() + int64(token.ExpiresIn)
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return o.base.Session().Set(o.tokenKey(token.OpenID), string(data), refreshTokenTTL)
+}
+
+// DeleteAccessToken 删除缓存的用户 access token
+func (o *OAuth) DeleteAccessToken(openID string) error {
+	return o.base.Session().Delete(o.tokenKey(openID))
+}
+
+// tokenKey 获取用户 access token 存储键，前缀与客户端的 WithKeyPrefix 一致
+func (o *OAuth) tokenKey(openID string) string {
+	return fmt.Sprintf("%s%s_oauth_access_token_%s", o.base.KeyPrefix(), o.AppID, openID)
+}
+
+// get 发送 GET 请求，errcode 非 0 时返回 *wechatgo.OAuthError，v 为 nil 时不解析结果
+func (o *OAuth) get(endpoint string, params map[string]string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, o.base.BuildURL(endpoint), nil)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	for k, val := range params {
+		q.Set(k, val)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := o.base.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed: %s", endpoint, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+	if err := wechatgo.CheckResponse(result, resp, body); err != nil {
+		var clientErr *wechatgo.ClientError
+		if errors.As(err, &clientErr) {
+			return &wechatgo.OAuthError{ClientError: *clientErr}
+		}
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
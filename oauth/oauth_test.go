@@ -0,0 +1,176 @@
+package oauth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+func TestAuthorizeURL(t *testing.T) {
+	o := NewOAuth("wx123", "secret", "https://example.com/callback?a=1", nil)
+
+	assert.Equal(t,
+		"https://open.weixin.qq.com/connect/oauth2/authorize?appid=wx123&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback%3Fa%3D1&response_type=code&scope=snsapi_userinfo&state=STATE#wechat_redirect",
+		o.AuthorizeURL(ScopeUserInfo, "STATE"))
+	assert.Equal(t,
+		"https://open.weixin.qq.com/connect/qrconnect?appid=wx123&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback%3Fa%3D1&response_type=code&scope=snsapi_login&state=#wechat_redirect",
+		o.QRConnectURL(""))
+}
+
+func TestFetchAccessToken(t *testing.T) {
+	var refreshCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/sns/oauth2/access_token":
+			assert.Equal(t, "CODE", q.Get("code"))
+			assert.Equal(t, "authorization_code", q.Get("grant_type"))
+			w.Write([]byte(`{"access_token":"ACCESS_TOKEN","expires_in":7200,"refresh_token":"REFRESH_TOKEN","openid":"OPENID","scope":"snsapi_userinfo"}`))
+		case "/sns/oauth2/refresh_token":
+			atomic.AddInt32(&refreshCalls, 1)
+			assert.Equal(t, "REFRESH_TOKEN", q.Get("refresh_token"))
+			w.Write([]byte(`{"access_token":"NEW_TOKEN","expires_in":7200,"refresh_token":"REFRESH_TOKEN","openid":"OPENID","scope":"snsapi_userinfo"}`))
+		case "/sns/userinfo":
+			assert.Equal(t, "NEW_TOKEN", q.Get("access_token"))
+			w.Write([]byte(`{"openid":"OPENID","nickname":"NICKNAME","sex":1,"privilege":["PRIVILEGE1"],"unionid":"UNIONID"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	storage := session.NewMemoryStorage()
+	o := NewOAuth("wx123", "secret", "https://example.com/callback", storage, client.WithBaseURL(server.URL))
+
+	token, err := o.FetchAccessToken("CODE")
+	assert.NoError(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", token.AccessToken)
+	assert.Equal(t, "OPENID", token.OpenID)
+
+	cached, err := o.GetAccessToken("OPENID")
+	assert.NoError(t, err)
+	assert.Equal(t, "ACCESS_TOKEN", cached.AccessToken)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&refreshCalls))
+
+	// 过期后使用 refresh_token 自动刷新
+	token.ExpiresAt = time.Now().Unix()
+	assert.NoError(t, o.SetAccessToken(token))
+
+	info, err := o.UserInfo("OPENID", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "NICKNAME", info.Nickname)
+	assert.Equal(t, "UNIONID", info.UnionID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshCalls))
+}
+
+func TestOAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errcode":40029,"errmsg":"invalid code"}`))
+	}))
+	defer server.Close()
+
+	o := NewOAuth("wx123", "secret", "https://example.com/callback", nil, client.WithBaseURL(server.URL))
+
+	_, err := o.FetchAccessToken("BAD_CODE")
+	var oauthErr *wechatgo.OAuthError
+	assert.True(t, errors.As(err, &oauthErr))
+	assert.True(t, errors.Is(err, wechatgo.InvalidOAuthCode))
+	assert.Equal(t, "/sns/oauth2/access_token", oauthErr.Endpoint)
+
+	_, err = o.GetAccessToken("UNKNOWN")
+	assert.ErrorIs(t, err, wechatgo.ErrOAuthTokenNotCached)
+}
+
+func TestOAuthHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>502 Bad Gateway</html>"))
+	}))
+	defer server.Close()
+
+	o := NewOAuth("wx123", "secret", "https://example.com/callback", nil, client.WithBaseURL(server.URL))
+
+	_, err := o.FetchAccessToken("CODE")
+	assert.ErrorContains(t, err, "502")
+}
+
+func TestAccessToken_KeyPrefix(t *testing.T) {
+	storage := session.NewMemoryStorage()
+	a := NewOAuth("wx123", "secret", "", storage, client.WithKeyPrefix("a_"))
+	b := NewOAuth("wx123", "secret", "", storage, client.WithKeyPrefix("b_"))
+
+	// 共用存储的两个实例使用不同前缀时，用户 access token 互不覆盖
+	assert.NoError(t, a.SetAccessToken(&AccessToken{AccessToken: "A", OpenID: "OPENID", ExpiresAt: time.Now().Unix() + 7200}))
+	assert.NoError(t, b.SetAccessToken(&AccessToken{AccessToken: "B", OpenID: "OPENID", ExpiresAt: time.Now().Unix() + 7200}))
+
+	token, err := a.GetAccessToken("OPENID")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", token.AccessToken)
+	token, err = b.GetAccessToken("OPENID")
+	assert.NoError(t, err)
+	assert.Equal(t, "B", token.AccessToken)
+
+	stored, err := storage.Get("a_wx123_oauth_access_token_OPENID")
+	assert.NoError(t, err)
+	assert.Contains(t, stored, `"access_token":"A"`)
+}
+
+func TestGetAccessToken_ConcurrentRefresh(t *testing.T) {
+	var refreshCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refreshCalls, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"access_token":"NEW_TOKEN","expires_in":7200,"refresh_token":"REFRESH_TOKEN","openid":"OPENID","scope":"snsapi_userinfo"}`))
+	}))
+	defer server.Close()
+
+	o := NewOAuth("wx123", "secret", "https://example.com/callback", session.NewMemoryStorage(), client.WithBaseURL(server.URL))
+	assert.NoError(t, o.SetAccessToken(&AccessToken{
+		AccessToken:  "OLD_TOKEN",
+		RefreshToken: "REFRESH_TOKEN",
+		OpenID:       "OPENID",
+		ExpiresAt:    time.Now().Unix() - 1,
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := o.GetAccessToken("OPENID")
+			assert.NoError(t, err)
+			assert.Equal(t, "NEW_TOKEN", token.AccessToken)
+		}()
+	}
+	wg.Wait()
+
+	// 同一 openid 的并发刷新只请求一次
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshCalls))
+}
+
+type failingStorage struct {
+	session.Storage
+	err error
+}
+
+func (s failingStorage) Get(key string) (string, error) {
+	return "", s.err
+}
+
+func TestGetAccessToken_StorageError(t *testing.T) {
+	storageErr := errors.New("redis: connection refused")
+	o := NewOAuth("wx123", "secret", "https://example.com/callback", failingStorage{Storage: session.NewMemoryStorage(), err: storageErr})
+
+	_, err := o.GetAccessToken("OPENID")
+	assert.ErrorIs(t, err, storageErr)
+	assert.False(t, errors.Is(err, wechatgo.ErrOAuthTokenNotCached))
+}
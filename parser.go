@@ -142,7 +142,8 @@ type RawMessage struct {
 	// 语音识别
 	Recognition string `xml:"Recognition,omitempty"`
 	// 文本消息
-	Content string `xml:"Content,omitempty"`
+	Content      string `xml:"Content,omitempty"`
+	BizMsgMenuID string `xml:"bizmsgmenuid,omitempty"`
 	// 语音消息
 	Format string `xml:"Format,omitempty"`
 	// 小程序
@@ -156,6 +157,8 @@ type RawMessage struct {
 	SentCount   int    `xml:"SentCount,omitempty"`
 	ErrorCount  int    `xml:"ErrorCount,omitempty"`
 	// 模板消息
+	// 群发和模板消息事件中的消息 ID 为 MsgID，与普通消息的 MsgId 大小写不同
+	EventMsgID  int64  `xml:"MsgID,omitempty"`
 	TemplateID  string `xml:"TemplateID,omitempty"`
 	ClientMsgID string `xml:"ClientMsgId,omitempty"`
 }
@@ -200,6 +203,10 @@ func (e *ParseError) Error() string {
 //   - 跳转事件 (ViewEvent)
 //   - 群发任务完成事件 (MassSendJobFinishEvent)
 //   - 模板消息发送完成事件 (TemplateSendJobFinishEvent)
+//   - 发布任务完成事件 (PublishJobFinishEvent)
+//   - 卡券事件 (CardCheckEvent/UserGetCardEvent/UserConsumeCardEvent 等)
+//   - 发票授权事件 (UserAuthorizeInvoiceEvent)
+//   - 小店订单事件 (MerchantOrderEvent)
 func ParseMessage(data []byte) (interface{}, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("empty message data")
@@ -322,23 +329,7 @@ func parseEvent(data []byte, raw *RawMessage) (interface{}, error) {
 		return event, nil
 
 	case EventMassSendJobFinish:
-		event := &MassSendJobFinishEvent{
-			BaseEvent: BaseEvent{
-				BaseMessage: BaseMessage{
-					ToUserName:   raw.ToUserName,
-					FromUserName: raw.FromUserName,
-					CreateTime:   raw.CreateTime,
-					MsgType:      "event",
-				},
-				Event: raw.Event,
-			},
-			Status:      raw.Status,
-			TotalCount:  raw.TotalCount,
-			FilterCount: raw.FilterCount,
-			SentCount:   raw.SentCount,
-			ErrorCount:  raw.ErrorCount,
-		}
-		return event, nil
+		return unmarshalEvent(data, &MassSendJobFinishEvent{})
 
 	case EventTemplateSendJobFinish:
 		event := &TemplateSendJobFinishEvent{
@@ -351,11 +342,41 @@ func parseEvent(data []byte, raw *RawMessage) (interface{}, error) {
 				},
 				Event: raw.Event,
 			},
-			MsgID:  raw.MsgID,
+			MsgID:  raw.EventMsgID,
 			Status: raw.Status,
 		}
 		return event, nil
 
+	case EventPublishJobFinish:
+		return unmarshalEvent(data, &PublishJobFinishEvent{})
+
+	case EventCardPassCheck, EventCardNotPassCheck:
+		return unmarshalEvent(data, &CardCheckEvent{})
+	case EventUserGetCard:
+		return unmarshalEvent(data, &UserGetCardEvent{})
+	case EventUserGiftingCard:
+		return unmarshalEvent(data, &UserGiftingCardEvent{})
+	case EventUserDelCard:
+		return unmarshalEvent(data, &UserDelCardEvent{})
+	case EventUserConsumeCard:
+		return unmarshalEvent(data, &UserConsumeCardEvent{})
+	case EventUserPayFromPayCell:
+		return unmarshalEvent(data, &UserPayFromPayCellEvent{})
+	case EventUserViewCard:
+		return unmarshalEvent(data, &UserViewCardEvent{})
+	case EventUserEnterSessionFromCard:
+		return unmarshalEvent(data, &UserEnterSessionFromCardEvent{})
+	case EventUpdateMemberCard:
+		return unmarshalEvent(data, &UpdateMemberCardEvent{})
+	case EventCardSkuRemind:
+		return unmarshalEvent(data, &CardSkuRemindEvent{})
+	case EventSubmitMemberCardUserInfo:
+		return unmarshalEvent(data, &SubmitMemberCardUserInfoEvent{})
+	case EventUserAuthorizeInvoice:
+		return unmarshalEvent(data, &UserAuthorizeInvoiceEvent{})
+	case EventMerchantOrder:
+		return unmarshalEvent(data, &MerchantOrderEvent{})
+
 	default:
 		// 未知事件类型，返回基础事件结构
 		event := &BaseEvent{
@@ -371,6 +392,14 @@ func parseEvent(data []byte, raw *RawMessage) (interface{}, error) {
 	}
 }
 
+// unmarshalEvent 将 XML 直接解析到事件结构体，用于字段较多的事件
+func unmarshalEvent(data []byte, event interface{}) (interface{}, error) {
+	if err := xml.Unmarshal(data, event); err != nil {
+		return nil, &ParseError{RawData: data, Err: err}
+	}
+	return event, nil
+}
+
 // parseNormalMessage 解析普通消息
 func parseNormalMessage(data []byte, msgType MessageType) (interface{}, error) {
 	// 重新解析原始数据以获取完整信息
@@ -389,7 +418,8 @@ func parseNormalMessage(data []byte, msgType MessageType) (interface{}, error) {
 				MsgType:      "text",
 				MsgID:        raw.MsgID,
 			},
-			Content: raw.Content,
+			Content:      raw.Content,
+			BizMsgMenuID: raw.BizMsgMenuID,
 		}
 		return msg, nil
 
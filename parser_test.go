@@ -1,6 +1,7 @@
 package wechatgo
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -155,3 +156,367 @@ func TestParseMessage_ClickEvent(t *testing.T) {
 		t.Fatalf("Expected EventKey 'MENU_KEY', got '%s'", event.EventKey)
 	}
 }
+
+func TestParseMessage_PublishJobFinishEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[gh_4d00ed8d6399]]></ToUserName>
+			<FromUserName><![CDATA[oV5CrjpxgaGXNHIQigzNlgLTnwic]]></FromUserName>
+			<CreateTime>1481013459</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[PUBLISHJOBFINISH]]></Event>
+			<PublishEventInfo>
+				<publish_id>2247503051</publish_id>
+				<publish_status>0</publish_status>
+				<article_id><![CDATA[b5O2OUs25HBxRceL7hfReg-U9QGeq9zQjiDvy]]></article_id>
+				<article_detail>
+					<count>1</count>
+					<item>
+						<idx>1</idx>
+						<article_url><![CDATA[ARTICLE_URL]]></article_url>
+					</item>
+				</article_detail>
+			</PublishEventInfo>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*PublishJobFinishEvent)
+	if !ok {
+		t.Fatalf("Expected PublishJobFinishEvent, got %T", result)
+	}
+
+	info := event.PublishEventInfo
+	if info.PublishID != "2247503051" {
+		t.Fatalf("Expected PublishID '2247503051', got '%s'", info.PublishID)
+	}
+	if info.PublishStatus != 0 {
+		t.Fatalf("Expected PublishStatus 0, got %d", info.PublishStatus)
+	}
+	if info.ArticleDetail.Count != 1 || len(info.ArticleDetail.Item) != 1 {
+		t.Fatalf("Expected 1 article, got %+v", info.ArticleDetail)
+	}
+	if info.ArticleDetail.Item[0].ArticleURL != "ARTICLE_URL" {
+		t.Fatalf("Expected ArticleURL 'ARTICLE_URL', got '%s'", info.ArticleDetail.Item[0].ArticleURL)
+	}
+}
+
+func TestParseMessage_PublishJobFinishEvent_Failed(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName>toUser</ToUserName>
+			<FromUserName>fromUser</FromUserName>
+			<CreateTime>1481013459</CreateTime>
+			<MsgType>event</MsgType>
+			<Event>PUBLISHJOBFINISH</Event>
+			<PublishEventInfo>
+				<publish_id>2247503051</publish_id>
+				<publish_status>2</publish_status>
+				<fail_idx>1</fail_idx>
+				<fail_idx>2</fail_idx>
+			</PublishEventInfo>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event := result.(*PublishJobFinishEvent)
+	if event.PublishEventInfo.PublishStatus != 2 {
+		t.Fatalf("Expected PublishStatus 2, got %d", event.PublishEventInfo.PublishStatus)
+	}
+	if len(event.PublishEventInfo.FailIdx) != 2 {
+		t.Fatalf("Expected 2 fail_idx, got %v", event.PublishEventInfo.FailIdx)
+	}
+}
+
+func TestParseMessage_TemplateSendJobFinishEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[gh_7f083739789a]]></ToUserName>
+			<FromUserName><![CDATA[oia2TjuEGTNoeX76QEjQNrcURxG8]]></FromUserName>
+			<CreateTime>1395658920</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[TEMPLATESENDJOBFINISH]]></Event>
+			<MsgID>200163836</MsgID>
+			<Status><![CDATA[success]]></Status>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*TemplateSendJobFinishEvent)
+	if !ok {
+		t.Fatalf("Expected TemplateSendJobFinishEvent, got %T", result)
+	}
+	if event.MsgID != 200163836 {
+		t.Fatalf("Expected MsgID 200163836, got %d", event.MsgID)
+	}
+	if event.Status != "success" {
+		t.Fatalf("Expected Status 'success', got '%s'", event.Status)
+	}
+}
+
+func TestParseMessage_UserGetCardEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[toUser]]></ToUserName>
+			<FromUserName><![CDATA[FromUser]]></FromUserName>
+			<CreateTime>123456789</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[user_get_card]]></Event>
+			<CardId><![CDATA[po2VNuCuRo-8sxxxxxxxxxxx]]></CardId>
+			<IsGiveByFriend>0</IsGiveByFriend>
+			<UserCardCode><![CDATA[226009850808]]></UserCardCode>
+			<FriendUserName><![CDATA[]]></FriendUserName>
+			<OuterId>0</OuterId>
+			<OldUserCardCode><![CDATA[]]></OldUserCardCode>
+			<OuterStr><![CDATA[12b]]></OuterStr>
+			<IsRestoreMemberCard>0</IsRestoreMemberCard>
+			<UnionId>o6_bmjrPTlm6_2sgVt7hMZOPfL2M</UnionId>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*UserGetCardEvent)
+	if !ok {
+		t.Fatalf("Expected UserGetCardEvent, got %T", result)
+	}
+	if event.CardID != "po2VNuCuRo-8sxxxxxxxxxxx" {
+		t.Fatalf("Expected CardID 'po2VNuCuRo-8sxxxxxxxxxxx', got '%s'", event.CardID)
+	}
+	if event.UserCardCode != "226009850808" {
+		t.Fatalf("Expected UserCardCode '226009850808', got '%s'", event.UserCardCode)
+	}
+	if event.OuterStr != "12b" || event.UnionID != "o6_bmjrPTlm6_2sgVt7hMZOPfL2M" {
+		t.Fatalf("Unexpected event fields: %+v", event)
+	}
+	if event.FromUserName != "FromUser" || event.Event != "user_get_card" {
+		t.Fatalf("Unexpected base fields: %+v", event.BaseEvent)
+	}
+}
+
+func TestParseMessage_CardEvents(t *testing.T) {
+	tests := []struct {
+		event    string
+		extra    string
+		expected interface{}
+	}{
+		{"card_pass_check", `<CardId><![CDATA[card]]></CardId>`, &CardCheckEvent{}},
+		{"card_not_pass_check", `<CardId><![CDATA[card]]></CardId><RefuseReason><![CDATA[reason]]></RefuseReason>`, &CardCheckEvent{}},
+		{"user_del_card", `<CardId><![CDATA[card]]></CardId><UserCardCode>12312312</UserCardCode>`, &UserDelCardEvent{}},
+		{"user_consume_card", `<CardId><![CDATA[card]]></CardId><UserCardCode>12312312</UserCardCode><ConsumeSource><![CDATA[FROM_API]]></ConsumeSource>`, &UserConsumeCardEvent{}},
+		{"update_member_card", `<CardId><![CDATA[card]]></CardId><UserCardCode>12312312</UserCardCode><ModifyBonus>3</ModifyBonus><ModifyBalance>-1</ModifyBalance>`, &UpdateMemberCardEvent{}},
+		{"card_sku_remind", `<CardId><![CDATA[card]]></CardId><Detail><![CDATA[the card's quantity is equal to 0]]></Detail>`, &CardSkuRemindEvent{}},
+	}
+
+	for _, tt := range tests {
+		xmlData := []byte(`<xml><ToUserName>to</ToUserName><FromUserName>from</FromUserName><CreateTime>1</CreateTime>` +
+			`<MsgType>event</MsgType><Event>` + tt.event + `</Event>` + tt.extra + `</xml>`)
+		result, err := ParseMessage(xmlData)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.event, err)
+		}
+		if fmt.Sprintf("%T", result) != fmt.Sprintf("%T", tt.expected) {
+			t.Fatalf("%s: expected %T, got %T", tt.event, tt.expected, result)
+		}
+	}
+
+	result, _ := ParseMessage([]byte(`<xml><MsgType>event</MsgType><Event>update_member_card</Event><ModifyBalance>-1</ModifyBalance></xml>`))
+	if event := result.(*UpdateMemberCardEvent); event.ModifyBalance != -1 {
+		t.Fatalf("Expected ModifyBalance -1, got %d", event.ModifyBalance)
+	}
+	result, _ = ParseMessage([]byte(`<xml><MsgType>event</MsgType><Event>card_not_pass_check</Event><RefuseReason>reason</RefuseReason></xml>`))
+	if event := result.(*CardCheckEvent); event.RefuseReason != "reason" {
+		t.Fatalf("Expected RefuseReason 'reason', got '%s'", event.RefuseReason)
+	}
+}
+
+func TestParseMessage_SubmitMemberCardUserInfoEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[gh_3fcea188bf78]]></ToUserName>
+			<FromUserName><![CDATA[obLatjlaNQKb8FqOvt1M1x1lIBFE]]></FromUserName>
+			<CreateTime>1432668700</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[submit_membercard_user_info]]></Event>
+			<CardId><![CDATA[pbLatjtZ7v1BG_ZnTjbW85GYc_E8]]></CardId>
+			<UserCardCode><![CDATA[018255396048]]></UserCardCode>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*SubmitMemberCardUserInfoEvent)
+	if !ok {
+		t.Fatalf("Expected SubmitMemberCardUserInfoEvent, got %T", result)
+	}
+	if event.CardID != "pbLatjtZ7v1BG_ZnTjbW85GYc_E8" || event.UserCardCode != "018255396048" {
+		t.Fatalf("Unexpected event fields: %+v", event)
+	}
+}
+
+func TestParseMessage_UserAuthorizeInvoiceEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[gh_fc0a06a20993]]></ToUserName>
+			<FromUserName><![CDATA[oZI8Fj040-be6rlDohc6gkoPOQTQ]]></FromUserName>
+			<CreateTime>1475134700</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[user_authorize_invoice]]></Event>
+			<SuccOrderId><![CDATA[1202933957956]]></SuccOrderId>
+			<FailOrderId><![CDATA[]]></FailOrderId>
+			<AppId><![CDATA[wx1234567890]]></AppId>
+			<Source><![CDATA[web]]></Source>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*UserAuthorizeInvoiceEvent)
+	if !ok {
+		t.Fatalf("Expected UserAuthorizeInvoiceEvent, got %T", result)
+	}
+	if event.SuccOrderID != "1202933957956" || event.AuthAppID != "wx1234567890" || event.Source != "web" {
+		t.Fatalf("Unexpected event fields: %+v", event)
+	}
+}
+
+func TestParseMessage_MerchantOrderEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[weixin_media1]]></ToUserName>
+			<FromUserName><![CDATA[oDF3iYyVlek46AyTBbMRVV8VZVlI]]></FromUserName>
+			<CreateTime>1398144192</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[merchant_order]]></Event>
+			<OrderId><![CDATA[test_order_id]]></OrderId>
+			<OrderStatus>2</OrderStatus>
+			<ProductId><![CDATA[test_product_id]]></ProductId>
+			<SkuInfo><![CDATA[10001:1000012;10002:100021]]></SkuInfo>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*MerchantOrderEvent)
+	if !ok {
+		t.Fatalf("Expected MerchantOrderEvent, got %T", result)
+	}
+	if event.OrderID != "test_order_id" || event.OrderStatus != 2 || event.SKUInfo != "10001:1000012;10002:100021" {
+		t.Fatalf("Unexpected event fields: %+v", event)
+	}
+}
+
+func TestParseMessage_MsgMenuClick(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[toUser]]></ToUserName>
+			<FromUserName><![CDATA[fromUser]]></FromUserName>
+			<CreateTime>1500000000</CreateTime>
+			<MsgType><![CDATA[text]]></MsgType>
+			<Content><![CDATA[满意]]></Content>
+			<MsgId>1234567890123456</MsgId>
+			<bizmsgmenuid>101</bizmsgmenuid>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	msg, ok := result.(*TextMessage)
+	if !ok {
+		t.Fatalf("Expected TextMessage, got %T", result)
+	}
+	if msg.Content != "满意" || msg.BizMsgMenuID != "101" {
+		t.Fatalf("Unexpected message fields: %+v", msg)
+	}
+}
+
+func TestParseMessage_MassSendJobFinishEvent(t *testing.T) {
+	xmlData := []byte(`
+		<xml>
+			<ToUserName><![CDATA[gh_4d00ed8d6399]]></ToUserName>
+			<FromUserName><![CDATA[oV5CrjpxgaGXNHIQigzNlgLTnwic]]></FromUserName>
+			<CreateTime>1481013459</CreateTime>
+			<MsgType><![CDATA[event]]></MsgType>
+			<Event><![CDATA[MASSSENDJOBFINISH]]></Event>
+			<MsgID>1000001625</MsgID>
+			<Status><![CDATA[err(30003)]]></Status>
+			<TotalCount>0</TotalCount>
+			<FilterCount>0</FilterCount>
+			<SentCount>0</SentCount>
+			<ErrorCount>0</ErrorCount>
+			<CopyrightCheckResult>
+				<Count>2</Count>
+				<ResultList>
+					<item>
+						<ArticleIdx>1</ArticleIdx>
+						<UserDeclareState>0</UserDeclareState>
+						<AuditState>2</AuditState>
+						<OriginalArticleUrl><![CDATA[Url_1]]></OriginalArticleUrl>
+						<OriginalArticleType>1</OriginalArticleType>
+						<CanReprint>1</CanReprint>
+						<NeedReplaceContent>1</NeedReplaceContent>
+						<NeedShowReprintSource>1</NeedShowReprintSource>
+					</item>
+					<item>
+						<ArticleIdx>2</ArticleIdx>
+						<UserDeclareState>0</UserDeclareState>
+						<AuditState>2</AuditState>
+						<OriginalArticleUrl><![CDATA[Url_2]]></OriginalArticleUrl>
+						<OriginalArticleType>1</OriginalArticleType>
+						<CanReprint>1</CanReprint>
+						<NeedReplaceContent>1</NeedReplaceContent>
+						<NeedShowReprintSource>1</NeedShowReprintSource>
+					</item>
+				</ResultList>
+				<CheckState>2</CheckState>
+			</CopyrightCheckResult>
+		</xml>
+	`)
+
+	result, err := ParseMessage(xmlData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event, ok := result.(*MassSendJobFinishEvent)
+	if !ok {
+		t.Fatalf("Expected MassSendJobFinishEvent, got %T", result)
+	}
+	if event.MsgID != 1000001625 || event.Status != "err(30003)" {
+		t.Fatalf("Unexpected event fields: %+v", event)
+	}
+	check := event.CopyrightCheckResult
+	if check.Count != 2 || check.CheckState != 2 || len(check.ResultList) != 2 {
+		t.Fatalf("Unexpected copyright check result: %+v", check)
+	}
+	if check.ResultList[1].ArticleIdx != 2 || check.ResultList[1].OriginalArticleURL != "Url_2" {
+		t.Fatalf("Unexpected copyright check item: %+v", check.ResultList[1])
+	}
+}
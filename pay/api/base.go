@@ -1,13 +1,12 @@
 package api
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
-	"sort"
 	"strings"
+
+	"github.com/wechatpy/wechatgo"
 )
 
 // BaseAPI 基础API
@@ -28,7 +27,9 @@ type Client interface {
 	GetAppID() string
 	GetMchID() string
 	GetAPIKey() string
+	GetSignType() SignType
 	GetHTTPClient() HTTPClient
+	GetCertHTTPClient() (HTTPClient, error)
 	GenerateJSAPIPayParams(prepayID string) (map[string]string, error)
 }
 
@@ -52,70 +53,255 @@ type BaseResponse struct {
 	ErrCodeDes string `json:"err_code_des"` // 错误代码描述
 }
 
-// PrepayRequest 预支付请求
+// Err 将返回状态码和业务结果码转换为 *wechatgo.PayError，成功时返回 nil
+func (r *BaseResponse) Err() error {
+	if e := r.err(nil, nil); e != nil {
+		return e
+	}
+	return nil
+}
+
+// err 将返回状态码和业务结果码转换为携带原始响应的 *wechatgo.PayError，成功时返回 nil
+func (r *BaseResponse) err(resp *http.Response, body []byte) *wechatgo.PayError {
+	var req *http.Request
+	if resp != nil {
+		req = resp.Request
+	}
+	var e *wechatgo.PayError
+	switch {
+	case r.ReturnCode != "" && r.ReturnCode != "SUCCESS":
+		e = wechatgo.NewPayError(r.ReturnCode, r.ResultCode, r.ReturnMsg, 0, r.ReturnMsg, req, resp)
+	case r.ResultCode != "" && r.ResultCode != "SUCCESS":
+		e = wechatgo.NewPayError(r.ReturnCode, r.ResultCode, r.ReturnMsg, 0, r.ErrCodeDes, req, resp)
+		e.Code = r.ErrCode
+	default:
+		return nil
+	}
+	e.RawBody = body
+	return e
+}
+
+// 常见业务错误码，可配合 errors.Is 使用
+var (
+	ErrOrderPaid        = &wechatgo.PayError{Code: "ORDERPAID"}         // 订单已支付
+	ErrOrderClosed      = &wechatgo.PayError{Code: "ORDERCLOSED"}       // 订单已关闭
+	ErrOrderNotExist    = &wechatgo.PayError{Code: "ORDERNOTEXIST"}     // 订单不存在
+	ErrNotEnough        = &wechatgo.PayError{Code: "NOTENOUGH"}         // 余额不足
+	ErrUserPaying       = &wechatgo.PayError{Code: "USERPAYING"}        // 用户支付中，需要输入密码
+	ErrSystemError      = &wechatgo.PayError{Code: "SYSTEMERROR"}       // 系统错误
+	ErrFrequencyLimited = &wechatgo.PayError{Code: "FREQUENCY_LIMITED"} // 频率限制
+)
+
+// PrepayRequest 预支付请求，AppID 和 MchID 为空时使用客户端配置
 type PrepayRequest struct {
-	AppID          string `json:"appid"`            // 公众号ID
-	MchID          string `json:"mch_id"`           // 商户号
-	Body           string `json:"body"`             // 商品描述
-	OutTradeNo     string `json:"out_trade_no"`     // 商户订单号
-	TotalFee       int    `json:"total_fee"`        // 总金额（分）
-	SpbillCreateIP string `json:"spbill_create_ip"` // 终端IP
-	NotifyURL      string `json:"notify_url"`       // 通知地址
-	TradeType      string `json:"trade_type"`       // 交易类型
-	OpenID         string `json:"openid,omitempty"` // 用户标识（JSAPI支付必填）
+	AppID          string   `json:"appid,omitempty"`       // 公众号ID
+	MchID          string   `json:"mch_id,omitempty"`      // 商户号
+	DeviceInfo     string   `json:"device_info,omitempty"` // 设备号（可选）
+	Body           string   `json:"body"`                  // 商品描述
+	Detail         string   `json:"detail,omitempty"`      // 商品详情（可选）
+	Attach         string   `json:"attach,omitempty"`      // 附加数据，在查询和支付通知中原样返回（可选）
+	OutTradeNo     string   `json:"out_trade_no"`          // 商户订单号
+	FeeType        string   `json:"fee_type,omitempty"`    // 货币类型，默认为 CNY（可选）
+	TotalFee       int      `json:"total_fee"`             // 总金额（分）
+	SpbillCreateIP string   `json:"spbill_create_ip"`      // 终端IP
+	TimeStart      string   `json:"time_start,omitempty"`  // 交易起始时间，格式为 yyyyMMddHHmmss（可选）
+	TimeExpire     string   `json:"time_expire,omitempty"` // 交易结束时间，格式为 yyyyMMddHHmmss（可选）
+	GoodsTag       string   `json:"goods_tag,omitempty"`   // 订单优惠标记（可选）
+	NotifyURL      string   `json:"notify_url"`            // 通知地址
+	TradeType      string   `json:"trade_type"`            // 交易类型
+	ProductID      string   `json:"product_id,omitempty"`  // 商品ID（NATIVE支付必填）
+	LimitPay       string   `json:"limit_pay,omitempty"`   // 指定支付方式，no_credit 表示不能使用信用卡（可选）
+	OpenID         string   `json:"openid,omitempty"`      // 用户标识（JSAPI支付必填）
+	SignType       SignType `json:"sign_type,omitempty"`   // 签名类型，为空时使用客户端默认值
 }
 
 // PrepayResponse 预支付响应
 type PrepayResponse struct {
 	BaseResponse
-	AppID      string `json:"appid"`       // 公众账号ID
-	MchID      string `json:"mch_id"`      // 商户号
-	DeviceInfo string `json:"device_info"` // 设备号
-	NonceStr   string `json:"nonce_str"`   // 随机字符串
-	Sign       string `json:"sign"`        // 签名
-	TradeType  string `json:"trade_type"`  // 交易类型
-	PrepayID   string `json:"prepay_id"`   // 预支付交易会话标识
-	CodeURL    string `json:"code_url"`    // 二维码链接（NATIVE支付）
+	TradeType string `json:"trade_type"` // 交易类型
+	PrepayID  string `json:"prepay_id"`  // 预支付交易会话标识
+	CodeURL   string `json:"code_url"`   // 二维码链接（NATIVE支付）
 }
 
 // APIBaseURL 微信支付API基础URL
 const APIBaseURL = "https://api.mch.weixin.qq.com/"
 
-// RandomString 生成随机字符串
-func RandomString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	result := make([]byte, length)
-	for i := range result {
-		result[i] = charset[i%len(charset)]
+// FraudBaseURL 风控接口基础URL，用于 risk/ 下的接口
+const FraudBaseURL = "https://fraud.mch.weixin.qq.com/"
+
+// apiURL 返回接口的完整URL
+func apiURL(path string) string {
+	if strings.HasPrefix(path, "risk/") {
+		return FraudBaseURL + path
+	}
+	return APIBaseURL + path
+}
+
+// md5OnlyPaths 仅支持 MD5 签名且不接受 sign_type 参数的接口
+var md5OnlyPaths = []string{"mmpaymkttransfers/"}
+
+// hmacOnlyPaths 仅支持 HMAC-SHA256 签名的接口
+var hmacOnlyPaths = []string{"pay/profitsharing", "secapi/pay/profitsharing", "risk/getpublickey"}
+
+// unsignedResponsePaths 响应中不携带签名的接口，其余接口的成功响应必须携带有效签名
+var unsignedResponsePaths = []string{
+	"mmpaymkttransfers/promotion/transfers",
+	"mmpaymkttransfers/gettransferinfo",
+	"mmpaymkttransfers/sendredpack",
+	"mmpaymkttransfers/sendgroupredpack",
+	"mmpaymkttransfers/gethbinfo",
+	"risk/getpublickey",
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
 	}
-	return string(result)
+	return false
 }
 
-// MD5 计算MD5
-func MD5(data string) string {
-	h := sha256.New()
-	h.Write([]byte(data))
-	return hex.EncodeToString(h.Sum(nil))
+// resolveSignType 确定请求的签名类型并同步 sign_type 参数
+// 优先级：接口限制 > 参数中的 sign_type > 客户端默认签名类型
+func (api *BaseAPI) resolveSignType(path string, params map[string]string) SignType {
+	switch {
+	case hasPathPrefix(path, md5OnlyPaths):
+		delete(params, "sign_type")
+		return SignTypeMD5
+	case hasPathPrefix(path, hmacOnlyPaths):
+		params["sign_type"] = string(SignTypeHMACSHA256)
+		return SignTypeHMACSHA256
+	}
+
+	signType := SignType(params["sign_type"])
+	if signType == "" {
+		signType = api.client.GetSignType()
+	}
+	if signType == "" {
+		signType = SignTypeMD5
+	}
+	params["sign_type"] = string(signType)
+	return signType
 }
 
-// GenerateSignature 生成签名
-func GenerateSignature(params map[string]string, key string) string {
-	keys := make([]string, 0, len(params))
-	for k := range params {
-		keys = append(keys, k)
+// request 补全 nonce_str、签名并发送请求，需要商户证书的接口使用 GetCertHTTPClient 返回的客户端
+// 响应使用与请求相同的签名类型校验，校验通过后检查返回码并解码到 result
+func (api *BaseAPI) request(path string, params map[string]string, result interface{}) error {
+	signType := api.resolveSignType(path, params)
+	if params["nonce_str"] == "" {
+		params["nonce_str"] = NonceStr()
 	}
-	sort.Strings(keys)
+	params["sign"] = Sign(params, api.client.GetAPIKey(), signType)
 
-	signStr := ""
-	for _, k := range keys {
-		if params[k] != "" {
-			signStr += fmt.Sprintf("%s=%s&", k, params[k])
+	httpClient := api.client.GetHTTPClient()
+	if hasPathPrefix(path, certPaths) {
+		certClient, err := api.client.GetCertHTTPClient()
+		if err != nil {
+			return fmt.Errorf("failed to load merchant certificate: %w", err)
 		}
+		httpClient = certClient
 	}
-	signStr += fmt.Sprintf("key=%s", key)
 
-	// 计算签名
-	h := hmac.New(sha256.New, []byte(key))
-	h.Write([]byte(signStr))
-	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+	resp, err := httpClient.Post(
+		apiURL(path),
+		EncodeXML(params),
+		map[string]string{
+			"Content-Type": "application/xml",
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	values, err := DecodeXML(body)
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if err := checkResponseSignature(path, values, api.client.GetAPIKey(), signType); err != nil {
+		return err
+	}
+	if err := responseError(path, values, resp, body); err != nil {
+		return err
+	}
+
+	if result != nil {
+		if err := UnmarshalParams(values, result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkResponseSignature 校验响应签名
+// return_code 为 SUCCESS 时必须携带有效签名，unsignedResponsePaths 中的接口除外；
+// 通信失败（return_code 为 FAIL）的响应不带签名，由 responseError 返回错误
+func checkResponseSignature(path string, values map[string]string, key string, signType SignType) error {
+	if values["sign"] == "" {
+		if values["return_code"] != "SUCCESS" || hasPathPrefix(path, unsignedResponsePaths) {
+			return nil
+		}
+		return wechatgo.NewInvalidSignatureError()
+	}
+	if !CheckSignature(values, key, signType) {
+		return wechatgo.NewInvalidSignatureError()
+	}
+	return nil
+}
+
+// newParams 将请求结构体编码为参数，并使用客户端配置补全未指定的公众账号ID和商户号
+// appIDKey 和 mchIDKey 为对应接口中的参数名，为空时不补全
+func (api *BaseAPI) newParams(req interface{}, appIDKey, mchIDKey string) (map[string]string, error) {
+	params, err := MarshalParams(req)
+	if err != nil {
+		return nil, err
+	}
+	if appIDKey != "" && params[appIDKey] == "" {
+		params[appIDKey] = api.client.GetAppID()
+	}
+	if mchIDKey != "" && params[mchIDKey] == "" {
+		params[mchIDKey] = api.client.GetMchID()
+	}
+	return params, nil
+}
+
+// responseError 根据 return_code 和 result_code 返回错误，错误中携带接口路径、原始响应和响应体
+// resp 未关联请求时接口路径取自 path
+func responseError(path string, values map[string]string, resp *http.Response, body []byte) error {
+	base := BaseResponse{
+		ReturnCode: values["return_code"],
+		ReturnMsg:  values["return_msg"],
+		ResultCode: values["result_code"],
+		ErrCode:    values["err_code"],
+		ErrCodeDes: values["err_code_des"],
+	}
+	e := base.err(resp, body)
+	if e == nil {
+		return nil
+	}
+	if e.Endpoint == "" && path != "" {
+		e.Endpoint = "/" + path
+	}
+	return e
+}
+
+// ParseNotify 解析并校验微信支付的异步通知，如支付结果通知
+// 签名类型由通知中的 sign_type 决定，签名无效时返回 *wechatgo.InvalidSignatureError
+// 签名有效但 return_code 或 result_code 不为 SUCCESS 时，同时返回参数和 *wechatgo.PayError
+// 返回的参数可以使用 UnmarshalParams 解码到结构体
+// https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_7
+func ParseNotify(data []byte, key string) (map[string]string, error) {
+	values, err := DecodeXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode notification: %w", err)
+	}
+	if !CheckSignature(values, key, "") {
+		return nil, wechatgo.NewInvalidSignatureError()
+	}
+	return values, responseError("", values, nil, data)
 }
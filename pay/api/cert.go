@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// certPaths 需要商户证书（双向 TLS）的接口
+var certPaths = []string{
+	"secapi/",
+	"risk/getpublickey",
+	"mmpaymkttransfers/sendredpack",
+	"mmpaymkttransfers/sendgroupredpack",
+	"mmpaymkttransfers/gethbinfo",
+	"mmpaymkttransfers/promotion/transfers",
+	"mmpaymkttransfers/gettransferinfo",
+	"mmpaymkttransfers/send_coupon",
+}
+
+// CertHTTPClient 携带商户证书的HTTP客户端，用于需要双向 TLS 的接口
+// 证书文件发生变化时会在下次握手时自动重新加载，也可以调用 Reload 主动加载
+type CertHTTPClient struct {
+	certPath string
+	keyPath  string
+	password string
+
+	client    *http.Client
+	transport *http.Transport
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// defaultCertTimeout 未指定基础HTTP客户端时的请求超时时间
+const defaultCertTimeout = 30 * time.Second
+
+// certOptions CertHTTPClient 的可选配置
+type certOptions struct {
+	client    *http.Client
+	transport *http.Transport
+}
+
+// CertOption CertHTTPClient 的配置项
+type CertOption func(*certOptions)
+
+// WithBaseHTTPClient 以指定的HTTP客户端为基础创建 CertHTTPClient，沿用其超时、Cookie 等设置
+// 客户端会被复制，其 Transport 为空时使用 http.DefaultTransport，否则必须是 *http.Transport
+func WithBaseHTTPClient(client *http.Client) CertOption {
+	return func(o *certOptions) {
+		o.client = client
+	}
+}
+
+// WithBaseTransport 以指定的 Transport 为基础创建 CertHTTPClient，沿用其代理、连接池等设置
+// Transport 会被复制，优先于 WithBaseHTTPClient 中的 Transport
+func WithBaseTransport(transport *http.Transport) CertOption {
+	return func(o *certOptions) {
+		o.transport = transport
+	}
+}
+
+// NewCertHTTPClient 创建携带商户证书的HTTP客户端
+//   - keyPath 不为空时，certPath 和 keyPath 分别为 PEM 格式的证书（apiclient_cert.pem）和私钥（apiclient_key.pem）
+//   - keyPath 为空时，certPath 为 PKCS#12 格式的证书（apiclient_cert.p12），密码为商户号 mchID；
+//     也可以是同时包含证书和私钥的 PEM 文件
+//
+// 默认基于 http.DefaultTransport 并设置 30 秒超时，可以通过 WithBaseHTTPClient、WithBaseTransport 指定
+func NewCertHTTPClient(certPath, keyPath, mchID string, opts ...CertOption) (*CertHTTPClient, error) {
+	var o certOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &CertHTTPClient{
+		certPath: certPath,
+		keyPath:  keyPath,
+		password: mchID,
+	}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	c.client = &http.Client{Timeout: defaultCertTimeout}
+	if o.client != nil {
+		client := *o.client
+		c.client = &client
+	}
+
+	base := o.transport
+	if base == nil {
+		switch t := c.client.Transport.(type) {
+		case nil:
+			base = http.DefaultTransport.(*http.Transport)
+		case *http.Transport:
+			base = t
+		default:
+			return nil, fmt.Errorf("unsupported transport %T, *http.Transport required", t)
+		}
+	}
+	c.transport = base.Clone()
+
+	// 保留基础 Transport 的 TLS 配置（如 RootCAs），证书始终由 CertHTTPClient 提供
+	if c.transport.TLSClientConfig == nil {
+		c.transport.TLSClientConfig = &tls.Config{}
+	}
+	if c.transport.TLSClientConfig.MinVersion < tls.VersionTLS12 {
+		c.transport.TLSClientConfig.MinVersion = tls.VersionTLS12
+	}
+	c.transport.TLSClientConfig.Certificates = nil
+	c.transport.TLSClientConfig.GetClientCertificate = c.getClientCertificate
+	c.client.Transport = c.transport
+	return c, nil
+}
+
+// TLSConfig 返回客户端使用的 TLS 配置，可以在发出请求前修改，如设置 RootCAs
+func (c *CertHTTPClient) TLSConfig() *tls.Config {
+	return c.transport.TLSClientConfig
+}
+
+// Reload 重新加载证书，加载失败时继续使用原证书
+// 加载成功后会关闭空闲连接，使后续请求使用新证书建立连接
+func (c *CertHTTPClient) Reload() error {
+	modTime, err := c.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.cert = cert
+	c.modTime = modTime
+	c.mu.Unlock()
+
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Get implements HTTPClient
+func (c *CertHTTPClient) Get(url string) (*http.Response, error) {
+	return c.client.Get(url)
+}
+
+// Post implements HTTPClient
+func (c *CertHTTPClient) Post(url string, data []byte, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return c.client.Do(req)
+}
+
+// getClientCertificate 在 TLS 握手时提供证书，证书文件有更新时先重新加载
+func (c *CertHTTPClient) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if modTime, err := c.latestModTime(); err == nil {
+		c.mu.RLock()
+		changed := modTime.After(c.modTime)
+		c.mu.RUnlock()
+		if changed {
+			// 文件可能正在写入，加载失败时继续使用原证书，下次握手时重试
+			_ = c.Reload()
+		}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+// latestModTime 返回证书文件和私钥文件中较晚的修改时间
+func (c *CertHTTPClient) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{c.certPath, c.keyPath} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to stat certificate: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (c *CertHTTPClient) load() (*tls.Certificate, error) {
+	if c.certPath == "" {
+		return nil, fmt.Errorf("certificate path is required")
+	}
+
+	if c.keyPath != "" {
+		cert, err := tls.LoadX509KeyPair(c.certPath, c.keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate: %w", err)
+		}
+		return &cert, nil
+	}
+
+	data, err := os.ReadFile(c.certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(c.certPath))
+	if ext != ".p12" && ext != ".pfx" && bytes.Contains(data, []byte("-----BEGIN")) {
+		cert, err := tls.X509KeyPair(data, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate: %w", err)
+		}
+		return &cert, nil
+	}
+
+	key, leaf, caCerts, err := pkcs12.DecodeChain(data, c.password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pkcs12 certificate: %w", err)
+	}
+	cert := &tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	for _, ca := range caCerts {
+		cert.Certificate = append(cert.Certificate, ca.Raw)
+	}
+	return cert, nil
+}
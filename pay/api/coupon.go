@@ -1,10 +1,5 @@
 package api
 
-import (
-	"encoding/xml"
-	"fmt"
-)
-
 // CouponAPI 代金券接口
 type CouponAPI struct {
 	*BaseAPI
@@ -17,47 +12,18 @@ func NewCouponAPI(client Client) *CouponAPI {
 	}
 }
 
-// QueryCoupons 查询代金券
+// QueryCoupons 查询代金券信息
 func (api *CouponAPI) QueryCoupons(req *QueryCouponsRequest) (*QueryCouponsResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":     api.client.GetAppID(),
-		"mch_id":    api.client.GetMchID(),
-		"coupon_id": req.CouponID,
-		"openid":    req.OpenID,
-		"stock_id":  req.StockID,
-		"nonce_str": RandomString(32),
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
+		return nil, err
 	}
 
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"marketing/coupon/query",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query coupons: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// 解析响应
 	var result QueryCouponsResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("mmpaymkttransfers/querycouponsinfo", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
@@ -71,22 +37,28 @@ type QueryCouponsRequest struct {
 // QueryCouponsResponse 查询代金券响应
 type QueryCouponsResponse struct {
 	BaseResponse
-	TotalNum   int          `json:"total_num"`   // 代金券总数
-	CouponList []CouponInfo `json:"coupon_list"` // 代金券列表
+	CouponInfo
 }
 
 // CouponInfo 代金券信息
 type CouponInfo struct {
-	CouponID           string `json:"coupon_id"`            // 代金券ID
-	CouponType         string `json:"coupon_type"`          // 代金券类型
-	CouponState        string `json:"coupon_state"`         // 代金券状态
-	GrandTotalAmount   int    `json:"grand_total_amount"`   // 优惠总金额（分）
-	DecreaseAmount     int    `json:"decrease_amount"`      // 优惠减免金额（分）
-	TransferAmount     int    `json:"transfer_amount"`      // 可转赠金额（分）
-	CouponName         string `json:"coupon_name"`          // 代金券名称
-	AvailableBeginTime string `json:"available_begin_time"` // 生效时间
-	AvailableEndTime   string `json:"available_end_time"`   // 失效时间
-	ReceiveTime        string `json:"receive_time"`         // 领取时间
-	ReceiveMethod      string `json:"receive_method"`       // 领取方式
-	SendSource         string `json:"send_source"`          // 发放来源
+	CouponStockID     string `json:"coupon_stock_id"`     // 代金券批次ID
+	CouponStockType   int    `json:"coupon_stock_type"`   // 批次类型，1 为批量型，2 为触发型
+	CouponID          string `json:"coupon_id"`           // 代金券ID
+	CouponValue       int    `json:"coupon_value"`        // 代金券面额（分）
+	CouponMinimum     int    `json:"coupon_mininumn"`     // 使用门槛（分），参数名的拼写与微信文档一致
+	CouponName        string `json:"coupon_name"`         // 代金券名称
+	CouponState       string `json:"coupon_state"`        // 代金券状态
+	CouponDesc        string `json:"coupon_desc"`         // 代金券描述
+	CouponUseValue    int    `json:"coupon_use_value"`    // 实际优惠金额（分）
+	CouponRemainValue int    `json:"coupon_remain_value"` // 优惠剩余可用额（分）
+	BeginTime         string `json:"begin_time"`          // 生效开始时间
+	EndTime           string `json:"end_time"`            // 生效结束时间
+	SendTime          string `json:"send_time"`           // 发放时间
+	UseTime           string `json:"use_time"`            // 使用时间
+	TradeNo           string `json:"trade_no"`            // 使用单号
+	ConsumerMchID     string `json:"consumer_mch_id"`     // 消耗方商户ID
+	ConsumerMchName   string `json:"consumer_mch_name"`   // 消耗方商户名称
+	SendSource        string `json:"send_source"`         // 发放来源
+	IsPartialUse      string `json:"is_partial_use"`      // 是否允许部分使用
 }
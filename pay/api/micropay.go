@@ -1,10 +1,5 @@
 package api
 
-import (
-	"encoding/xml"
-	"fmt"
-)
-
 // MicroPayAPI 刷卡支付接口
 type MicroPayAPI struct {
 	*BaseAPI
@@ -20,129 +15,75 @@ func NewMicroPayAPI(client Client) *MicroPayAPI {
 // Pay 刷卡支付
 func (api *MicroPayAPI) Pay(req *MicroPayRequest) (*MicroPayResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":            api.client.GetAppID(),
-		"mch_id":           api.client.GetMchID(),
-		"nonce_str":        RandomString(32),
-		"body":             req.Body,
-		"out_trade_no":     req.OutTradeNo,
-		"total_fee":        fmt.Sprintf("%d", req.TotalFee),
-		"spbill_create_ip": req.SpbillCreateIP,
-		"auth_code":        req.AuthCode,
-	}
-
-	if req.DeviceInfo != "" {
-		params["device_info"] = req.DeviceInfo
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
+		return nil, err
 	}
 
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"pay/micropay",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to micropay: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// 解析响应
 	var result MicroPayResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("pay/micropay", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // Reverse 撤销订单
 func (api *MicroPayAPI) Reverse(req *ReverseRequest) (*ReverseResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":        api.client.GetAppID(),
-		"mch_id":       api.client.GetMchID(),
-		"nonce_str":    RandomString(32),
-		"out_trade_no": req.OutTradeNo,
-	}
-
-	if req.TransactionID != "" {
-		params["transaction_id"] = req.TransactionID
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
+		return nil, err
 	}
 
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"secapi/pay/reverse",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to reverse: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// 解析响应
 	var result ReverseResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("secapi/pay/reverse", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // MicroPayRequest 刷卡支付请求
 type MicroPayRequest struct {
-	DeviceInfo     string `json:"device_info"`      // 设备号
-	Body           string `json:"body"`             // 商品描述
-	OutTradeNo     string `json:"out_trade_no"`     // 商户订单号
-	TotalFee       int    `json:"total_fee"`        // 总金额（分）
-	SpbillCreateIP string `json:"spbill_create_ip"` // 终端IP
-	AuthCode       string `json:"auth_code"`        // 授权码
+	DeviceInfo     string   `json:"device_info"`         // 设备号
+	Body           string   `json:"body"`                // 商品描述
+	Detail         string   `json:"detail,omitempty"`    // 商品详情（可选）
+	Attach         string   `json:"attach,omitempty"`    // 附加数据（可选）
+	OutTradeNo     string   `json:"out_trade_no"`        // 商户订单号
+	TotalFee       int      `json:"total_fee"`           // 总金额（分）
+	FeeType        string   `json:"fee_type,omitempty"`  // 货币类型，默认为 CNY（可选）
+	SpbillCreateIP string   `json:"spbill_create_ip"`    // 终端IP
+	GoodsTag       string   `json:"goods_tag,omitempty"` // 订单优惠标记（可选）
+	AuthCode       string   `json:"auth_code"`           // 授权码
+	SignType       SignType `json:"sign_type,omitempty"` // 签名类型，为空时使用客户端默认值
 }
 
 // MicroPayResponse 刷卡支付响应
 type MicroPayResponse struct {
 	BaseResponse
-	DeviceInfo  string `json:"device_info"`  // 设备号
-	OpenID      string `json:"openid"`       // 用户标识
-	IsSubscribe string `json:"is_subscribe"` // 是否关注公众账号
-	TradeType   string `json:"trade_type"`   // 交易类型
-	BankType    string `json:"bank_type"`    // 银行类型
-	TotalFee    int    `json:"total_fee"`    // 总金额（分）
-	CashFee     int    `json:"cash_fee"`     // 现金支付金额
-	TimeEnd     string `json:"time_end"`     // 支付完成时间
+	OpenID        string        `json:"openid"`         // 用户标识
+	IsSubscribe   string        `json:"is_subscribe"`   // 是否关注公众账号
+	TradeType     string        `json:"trade_type"`     // 交易类型
+	BankType      string        `json:"bank_type"`      // 银行类型
+	TotalFee      int           `json:"total_fee"`      // 总金额（分）
+	FeeType       string        `json:"fee_type"`       // 货币类型
+	CashFee       int           `json:"cash_fee"`       // 现金支付金额
+	CouponFee     int           `json:"coupon_fee"`     // 代金券金额（分）
+	Coupons       []OrderCoupon `json:"coupons"`        // 代金券列表，对应 coupon_*_$n 字段
+	TransactionID string        `json:"transaction_id"` // 微信订单号
+	OutTradeNo    string        `json:"out_trade_no"`   // 商户订单号
+	Attach        string        `json:"attach"`         // 附加数据
+	TimeEnd       string        `json:"time_end"`       // 支付完成时间
 }
 
 // ReverseRequest 撤销订单请求
 type ReverseRequest struct {
-	OutTradeNo    string `json:"out_trade_no"`   // 商户订单号
-	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutTradeNo    string   `json:"out_trade_no"`        // 商户订单号
+	TransactionID string   `json:"transaction_id"`      // 微信订单号
+	SignType      SignType `json:"sign_type,omitempty"` // 签名类型，为空时使用客户端默认值
 }
 
 // ReverseResponse 撤销订单响应
 type ReverseResponse struct {
 	BaseResponse
+	Recall string `json:"recall"` // 是否需要继续调用撤销，Y 为需要，N 为不需要
 }
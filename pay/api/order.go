@@ -1,10 +1,5 @@
 package api
 
-import (
-	"encoding/xml"
-	"fmt"
-)
-
 // OrderAPI 订单接口
 type OrderAPI struct {
 	*BaseAPI
@@ -20,166 +15,89 @@ func NewOrderAPI(client Client) *OrderAPI {
 // GetPrepayID 获取预支付交易会话标识
 func (api *OrderAPI) GetPrepayID(req *PrepayRequest) (*PrepayResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":            req.AppID,
-		"mch_id":           req.MchID,
-		"body":             req.Body,
-		"out_trade_no":     req.OutTradeNo,
-		"total_fee":        fmt.Sprintf("%d", req.TotalFee),
-		"spbill_create_ip": req.SpbillCreateIP,
-		"notify_url":       req.NotifyURL,
-		"trade_type":       req.TradeType,
-	}
-
-	if req.OpenID != "" {
-		params["openid"] = req.OpenID
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
+		return nil, err
 	}
 
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"pay/unifiedorder",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to request prepay: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// 解析响应
 	var result PrepayResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("pay/unifiedorder", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // QueryOrder 查询订单
 func (api *OrderAPI) QueryOrder(req *QueryOrderRequest) (*QueryOrderResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":  api.client.GetAppID(),
-		"mch_id": api.client.GetMchID(),
-	}
-
-	if req.OutTradeNo != "" {
-		params["out_trade_no"] = req.OutTradeNo
-	}
-	if req.TransactionID != "" {
-		params["transaction_id"] = req.TransactionID
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
-	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"pay/orderquery",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to query order: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result QueryOrderResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("pay/orderquery", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // CloseOrder 关闭订单
 func (api *OrderAPI) CloseOrder(req *CloseOrderRequest) (*CloseOrderResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":        api.client.GetAppID(),
-		"mch_id":       api.client.GetMchID(),
-		"out_trade_no": req.OutTradeNo,
-		"nonce_str":    RandomString(32),
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
-	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"pay/closeorder",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to close order: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result CloseOrderResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("pay/closeorder", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
-// QueryOrderRequest 查询订单请求
+// QueryOrderRequest 查询订单请求，OutTradeNo 和 TransactionID 二选一
 type QueryOrderRequest struct {
-	OutTradeNo    string `json:"out_trade_no"`   // 商户订单号
-	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutTradeNo    string   `json:"out_trade_no"`        // 商户订单号
+	TransactionID string   `json:"transaction_id"`      // 微信订单号
+	SignType      SignType `json:"sign_type,omitempty"` // 签名类型，为空时使用客户端默认值
+}
+
+// OrderCoupon 订单使用的代金券
+type OrderCoupon struct {
+	CouponType string `json:"coupon_type_$n"` // 代金券类型，CASH 为充值代金券，NO_CASH 为非充值优惠券
+	CouponID   string `json:"coupon_id_$n"`   // 代金券ID
+	CouponFee  int    `json:"coupon_fee_$n"`  // 代金券金额（分）
 }
 
 // QueryOrderResponse 查询订单响应
 type QueryOrderResponse struct {
 	BaseResponse
-	DeviceInfo     string `json:"device_info"`      // 设备号
-	OutTradeNo     string `json:"out_trade_no"`     // 商户订单号
-	TransactionID  string `json:"transaction_id"`   // 微信订单号
-	TradeType      string `json:"trade_type"`       // 交易类型
-	TradeState     string `json:"trade_state"`      // 交易状态
-	TradeStateDesc string `json:"trade_state_desc"` // 交易状态描述
-	BankType       string `json:"bank_type"`        // 银行类型
-	TotalFee       int    `json:"total_fee"`        // 总金额（分）
-	CashFee        int    `json:"cash_fee"`         // 现金支付金额
-	TimeEnd        string `json:"time_end"`         // 支付完成时间
+	OpenID             string        `json:"openid"`               // 用户标识
+	IsSubscribe        string        `json:"is_subscribe"`         // 是否关注公众账号
+	OutTradeNo         string        `json:"out_trade_no"`         // 商户订单号
+	TransactionID      string        `json:"transaction_id"`       // 微信订单号
+	TradeType          string        `json:"trade_type"`           // 交易类型
+	TradeState         string        `json:"trade_state"`          // 交易状态
+	TradeStateDesc     string        `json:"trade_state_desc"`     // 交易状态描述
+	BankType           string        `json:"bank_type"`            // 银行类型
+	TotalFee           int           `json:"total_fee"`            // 总金额（分）
+	SettlementTotalFee int           `json:"settlement_total_fee"` // 应结订单金额（分）
+	FeeType            string        `json:"fee_type"`             // 货币类型
+	CashFee            int           `json:"cash_fee"`             // 现金支付金额
+	CashFeeType        string        `json:"cash_fee_type"`        // 现金支付货币类型
+	CouponFee          int           `json:"coupon_fee"`           // 代金券金额（分）
+	CouponCount        int           `json:"coupon_count"`         // 代金券使用数量
+	Coupons            []OrderCoupon `json:"coupons"`              // 代金券列表，对应 coupon_*_$n 字段
+	Attach             string        `json:"attach"`               // 附加数据
+	TimeEnd            string        `json:"time_end"`             // 支付完成时间
 }
 
 // CloseOrderRequest 关闭订单请求
 type CloseOrderRequest struct {
-	OutTradeNo string `json:"out_trade_no"` // 商户订单号
+	OutTradeNo string   `json:"out_trade_no"`        // 商户订单号
+	SignType   SignType `json:"sign_type,omitempty"` // 签名类型，为空时使用客户端默认值
 }
 
 // CloseOrderResponse 关闭订单响应
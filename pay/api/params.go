@@ -0,0 +1,227 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// indexPlaceholders 下标字段名中各层级的占位符，如 coupon_id_$n、coupon_refund_id_$n_$m
+var indexPlaceholders = []string{"$n", "$m"}
+
+// MarshalParams 将请求结构体编码为参数，参数名取自字段的 json 标签
+//   - 空字符串字段不会编码；数值字段标记 omitempty 时零值不会编码
+//   - 匿名嵌入的结构体字段会被展开
+//   - 结构体切片按下标展开，元素字段名中的 $n 替换为从 0 开始的下标，如 coupon_id_0，不带 $n 的元素字段会被忽略
+//   - 元素中可再嵌套一层结构体切片，其字段名中的 $m 替换为内层下标，如 coupon_refund_id_0_1
+func MarshalParams(v interface{}) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("cannot marshal nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot marshal %s, struct required", rv.Type())
+	}
+
+	params := make(map[string]string)
+	if err := marshalStruct(rv, params, nil); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func marshalStruct(rv reflect.Value, params map[string]string, indexes []string) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		value := rv.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := marshalStruct(value, params, indexes); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, omitEmpty, ok := paramName(field)
+		if !ok {
+			continue
+		}
+
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Struct {
+			if len(indexes) >= len(indexPlaceholders) {
+				return fmt.Errorf("cannot marshal field %s: indexed slices nested too deeply", field.Name)
+			}
+			for n := 0; n < value.Len(); n++ {
+				if err := marshalStruct(value.Index(n), params, append(indexes[:len(indexes):len(indexes)], strconv.Itoa(n))); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name, ok = indexedName(name, indexes)
+		if !ok {
+			continue
+		}
+		s, err := formatParam(value)
+		if err != nil {
+			return fmt.Errorf("cannot marshal field %s: %w", field.Name, err)
+		}
+		if s == "" || (omitEmpty && value.IsZero()) {
+			continue
+		}
+		params[name] = s
+	}
+	return nil
+}
+
+func formatParam(value reflect.Value) (string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// UnmarshalParams 将参数解码到响应结构体，规则与 MarshalParams 相同
+// 结构体切片从下标 0 开始解码，直到某个下标的元素字段均不存在为止
+func UnmarshalParams(params map[string]string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot unmarshal into %T, non-nil struct pointer required", v)
+	}
+	_, err := unmarshalStruct(params, rv.Elem(), nil)
+	return err
+}
+
+// unmarshalStruct 解码结构体字段，返回是否解码到了任意字段
+func unmarshalStruct(params map[string]string, rv reflect.Value, indexes []string) (bool, error) {
+	found := false
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		value := rv.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			ok, err := unmarshalStruct(params, value, indexes)
+			if err != nil {
+				return false, err
+			}
+			found = found || ok
+			continue
+		}
+
+		name, _, ok := paramName(field)
+		if !ok {
+			continue
+		}
+
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Struct {
+			if len(indexes) >= len(indexPlaceholders) {
+				return false, fmt.Errorf("cannot unmarshal field %s: indexed slices nested too deeply", field.Name)
+			}
+			items := reflect.MakeSlice(value.Type(), 0, 0)
+			for n := 0; ; n++ {
+				item := reflect.New(value.Type().Elem()).Elem()
+				ok, err := unmarshalStruct(params, item, append(indexes[:len(indexes):len(indexes)], strconv.Itoa(n)))
+				if err != nil {
+					return false, err
+				}
+				if !ok {
+					break
+				}
+				items = reflect.Append(items, item)
+			}
+			if items.Len() > 0 {
+				value.Set(items)
+				found = true
+			}
+			continue
+		}
+
+		name, ok = indexedName(name, indexes)
+		if !ok {
+			continue
+		}
+		s, ok := params[name]
+		if !ok {
+			continue
+		}
+		if err := parseParam(value, s); err != nil {
+			return false, fmt.Errorf("cannot unmarshal %s: %w", name, err)
+		}
+		found = true
+	}
+	return found, nil
+}
+
+func parseParam(value reflect.Value, s string) error {
+	if s == "" {
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// indexedName 将字段名中的占位符替换为对应层级的下标
+// 下标元素中只处理带有当前层级占位符的字段，如外层元素需带 $n，内层元素需带 $m
+func indexedName(name string, indexes []string) (string, bool) {
+	if len(indexes) == 0 {
+		return name, true
+	}
+	if !strings.Contains(name, indexPlaceholders[len(indexes)-1]) {
+		return "", false
+	}
+	for i, index := range indexes {
+		name = strings.ReplaceAll(name, indexPlaceholders[i], index)
+	}
+	return name, true
+}
+
+// paramName 返回字段的参数名及是否标记了 omitempty，未导出或标记为 "-" 的字段返回 false
+func paramName(field reflect.StructField) (string, bool, bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,"), true
+}
@@ -1,10 +1,5 @@
 package api
 
-import (
-	"encoding/xml"
-	"fmt"
-)
-
 // RedPackAPI 红包接口
 type RedPackAPI struct {
 	*BaseAPI
@@ -17,136 +12,85 @@ func NewRedPackAPI(client Client) *RedPackAPI {
 	}
 }
 
-// SendRedPack 发送红包
+// SendRedPack 发送普通红包
 func (api *RedPackAPI) SendRedPack(req *SendRedPackRequest) (*SendRedPackResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"nonce_str":    RandomString(32),
-		"mch_billno":   req.MchBillNo,
-		"mch_id":       api.client.GetMchID(),
-		"wxappid":      api.client.GetAppID(),
-		"send_name":    req.SendName,
-		"re_openid":    req.ReOpenID,
-		"total_amount": fmt.Sprintf("%d", req.TotalAmount),
-		"total_num":    "1",
-		"wishing":      req.Wishing,
-		"client_ip":    req.ClientIP,
-		"act_name":     req.ActName,
-		"remark":       req.Remark,
-	}
-
-	if req.DeviceInfo != "" {
-		params["device_info"] = req.DeviceInfo
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
+	params, err := api.newParams(req, "wxappid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf(": %w", err)
+		return nil, err
 	}
-
-	// 发送failed to marshal xml请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"mmpaymkttransfers/sendredpack",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send red pack: %w", err)
+	if params["total_num"] == "" {
+		params["total_num"] = "1"
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result SendRedPackResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("mmpaymkttransfers/sendredpack", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // QueryRedPack 查询红包状态
 func (api *RedPackAPI) QueryRedPack(req *QueryRedPackRequest) (*QueryRedPackResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"mch_id":     api.client.GetMchID(),
-		"appid":      api.client.GetAppID(),
-		"mch_billno": req.MchBillNo,
-		"nonce_str":  RandomString(32),
-		"bill_type":  "MCHT",
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
-	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"mmpaymkttransfers/gethbinfo",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to query red pack: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	params["bill_type"] = "MCHT"
 
-	// 解析响应
 	var result QueryRedPackResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("mmpaymkttransfers/gethbinfo", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // SendRedPackRequest 发送红包请求
 type SendRedPackRequest struct {
-	DeviceInfo  string `json:"device_info"`  // 设备号
-	MchBillNo   string `json:"mch_bill_no"`  // 商户订单号
-	SendName    string `json:"send_name"`    // 发送者名称
-	ReOpenID    string `json:"re_openid"`    // 接收者OpenID
-	TotalAmount int    `json:"total_amount"` // 红包金额（分）
-	Wishing     string `json:"wishing"`      // 祝福语
-	ClientIP    string `json:"client_ip"`    // 客户端IP
-	ActName     string `json:"act_name"`     // 活动名称
-	Remark      string `json:"remark"`       // 备注
+	MchBillNo   string `json:"mch_billno"`          // 商户订单号
+	SendName    string `json:"send_name"`           // 发送者名称
+	ReOpenID    string `json:"re_openid"`           // 接收者OpenID
+	TotalAmount int    `json:"total_amount"`        // 红包金额（分）
+	TotalNum    int    `json:"total_num,omitempty"` // 红包发放总人数，默认为 1
+	Wishing     string `json:"wishing"`             // 祝福语
+	ClientIP    string `json:"client_ip"`           // 客户端IP
+	ActName     string `json:"act_name"`            // 活动名称
+	Remark      string `json:"remark"`              // 备注
+	SceneID     string `json:"scene_id,omitempty"`  // 场景ID，金额大于 200 元时必填（可选）
+	RiskInfo    string `json:"risk_info,omitempty"` // 活动信息（可选）
 }
 
 // SendRedPackResponse 发送红包响应
 type SendRedPackResponse struct {
 	BaseResponse
-	MchBillNo  string `json:"mch_bill_no"`  // 商户订单号
-	SendListID string `json:"send_list_id"` // 微信单号
+	MchBillNo   string `json:"mch_billno"`   // 商户订单号
+	WxAppID     string `json:"wxappid"`      // 公众账号ID
+	ReOpenID    string `json:"re_openid"`    // 接收者OpenID
+	TotalAmount int    `json:"total_amount"` // 红包金额（分）
+	SendListID  string `json:"send_listid"`  // 微信单号
 }
 
 // QueryRedPackRequest 查询红包请求
 type QueryRedPackRequest struct {
-	MchBillNo string `json:"mch_bill_no"` // 商户订单号
+	MchBillNo string `json:"mch_billno"` // 商户订单号
 }
 
 // QueryRedPackResponse 查询红包响应
 type QueryRedPackResponse struct {
 	BaseResponse
-	MchBillNo  string `json:"mch_bill_no"` // 商户订单号
-	SendName   string `json:"send_name"`   // 发送者名称
-	ReOpenID   string `json:"re_openid"`   // 接收者OpenID
-	SendAmount int    `json:"send_amount"` // 红包金额（分）
-	SendStatus string `json:"send_status"` // 发送状态
-	SendTime   string `json:"send_time"`   // 发送时间
-	HbStatus   string `json:"hb_status"`   // 红包状态
+	MchBillNo    string `json:"mch_billno"`    // 商户订单号
+	DetailID     string `json:"detail_id"`     // 红包单号
+	Status       string `json:"status"`        // 红包状态，如 SENDING、SENT、RECEIVED、REFUND
+	SendType     string `json:"send_type"`     // 发放类型
+	HbType       string `json:"hb_type"`       // 红包类型，GROUP 为裂变红包，NORMAL 为普通红包
+	TotalNum     int    `json:"total_num"`     // 红包个数
+	TotalAmount  int    `json:"total_amount"`  // 红包总金额（分）
+	Reason       string `json:"reason"`        // 发送失败原因
+	SendTime     string `json:"send_time"`     // 发送时间
+	RefundTime   string `json:"refund_time"`   // 退款时间
+	RefundAmount int    `json:"refund_amount"` // 退款金额（分）
+	Wishing      string `json:"wishing"`       // 祝福语
+	Remark       string `json:"remark"`        // 备注
+	ActName      string `json:"act_name"`      // 活动名称
 }
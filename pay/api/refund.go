@@ -1,10 +1,5 @@
 package api
 
-import (
-	"encoding/xml"
-	"fmt"
-)
-
 // RefundAPI 退款接口
 type RefundAPI struct {
 	*BaseAPI
@@ -20,153 +15,114 @@ func NewRefundAPI(client Client) *RefundAPI {
 // Refund 申请退款
 func (api *RefundAPI) Refund(req *RefundRequest) (*RefundResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":           api.client.GetAppID(),
-		"mch_id":          api.client.GetMchID(),
-		"nonce_str":       RandomString(32),
-		"out_refund_no":   req.OutRefundNo,
-		"out_trade_no":    req.OutTradeNo,
-		"total_fee":       fmt.Sprintf("%d", req.TotalFee),
-		"refund_fee":      fmt.Sprintf("%d", req.RefundFee),
-		"refund_fee_type": "CNY",
-	}
-
-	if req.TransactionID != "" {
-		params["transaction_id"] = req.TransactionID
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
-	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"secapi/pay/refund",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to refund: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result RefundResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("secapi/pay/refund", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // QueryRefund 查询退款
 func (api *RefundAPI) QueryRefund(req *QueryRefundRequest) (*QueryRefundResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":     api.client.GetAppID(),
-		"mch_id":    api.client.GetMchID(),
-		"nonce_str": RandomString(32),
-	}
-
-	if req.OutRefundNo != "" {
-		params["out_refund_no"] = req.OutRefundNo
-	}
-	if req.RefundID != "" {
-		params["refund_id"] = req.RefundID
-	}
-	if req.OutTradeNo != "" {
-		params["out_trade_no"] = req.OutTradeNo
-	}
-	if req.TransactionID != "" {
-		params["transaction_id"] = req.TransactionID
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
-	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"pay/refundquery",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to query refund: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result QueryRefundResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("pay/refundquery", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
-// RefundRequest 退款请求
+// RefundRequest 退款请求，OutTradeNo 和 TransactionID 二选一
 type RefundRequest struct {
-	OutTradeNo    string `json:"out_trade_no"`   // 商户订单号
-	OutRefundNo   string `json:"out_refund_no"`  // 商户退款单号
-	TotalFee      int    `json:"total_fee"`      // 订单总金额（分）
-	RefundFee     int    `json:"refund_fee"`     // 退款金额（分）
-	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutTradeNo    string   `json:"out_trade_no"`              // 商户订单号
+	OutRefundNo   string   `json:"out_refund_no"`             // 商户退款单号
+	TotalFee      int      `json:"total_fee"`                 // 订单总金额（分）
+	RefundFee     int      `json:"refund_fee"`                // 退款金额（分）
+	RefundFeeType string   `json:"refund_fee_type,omitempty"` // 退款货币种类，默认为 CNY（可选）
+	RefundDesc    string   `json:"refund_desc,omitempty"`     // 退款原因（可选）
+	RefundAccount string   `json:"refund_account,omitempty"`  // 退款资金来源（可选）
+	NotifyURL     string   `json:"notify_url,omitempty"`      // 退款结果通知地址（可选）
+	TransactionID string   `json:"transaction_id"`            // 微信订单号
+	SignType      SignType `json:"sign_type,omitempty"`       // 签名类型，为空时使用客户端默认值
+}
+
+// RefundCoupon 退款涉及的代金券
+type RefundCoupon struct {
+	CouponType      string `json:"coupon_type_$n"`       // 代金券类型
+	CouponRefundID  string `json:"coupon_refund_id_$n"`  // 退款代金券ID
+	CouponRefundFee int    `json:"coupon_refund_fee_$n"` // 单个代金券退款金额（分）
 }
 
 // RefundResponse 退款响应
 type RefundResponse struct {
 	BaseResponse
-	DeviceInfo        string `json:"device_info"`         // 设备号
-	TransactionID     string `json:"transaction_id"`      // 微信订单号
-	OutTradeNo        string `json:"out_trade_no"`        // 商户订单号
-	OutRefundNo       string `json:"out_refund_no"`       // 商户退款单号
-	RefundID          string `json:"refund_id"`           // 微信退款单号
-	RefundFee         int    `json:"refund_fee"`          // 退款金额（分）
-	TotalFee          int    `json:"total_fee"`           // 订单总金额（分）
-	CashFee           int    `json:"cash_fee"`            // 现金退款金额（分）
-	RefundStatus      string `json:"refund_status"`       // 退款状态
-	RefundRecvAccount string `json:"refund_recv_account"` // 退款入账账户
-	RefundAccount     string `json:"refund_account"`      // 退款资金来源
-	Time              string `json:"time"`                // 退款时间
+	TransactionID       string         `json:"transaction_id"`        // 微信订单号
+	OutTradeNo          string         `json:"out_trade_no"`          // 商户订单号
+	OutRefundNo         string         `json:"out_refund_no"`         // 商户退款单号
+	RefundID            string         `json:"refund_id"`             // 微信退款单号
+	RefundFee           int            `json:"refund_fee"`            // 退款金额（分）
+	SettlementRefundFee int            `json:"settlement_refund_fee"` // 应结退款金额（分）
+	TotalFee            int            `json:"total_fee"`             // 订单总金额（分）
+	CashFee             int            `json:"cash_fee"`              // 现金支付金额（分）
+	CashRefundFee       int            `json:"cash_refund_fee"`       // 现金退款金额（分）
+	CouponRefundFee     int            `json:"coupon_refund_fee"`     // 代金券退款总金额（分）
+	CouponRefundCount   int            `json:"coupon_refund_count"`   // 退款代金券使用数量
+	Coupons             []RefundCoupon `json:"coupons"`               // 退款代金券列表，对应 coupon_*_$n 字段
 }
 
-// QueryRefundRequest 查询退款请求
+// QueryRefundRequest 查询退款请求，四个单号任选其一，优先级为 RefundID > OutRefundNo > TransactionID > OutTradeNo
 type QueryRefundRequest struct {
-	OutRefundNo   string `json:"out_refund_no"`  // 商户退款单号
-	RefundID      string `json:"refund_id"`      // 微信退款单号
-	OutTradeNo    string `json:"out_trade_no"`   // 商户订单号
-	TransactionID string `json:"transaction_id"` // 微信订单号
+	OutRefundNo   string   `json:"out_refund_no"`       // 商户退款单号
+	RefundID      string   `json:"refund_id"`           // 微信退款单号
+	OutTradeNo    string   `json:"out_trade_no"`        // 商户订单号
+	TransactionID string   `json:"transaction_id"`      // 微信订单号
+	Offset        int      `json:"offset,omitempty"`    // 偏移量，订单退款笔数超过 10 笔时用于分页（可选）
+	SignType      SignType `json:"sign_type,omitempty"` // 签名类型，为空时使用客户端默认值
+}
+
+// RefundDetail 退款单详情
+type RefundDetail struct {
+	OutRefundNo         string               `json:"out_refund_no_$n"`         // 商户退款单号
+	RefundID            string               `json:"refund_id_$n"`             // 微信退款单号
+	RefundChannel       string               `json:"refund_channel_$n"`        // 退款渠道
+	RefundFee           int                  `json:"refund_fee_$n"`            // 申请退款金额（分）
+	SettlementRefundFee int                  `json:"settlement_refund_fee_$n"` // 退款金额（分）
+	CouponRefundFee     int                  `json:"coupon_refund_fee_$n"`     // 代金券退款金额（分）
+	CouponRefundCount   int                  `json:"coupon_refund_count_$n"`   // 退款代金券使用数量
+	RefundStatus        string               `json:"refund_status_$n"`         // 退款状态
+	RefundAccount       string               `json:"refund_account_$n"`        // 退款资金来源
+	RefundRecvAccount   string               `json:"refund_recv_accout_$n"`    // 退款入账账户，参数名的拼写与微信文档一致
+	RefundSuccessTime   string               `json:"refund_success_time_$n"`   // 退款成功时间
+	Coupons             []RefundDetailCoupon `json:"coupons"`                  // 退款代金券列表，对应 coupon_*_$n_$m 字段
+}
+
+// RefundDetailCoupon 退款单详情中的代金券
+type RefundDetailCoupon struct {
+	CouponType      string `json:"coupon_type_$n_$m"`       // 代金券类型
+	CouponRefundID  string `json:"coupon_refund_id_$n_$m"`  // 退款代金券ID
+	CouponRefundFee int    `json:"coupon_refund_fee_$n_$m"` // 单个退款代金券支付金额（分）
 }
 
 // QueryRefundResponse 查询退款响应
 type QueryRefundResponse struct {
 	BaseResponse
-	DeviceInfo        string `json:"device_info"`         // 设备号
-	OutTradeNo        string `json:"out_trade_no"`        // 商户订单号
-	TransactionID     string `json:"transaction_id"`      // 微信订单号
-	OutRefundNo       string `json:"out_refund_no"`       // 商户退款单号
-	RefundID          string `json:"refund_id"`           // 微信退款单号
-	RefundFee         int    `json:"refund_fee"`          // 退款金额（分）
-	TotalFee          int    `json:"total_fee"`           // 订单总金额（分）
-	CashFee           int    `json:"cash_fee"`            // 现金退款金额（分）
-	RefundStatus      string `json:"refund_status"`       // 退款状态
-	RefundRecvAccount string `json:"refund_recv_account"` // 退款入账账户
-	RefundAccount     string `json:"refund_account"`      // 退款资金来源
+	TotalRefundCount   int            `json:"total_refund_count"`   // 订单总退款次数
+	OutTradeNo         string         `json:"out_trade_no"`         // 商户订单号
+	TransactionID      string         `json:"transaction_id"`       // 微信订单号
+	TotalFee           int            `json:"total_fee"`            // 订单总金额（分）
+	SettlementTotalFee int            `json:"settlement_total_fee"` // 应结订单金额（分）
+	FeeType            string         `json:"fee_type"`             // 货币类型
+	CashFee            int            `json:"cash_fee"`             // 现金支付金额（分）
+	RefundCount        int            `json:"refund_count"`         // 本次返回的退款笔数
+	Refunds            []RefundDetail `json:"refunds"`              // 退款单列表，对应 *_$n 字段
 }
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// SignType 签名类型
+type SignType string
+
+const (
+	SignTypeMD5        SignType = "MD5"         // MD5，未指定 sign_type 时的默认值
+	SignTypeHMACSHA256 SignType = "HMAC-SHA256" // HMAC-SHA256，以 API 密钥为 HMAC 密钥
+)
+
+// signTypeOf 返回参数中 sign_type 指定的签名类型，未指定时为 MD5
+func signTypeOf(params map[string]string) SignType {
+	if signType := params["sign_type"]; signType != "" {
+		return SignType(signType)
+	}
+	return SignTypeMD5
+}
+
+// RandomString 使用 crypto/rand 生成由字母和数字组成的随机字符串
+func RandomString(length int) string {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	max := big.NewInt(int64(len(charset)))
+	result := make([]byte, length)
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic("pay: crypto/rand unavailable: " + err.Error())
+		}
+		result[i] = charset[n.Int64()]
+	}
+	return string(result)
+}
+
+// NonceStr 生成 32 位随机字符串，用作 nonce_str
+func NonceStr() string {
+	return RandomString(32)
+}
+
+// MD5 计算 MD5，返回小写十六进制字符串
+func MD5(data string) string {
+	sum := md5.Sum([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// HMACSHA256 以 key 为密钥计算 HMAC-SHA256，返回小写十六进制字符串
+func HMACSHA256(data, key string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// signString 按参数名 ASCII 码排序拼接待签名字符串，跳过空值和 sign 字段，末尾拼接 key
+func signString(params map[string]string, key string) string {
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		if k == "sign" || v == "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(params[k])
+		b.WriteByte('&')
+	}
+	b.WriteString("key=")
+	b.WriteString(key)
+	return b.String()
+}
+
+// Sign 使用指定的签名类型计算签名，返回大写十六进制字符串
+// https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=4_3
+func Sign(params map[string]string, key string, signType SignType) string {
+	data := signString(params, key)
+	if signType == SignTypeHMACSHA256 {
+		return strings.ToUpper(HMACSHA256(data, key))
+	}
+	return strings.ToUpper(MD5(data))
+}
+
+// GenerateSignature 生成签名，签名类型由参数中的 sign_type 决定，未指定时为 MD5
+func GenerateSignature(params map[string]string, key string) string {
+	return Sign(params, key, signTypeOf(params))
+}
+
+// CheckSignature 校验参数中的 sign 字段
+// signType 为空时使用参数中的 sign_type，均未指定时为 MD5
+func CheckSignature(params map[string]string, key string, signType SignType) bool {
+	sign := params["sign"]
+	if sign == "" {
+		return false
+	}
+	if signType == "" {
+		signType = signTypeOf(params)
+	}
+	expected := Sign(params, key, signType)
+	return hmac.Equal([]byte(expected), []byte(strings.ToUpper(sign)))
+}
@@ -1,8 +1,9 @@
 package api
 
-import (
-	"encoding/xml"
-	"fmt"
+// 企业付款校验用户姓名选项
+const (
+	CheckNameNo    = "NO_CHECK"    // 不校验真实姓名
+	CheckNameForce = "FORCE_CHECK" // 强校验真实姓名
 )
 
 // TransferAPI 企业付款接口
@@ -17,132 +18,76 @@ func NewTransferAPI(client Client) *TransferAPI {
 	}
 }
 
-// Transfer 企业付款
+// Transfer 企业付款到零钱
 func (api *TransferAPI) Transfer(req *TransferRequest) (*TransferResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"mchid":            api.client.GetMchID(),
-		"out_biz_no":       req.OutBizNo,
-		"pay_scene":        req.PayScene,
-		"payer_type":       "OPENID",
-		"openid":           req.OpenID,
-		"check_name":       req.CheckName,
-		"amount":           fmt.Sprintf("%d", req.Amount),
-		"appid":            api.client.GetAppID(),
-		"description":      req.Description,
-		"spbill_create_ip": req.SpbillCreateIP,
-	}
-
-	if req.PayerName != "" {
-		params["payer_real_name"] = req.PayerName
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
+	params, err := api.newParams(req, "mch_appid", "mchid")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
+		return nil, err
 	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"mmpaymkttransfers/promotion/transfers",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to transfer: %w", err)
+	if params["check_name"] == "" {
+		params["check_name"] = CheckNameNo
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result TransferResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("mmpaymkttransfers/promotion/transfers", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // QueryTransfer 查询企业付款
 func (api *TransferAPI) QueryTransfer(req *QueryTransferRequest) (*QueryTransferResponse, error) {
 	// 构建请求参数
-	params := map[string]string{
-		"appid":      api.client.GetAppID(),
-		"mchid":      api.client.GetMchID(),
-		"out_biz_no": req.OutBizNo,
-		"nonce_str":  RandomString(32),
-	}
-
-	// 生成签名
-	sign := GenerateSignature(params, api.client.GetAPIKey())
-	params["sign"] = sign
-
-	// 转换为XML
-	xmlData, err := xml.Marshal(map[string]string(params))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal xml: %w", err)
-	}
-
-	// 发送请求
-	resp, err := api.client.GetHTTPClient().Post(
-		APIBaseURL+"mmpaymkttransfers/gettransferinfo",
-		xmlData,
-		map[string]string{
-			"Content-Type": "application/xml",
-		},
-	)
+	params, err := api.newParams(req, "appid", "mch_id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to query transfer: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// 解析响应
 	var result QueryTransferResponse
-	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := api.request("mmpaymkttransfers/gettransferinfo", params, &result); err != nil {
+		return nil, err
 	}
-
 	return &result, nil
 }
 
 // TransferRequest 企业付款请求
 type TransferRequest struct {
-	OutBizNo       string `json:"out_biz_no"`       // 商户订单号
-	PayScene       string `json:"pay_scene"`        // 支付场景
+	DeviceInfo     string `json:"device_info"`      // 设备号（可选）
+	PartnerTradeNo string `json:"partner_trade_no"` // 商户订单号
 	OpenID         string `json:"openid"`           // 接收者OpenID
-	CheckName      string `json:"check_name"`       // 校验方式
-	PayerName      string `json:"payer_name"`       // 收款人真实姓名（可选）
+	CheckName      string `json:"check_name"`       // 校验用户姓名选项，使用 CheckName 常量，默认为 CheckNameNo
+	ReUserName     string `json:"re_user_name"`     // 收款用户真实姓名，CheckName 为 CheckNameForce 时必填
 	Amount         int    `json:"amount"`           // 金额（分）
-	Description    string `json:"description"`      // 付款描述
-	SpbillCreateIP string `json:"spbill_create_ip"` // 终端IP
+	Desc           string `json:"desc"`             // 付款备注
+	SpbillCreateIP string `json:"spbill_create_ip"` // 终端IP（可选）
 }
 
 // TransferResponse 企业付款响应
 type TransferResponse struct {
 	BaseResponse
-	OutBizNo    string `json:"out_biz_no"`   // 商户订单号
-	TransferFee int    `json:"transfer_fee"` // 手续费（分）
-	PayTime     string `json:"pay_time"`     // 付款时间
+	MchAppID       string `json:"mch_appid"`        // 商户账号 appid
+	PartnerTradeNo string `json:"partner_trade_no"` // 商户订单号
+	PaymentNo      string `json:"payment_no"`       // 微信付款单号
+	PaymentTime    string `json:"payment_time"`     // 付款成功时间
 }
 
 // QueryTransferRequest 查询企业付款请求
 type QueryTransferRequest struct {
-	OutBizNo string `json:"out_biz_no"` // 商户订单号
+	PartnerTradeNo string `json:"partner_trade_no"` // 商户订单号
 }
 
 // QueryTransferResponse 查询企业付款响应
 type QueryTransferResponse struct {
 	BaseResponse
-	OutBizNo      string `json:"out_biz_no"`     // 商户订单号
-	PayScene      string `json:"pay_scene"`      // 支付场景
-	OpenID        string `json:"openid"`         // 接收者OpenID
-	TransferState string `json:"transfer_state"` // 转账状态
-	TransferFee   int    `json:"transfer_fee"`   // 手续费（分）
-	PayTime       string `json:"pay_time"`       // 付款时间
+	PartnerTradeNo string `json:"partner_trade_no"` // 商户订单号
+	DetailID       string `json:"detail_id"`        // 付款单号
+	Status         string `json:"status"`           // 转账状态，SUCCESS、FAILED 或 PROCESSING
+	Reason         string `json:"reason"`           // 失败原因
+	OpenID         string `json:"openid"`           // 接收者OpenID
+	TransferName   string `json:"transfer_name"`    // 收款用户姓名
+	PaymentAmount  int    `json:"payment_amount"`   // 付款金额（分）
+	TransferTime   string `json:"transfer_time"`    // 发起转账的时间
+	PaymentTime    string `json:"payment_time"`     // 付款成功时间
+	Desc           string `json:"desc"`             // 付款备注
 }
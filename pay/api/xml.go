@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// EncodeXML 将参数编码为 <xml> 根节点下的平铺 XML，参数值使用 CDATA 包裹
+// 参数按名称排序以保证输出稳定
+func EncodeXML(params map[string]string) []byte {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("<xml>")
+	for _, k := range keys {
+		buf.WriteString("<" + k + "><![CDATA[")
+		// CDATA 中不能出现 "]]>"，需要拆分为两段
+		buf.WriteString(strings.ReplaceAll(params[k], "]]>", "]]]]><![CDATA[>"))
+		buf.WriteString("]]></" + k + ">")
+	}
+	buf.WriteString("</xml>")
+	return buf.Bytes()
+}
+
+// DecodeXML 将微信支付返回的平铺 XML 解码为参数
+func DecodeXML(data []byte) (map[string]string, error) {
+	params := make(map[string]string)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	depth := 0
+	var name string
+	var text bytes.Buffer
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 {
+				name = t.Name.Local
+				text.Reset()
+			}
+		case xml.CharData:
+			if depth == 2 {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				params[name] = text.String()
+			}
+			depth--
+		}
+	}
+	return params, nil
+}
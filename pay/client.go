@@ -3,8 +3,7 @@ package pay
 import (
 	"fmt"
 	"net/http"
-	"sort"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/wechatpy/wechatgo/pay/api"
@@ -12,12 +11,19 @@ import (
 
 // Client 微信支付客户端
 type Client struct {
-	AppID      string `json:"appid"`     // 公众号APPID
-	APIKey     string `json:"api_key"`   // 商户API密钥
-	MchID      string `json:"mch_id"`    // 商户号
-	CertPath   string `json:"cert_path"` // 商户证书路径
-	KeyPath    string `json:"key_path"`  // 商户私钥路径
-	httpClient api.HTTPClient
+	AppID    string `json:"appid"`     // 公众号APPID
+	APIKey   string `json:"api_key"`   // 商户API密钥
+	MchID    string `json:"mch_id"`    // 商户号
+	CertPath string `json:"cert_path"` // 商户证书路径
+	KeyPath  string `json:"key_path"`  // 商户私钥路径
+	// SignType 默认签名类型，为空时使用 MD5；请求参数中的 sign_type 优先
+	SignType api.SignType `json:"sign_type"`
+	// CertOptions 创建商户证书HTTP客户端时使用的配置，如 api.WithBaseHTTPClient，需在首次请求前设置
+	CertOptions []api.CertOption `json:"-"`
+	httpClient  api.HTTPClient
+
+	certMu     sync.Mutex
+	certClient *api.CertHTTPClient
 
 	// API 模块
 	Order       *api.OrderAPI       `json:"-"` // 订单接口
@@ -74,11 +80,53 @@ func (c *Client) GetAPIKey() string {
 	return c.APIKey
 }
 
+// GetSignType 返回默认签名类型
+func (c *Client) GetSignType() api.SignType {
+	if c.SignType == "" {
+		return api.SignTypeMD5
+	}
+	return c.SignType
+}
+
 // GetHTTPClient 返回HTTP客户端
 func (c *Client) GetHTTPClient() api.HTTPClient {
 	return c.httpClient
 }
 
+// GetCertHTTPClient 返回携带商户证书的HTTP客户端，用于退款、红包、企业付款、分账等需要证书的接口
+// 首次调用时根据 CertPath 和 KeyPath 加载证书，未配置 CertPath 时返回普通HTTP客户端，
+// 此时需要由创建客户端时传入的 HTTPClient 自行携带证书
+func (c *Client) GetCertHTTPClient() (api.HTTPClient, error) {
+	if c.CertPath == "" {
+		return c.httpClient, nil
+	}
+
+	c.certMu.Lock()
+	defer c.certMu.Unlock()
+	if c.certClient == nil {
+		certClient, err := api.NewCertHTTPClient(c.CertPath, c.KeyPath, c.MchID, c.CertOptions...)
+		if err != nil {
+			return nil, err
+		}
+		c.certClient = certClient
+	}
+	return c.certClient, nil
+}
+
+// ReloadCert 重新加载商户证书，用于证书更换后无需重启即可生效
+// 证书文件被替换时也会在下次建立连接时自动加载
+func (c *Client) ReloadCert() error {
+	c.certMu.Lock()
+	certClient := c.certClient
+	c.certMu.Unlock()
+
+	if certClient == nil {
+		_, err := c.GetCertHTTPClient()
+		return err
+	}
+	return certClient.Reload()
+}
+
 // Get implements api.HTTPClient
 func (c *Client) Get(url string) (*http.Response, error) {
 	return c.httpClient.Get(url)
@@ -98,47 +146,35 @@ func (c *Client) GetPrepayID(req *api.PrepayRequest) (string, error) {
 	return result.PrepayID, nil
 }
 
-// GenerateJSAPIPayParams 生成JSAPI支付参数
+// GenerateJSAPIPayParams 生成JSAPI支付参数，paySign 使用客户端的默认签名类型
+// https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=7_7
 func (c *Client) GenerateJSAPIPayParams(prepayID string) (map[string]string, error) {
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	nonceStr := api.RandomString(32)
-	pkg := fmt.Sprintf("prepay_id=%s", prepayID)
-
-	// 生成签名
-	signStr := fmt.Sprintf("appId=%s&nonceStr=%s&package=%s&signType=MD5&timeStamp=%s&key=%s",
-		c.AppID, nonceStr, pkg, timestamp, c.APIKey)
-	md5Hash := api.MD5(signStr)
-	sign := strings.ToUpper(md5Hash)
-
-	return map[string]string{
+	signType := c.GetSignType()
+	params := map[string]string{
 		"appId":     c.AppID,
-		"timeStamp": timestamp,
-		"nonceStr":  nonceStr,
-		"package":   pkg,
-		"signType":  "MD5",
-		"paySign":   sign,
-	}, nil
+		"timeStamp": fmt.Sprintf("%d", time.Now().Unix()),
+		"nonceStr":  api.NonceStr(),
+		"package":   fmt.Sprintf("prepay_id=%s", prepayID),
+		"signType":  string(signType),
+	}
+	params["paySign"] = api.Sign(params, c.APIKey, signType)
+	return params, nil
 }
 
-// VerifySignature 验证签名
+// VerifySignature 验证签名，签名类型由参数中的 sign_type 决定，未指定时为 MD5
+// 不会修改 params
 func (c *Client) VerifySignature(params map[string]string, sign string) bool {
-	// 生成签名
-	delete(params, "sign")
-	keys := make([]string, 0, len(params))
-	for k := range params {
-		keys = append(keys, k)
+	values := make(map[string]string, len(params))
+	for k, v := range params {
+		values[k] = v
 	}
-	sort.Strings(keys)
-
-	signStr := ""
-	for _, k := range keys {
-		signStr += fmt.Sprintf("%s=%s&", k, params[k])
-	}
-	signStr += fmt.Sprintf("key=%s", c.APIKey)
-
-	// 计算签名
-	md5Hash := api.MD5(signStr)
-	calcSign := strings.ToUpper(md5Hash)
+	values["sign"] = sign
+	return api.CheckSignature(values, c.APIKey, "")
+}
 
-	return calcSign == sign
+// ParsePaymentResult 解析并校验支付结果通知
+// 签名有效的失败通知会同时返回参数和 *wechatgo.PayError，可据此应答微信
+// https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_7
+func (c *Client) ParsePaymentResult(data []byte) (map[string]string, error) {
+	return api.ParseNotify(data, c.APIKey)
 }
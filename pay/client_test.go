@@ -1,10 +1,26 @@
 package pay
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/pay/api"
 )
 
 // mockHTTPClient mock HTTP客户端实现api.HTTPClient接口
@@ -101,3 +117,495 @@ func TestClient_Post(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+// xmlHTTPClient 记录请求并返回预设的 XML 响应
+type xmlHTTPClient struct {
+	url      string
+	request  map[string]string
+	response func(request map[string]string) map[string]string
+}
+
+func (m *xmlHTTPClient) Post(url string, data []byte, headers map[string]string) (*http.Response, error) {
+	m.url = url
+	request, err := api.DecodeXML(data)
+	if err != nil {
+		return nil, err
+	}
+	m.request = request
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Request-Id": {"req_001"}},
+		Body:       io.NopCloser(bytes.NewReader(api.EncodeXML(m.response(request)))),
+		Request:    req,
+	}, nil
+}
+
+func (m *xmlHTTPClient) Get(url string) (*http.Response, error) {
+	return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+}
+
+// signResponse 使用请求的签名类型为模拟响应签名
+func signResponse(request, response map[string]string) map[string]string {
+	response["sign"] = api.Sign(response, "api_key", api.SignType(request["sign_type"]))
+	return response
+}
+
+func TestSign(t *testing.T) {
+	params := map[string]string{
+		"appid":       "wxd930ea5d5a258f4f",
+		"mch_id":      "10000100",
+		"device_info": "1000",
+		"body":        "test",
+		"nonce_str":   "ibuaiVcKdpRxkhJA",
+		"attach":      "",
+	}
+	key := "192006250b4c09247ec02edce69f6a2d"
+
+	assert.Equal(t, "9A0A8659F005D6984697E2CA0A9CF3B7", api.Sign(params, key, api.SignTypeMD5))
+	assert.Equal(t, "6A9AE1657590FD6257D693A078E1C3E4BB6BA4DC30B23E0EE2496E54170DACD6", api.Sign(params, key, api.SignTypeHMACSHA256))
+	assert.Equal(t, api.Sign(params, key, api.SignTypeMD5), api.GenerateSignature(params, key))
+
+	client := NewClient("appid", key, "mch_id", "", "", nil)
+	assert.True(t, client.VerifySignature(params, "9A0A8659F005D6984697E2CA0A9CF3B7"))
+	assert.NotContains(t, params, "sign")
+
+	params["sign_type"] = string(api.SignTypeHMACSHA256)
+	assert.False(t, client.VerifySignature(params, "9A0A8659F005D6984697E2CA0A9CF3B7"))
+}
+
+func TestNonceStr(t *testing.T) {
+	a, b := api.NonceStr(), api.NonceStr()
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
+
+func TestClient_RequestSignature(t *testing.T) {
+	httpClient := &xmlHTTPClient{}
+	client := NewClient("appid", "api_key", "mch_id", "", "", httpClient)
+	client.SignType = api.SignTypeHMACSHA256
+
+	httpClient.response = func(request map[string]string) map[string]string {
+		response := map[string]string{
+			"return_code": "SUCCESS",
+			"result_code": "SUCCESS",
+			"appid":       "appid",
+			"mch_id":      "mch_id",
+			"nonce_str":   "5K8264ILTKCH16CQ2502SI8ZNMTM67VS",
+			"prepay_id":   "wx201410272009395522657a690389285100",
+			"trade_type":  "JSAPI",
+		}
+		response["sign"] = api.Sign(response, "api_key", api.SignType(request["sign_type"]))
+		return response
+	}
+
+	result, err := client.Order.GetPrepayID(&api.PrepayRequest{
+		AppID:      "appid",
+		MchID:      "mch_id",
+		Body:       "test",
+		OutTradeNo: "order_001",
+		TotalFee:   1,
+		TradeType:  "JSAPI",
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "https://api.mch.weixin.qq.com/pay/unifiedorder", httpClient.url)
+	assert.Equal(t, "HMAC-SHA256", httpClient.request["sign_type"])
+	assert.Len(t, httpClient.request["nonce_str"], 32)
+	assert.True(t, api.CheckSignature(httpClient.request, "api_key", ""))
+
+	// 单个请求可以覆盖默认签名类型
+	_, err = client.Order.CloseOrder(&api.CloseOrderRequest{OutTradeNo: "order_001", SignType: api.SignTypeMD5})
+	assert.NoError(t, err)
+	assert.Equal(t, "MD5", httpClient.request["sign_type"])
+
+	// 红包接口仅支持 MD5，且不能携带 sign_type
+	_, err = client.RedPack.QueryRedPack(&api.QueryRedPackRequest{MchBillNo: "bill"})
+	assert.NoError(t, err)
+	assert.NotContains(t, httpClient.request, "sign_type")
+	assert.True(t, api.CheckSignature(httpClient.request, "api_key", api.SignTypeMD5))
+
+	// 响应签名无效
+	httpClient.response = func(request map[string]string) map[string]string {
+		return map[string]string{"return_code": "SUCCESS", "result_code": "SUCCESS", "sign": "INVALID"}
+	}
+	_, err = client.Order.QueryOrder(&api.QueryOrderRequest{OutTradeNo: "order_001"})
+	var sigErr *wechatgo.InvalidSignatureError
+	assert.ErrorAs(t, err, &sigErr)
+
+	// 成功响应缺少签名
+	httpClient.response = func(request map[string]string) map[string]string {
+		return map[string]string{"return_code": "SUCCESS", "result_code": "SUCCESS", "trade_state": "SUCCESS"}
+	}
+	_, err = client.Order.QueryOrder(&api.QueryOrderRequest{OutTradeNo: "order_001"})
+	assert.ErrorAs(t, err, &sigErr)
+
+	// 业务错误的响应同样带签名
+	httpClient.response = func(request map[string]string) map[string]string {
+		return signResponse(request, map[string]string{"return_code": "SUCCESS", "result_code": "FAIL", "err_code": "ORDERPAID", "err_code_des": "订单已支付"})
+	}
+	_, err = client.Order.CloseOrder(&api.CloseOrderRequest{OutTradeNo: "order_001"})
+	assert.ErrorIs(t, err, api.ErrOrderPaid)
+	var payErr *wechatgo.PayError
+	if assert.ErrorAs(t, err, &payErr) {
+		assert.Equal(t, "/pay/closeorder", payErr.Endpoint)
+		assert.Equal(t, "req_001", payErr.RequestID)
+		assert.Contains(t, string(payErr.RawBody), "ORDERPAID")
+		assert.NotNil(t, payErr.Response)
+	}
+
+	// 通信失败的响应不带签名
+	httpClient.response = func(request map[string]string) map[string]string {
+		return map[string]string{"return_code": "FAIL", "return_msg": "签名错误"}
+	}
+	_, err = client.Order.CloseOrder(&api.CloseOrderRequest{OutTradeNo: "order_001"})
+	assert.ErrorContains(t, err, "签名错误")
+	assert.False(t, errors.As(err, &sigErr))
+}
+
+func TestClient_ParsePaymentResult(t *testing.T) {
+	client := NewClient("appid", "api_key", "mch_id", "", "", nil)
+
+	notify := map[string]string{
+		"return_code":    "SUCCESS",
+		"result_code":    "SUCCESS",
+		"appid":          "appid",
+		"mch_id":         "mch_id",
+		"nonce_str":      "nonce",
+		"sign_type":      "HMAC-SHA256",
+		"out_trade_no":   "order_001",
+		"transaction_id": "1004400740201409030005092168",
+		"total_fee":      "1",
+	}
+	notify["sign"] = api.Sign(notify, "api_key", api.SignTypeHMACSHA256)
+
+	result, err := client.ParsePaymentResult(api.EncodeXML(notify))
+	assert.NoError(t, err)
+	assert.Equal(t, "order_001", result["out_trade_no"])
+
+	notify["total_fee"] = "100"
+	_, err = client.ParsePaymentResult(api.EncodeXML(notify))
+	var sigErr *wechatgo.InvalidSignatureError
+	assert.ErrorAs(t, err, &sigErr)
+
+	// 签名有效的失败通知同时返回参数和业务错误
+	notify["result_code"] = "FAIL"
+	notify["err_code"] = "NOTENOUGH"
+	notify["err_code_des"] = "余额不足"
+	notify["sign"] = api.Sign(notify, "api_key", api.SignTypeHMACSHA256)
+	result, err = client.ParsePaymentResult(api.EncodeXML(notify))
+	var payErr *wechatgo.PayError
+	assert.ErrorAs(t, err, &payErr)
+	assert.Equal(t, "NOTENOUGH", payErr.Code)
+	assert.Equal(t, "order_001", result["out_trade_no"])
+
+	// 篡改的失败通知先报告签名错误
+	notify["out_trade_no"] = "order_002"
+	result, err = client.ParsePaymentResult(api.EncodeXML(notify))
+	assert.ErrorAs(t, err, &sigErr)
+	assert.Nil(t, result)
+}
+
+func TestClient_GenerateJSAPIPayParams(t *testing.T) {
+	client := NewClient("appid", "api_key", "mch_id", "", "", nil)
+
+	params, err := client.GenerateJSAPIPayParams("wx2017033010242291fcfe0db70013231072")
+	assert.NoError(t, err)
+	assert.Equal(t, "MD5", params["signType"])
+	assert.Equal(t, "prepay_id=wx2017033010242291fcfe0db70013231072", params["package"])
+
+	paySign := params["paySign"]
+	delete(params, "paySign")
+	assert.Equal(t, api.Sign(params, "api_key", api.SignTypeMD5), paySign)
+}
+
+func TestParams(t *testing.T) {
+	params, err := api.MarshalParams(&api.RefundRequest{
+		OutTradeNo:  "order_001",
+		OutRefundNo: "refund_001",
+		TotalFee:    100,
+		RefundFee:   0,
+		RefundDesc:  "<退款>&]]>",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"out_trade_no":  "order_001",
+		"out_refund_no": "refund_001",
+		"total_fee":     "100",
+		"refund_fee":    "0",
+		"refund_desc":   "<退款>&]]>",
+	}, params)
+
+	data := api.EncodeXML(params)
+	assert.Contains(t, string(data), "<total_fee><![CDATA[100]]></total_fee>")
+	decoded, err := api.DecodeXML(data)
+	assert.NoError(t, err)
+	assert.Equal(t, params, decoded)
+
+	params, err = api.MarshalParams(api.QueryOrderResponse{
+		CouponCount: 2,
+		Coupons: []api.OrderCoupon{
+			{CouponID: "c0", CouponFee: 10},
+			{CouponID: "c1", CouponType: "CASH", CouponFee: 20},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", params["coupon_id_1"])
+	assert.Equal(t, "20", params["coupon_fee_1"])
+	assert.NotContains(t, params, "coupon_type_0")
+
+	var resp api.QueryOrderResponse
+	assert.NoError(t, api.UnmarshalParams(map[string]string{
+		"return_code":  "SUCCESS",
+		"device_info":  "WEB",
+		"total_fee":    "101",
+		"coupon_count": "2",
+		"coupon_id_0":  "c0",
+		"coupon_fee_0": "10",
+		"coupon_id_1":  "c1",
+		"coupon_fee_1": "20",
+		"coupon_id_3":  "ignored",
+	}, &resp))
+	assert.Equal(t, "SUCCESS", resp.ReturnCode)
+	assert.Equal(t, "WEB", resp.DeviceInfo)
+	assert.Equal(t, 101, resp.TotalFee)
+	assert.Equal(t, []api.OrderCoupon{{CouponID: "c0", CouponFee: 10}, {CouponID: "c1", CouponFee: 20}}, resp.Coupons)
+
+	assert.Error(t, api.UnmarshalParams(map[string]string{"total_fee": "abc"}, &resp))
+	assert.Error(t, api.UnmarshalParams(nil, resp))
+}
+
+func TestClient_QueryRefund(t *testing.T) {
+	httpClient := &xmlHTTPClient{}
+	client := NewClient("appid", "api_key", "mch_id", "", "", httpClient)
+	httpClient.response = func(request map[string]string) map[string]string {
+		response := map[string]string{
+			"return_code":           "SUCCESS",
+			"result_code":           "SUCCESS",
+			"transaction_id":        "4200000001",
+			"total_fee":             "300",
+			"refund_count":          "2",
+			"out_refund_no_0":       "refund_001",
+			"refund_fee_0":          "100",
+			"refund_status_0":       "SUCCESS",
+			"refund_recv_accout_0":  "支付用户的零钱",
+			"out_refund_no_1":       "refund_002",
+			"refund_fee_1":          "200",
+			"refund_status_1":       "PROCESSING",
+			"coupon_refund_count_0": "2",
+			"coupon_type_0_0":       "CASH",
+			"coupon_refund_id_0_0":  "coupon_a",
+			"coupon_refund_fee_0_0": "10",
+			"coupon_type_0_1":       "NO_CASH",
+			"coupon_refund_id_0_1":  "coupon_b",
+			"coupon_refund_fee_0_1": "20",
+		}
+		response["sign"] = api.Sign(response, "api_key", api.SignTypeMD5)
+		return response
+	}
+
+	result, err := client.Refund.QueryRefund(&api.QueryRefundRequest{TransactionID: "4200000001"})
+	assert.NoError(t, err)
+	assert.Equal(t, "appid", httpClient.request["appid"])
+	assert.Equal(t, "mch_id", httpClient.request["mch_id"])
+	assert.Equal(t, "4200000001", httpClient.request["transaction_id"])
+	assert.NotContains(t, httpClient.request, "out_trade_no")
+	assert.NotContains(t, httpClient.request, "offset")
+
+	assert.Equal(t, 300, result.TotalFee)
+	assert.Equal(t, 2, result.RefundCount)
+	if assert.Len(t, result.Refunds, 2) {
+		assert.Equal(t, "refund_001", result.Refunds[0].OutRefundNo)
+		assert.Equal(t, "支付用户的零钱", result.Refunds[0].RefundRecvAccount)
+		assert.Equal(t, 200, result.Refunds[1].RefundFee)
+		assert.Equal(t, "PROCESSING", result.Refunds[1].RefundStatus)
+		assert.Equal(t, []api.RefundDetailCoupon{
+			{CouponType: "CASH", CouponRefundID: "coupon_a", CouponRefundFee: 10},
+			{CouponType: "NO_CASH", CouponRefundID: "coupon_b", CouponRefundFee: 20},
+		}, result.Refunds[0].Coupons)
+		assert.Empty(t, result.Refunds[1].Coupons)
+	}
+
+	// 嵌套下标字段编码后与解码前一致
+	params, err := api.MarshalParams(&api.QueryRefundResponse{Refunds: result.Refunds})
+	assert.NoError(t, err)
+	assert.Equal(t, "coupon_b", params["coupon_refund_id_0_1"])
+	assert.Equal(t, "20", params["coupon_refund_fee_0_1"])
+	assert.NotContains(t, params, "coupon_type_1_0")
+}
+
+func TestClient_Transfer(t *testing.T) {
+	httpClient := &xmlHTTPClient{}
+	client := NewClient("appid", "api_key", "mch_id", "", "", httpClient)
+	httpClient.response = func(request map[string]string) map[string]string {
+		return map[string]string{
+			"return_code":      "SUCCESS",
+			"result_code":      "SUCCESS",
+			"partner_trade_no": request["partner_trade_no"],
+			"payment_no":       "1000018301201505190181489473",
+		}
+	}
+
+	result, err := client.Transfer.Transfer(&api.TransferRequest{
+		PartnerTradeNo: "trade_001",
+		OpenID:         "openid",
+		Amount:         100,
+		Desc:           "test",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/transfers", httpClient.url)
+	assert.Equal(t, "appid", httpClient.request["mch_appid"])
+	assert.Equal(t, "mch_id", httpClient.request["mchid"])
+	assert.Equal(t, api.CheckNameNo, httpClient.request["check_name"])
+	assert.Equal(t, "trade_001", result.PartnerTradeNo)
+	assert.Equal(t, "1000018301201505190181489473", result.PaymentNo)
+}
+
+// writeCert 生成自签名证书，将 PEM 格式的证书和私钥写入 dir
+func writeCert(t *testing.T, dir, commonName string) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	certPath := filepath.Join(dir, "apiclient_cert.pem")
+	keyPath := filepath.Join(dir, "apiclient_key.pem")
+	assert.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	return certPath, keyPath
+}
+
+func TestCertHTTPClient(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certPath, keyPath := writeCert(t, dir, "first")
+
+	client, err := api.NewCertHTTPClient(certPath, keyPath, "mch_id")
+	assert.NoError(t, err)
+	client.TLSConfig().RootCAs = x509.NewCertPool()
+	client.TLSConfig().RootCAs.AddCert(server.Certificate())
+
+	commonName := func() string {
+		resp, err := client.Get(server.URL)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	assert.Equal(t, "first", commonName())
+
+	// 替换证书文件后主动重新加载
+	writeCert(t, dir, "second")
+	assert.NoError(t, client.Reload())
+	assert.Equal(t, "second", commonName())
+
+	// 替换证书文件后在下次握手时自动加载
+	writeCert(t, dir, "third")
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certPath, future, future))
+	server.CloseClientConnections()
+	assert.Equal(t, "third", commonName())
+
+	// 加载失败时保留原证书
+	assert.NoError(t, os.WriteFile(keyPath, []byte("invalid"), 0o600))
+	assert.Error(t, client.Reload())
+	server.CloseClientConnections()
+	assert.Equal(t, "third", commonName())
+
+	_, err = api.NewCertHTTPClient(filepath.Join(dir, "missing.pem"), keyPath, "mch_id")
+	assert.Error(t, err)
+}
+
+func TestCertHTTPClient_PKCS12(t *testing.T) {
+	_, err := api.NewCertHTTPClient("testdata/apiclient_cert.p12", "", "1900000109")
+	assert.NoError(t, err)
+
+	_, err = api.NewCertHTTPClient("testdata/apiclient_cert.p12", "", "wrong")
+	assert.Error(t, err)
+}
+
+func TestCertHTTPClient_Options(t *testing.T) {
+	certPath, keyPath := writeCert(t, t.TempDir(), "mch_id")
+
+	// 默认使用 http.DefaultTransport 的副本，不会修改 http.DefaultTransport
+	client, err := api.NewCertHTTPClient(certPath, keyPath, "mch_id")
+	assert.NoError(t, err)
+	assert.NotNil(t, client.TLSConfig().GetClientCertificate)
+	if defaultTLS := http.DefaultTransport.(*http.Transport).TLSClientConfig; defaultTLS != nil {
+		assert.NotSame(t, defaultTLS, client.TLSConfig())
+		assert.Nil(t, defaultTLS.GetClientCertificate)
+	}
+
+	// 基础 Transport 被复制，其 TLS 配置被保留且不会被修改
+	roots := x509.NewCertPool()
+	transport := &http.Transport{MaxIdleConns: 7, TLSClientConfig: &tls.Config{RootCAs: roots}}
+	client, err = api.NewCertHTTPClient(certPath, keyPath, "mch_id", api.WithBaseHTTPClient(&http.Client{Timeout: time.Second, Transport: transport}))
+	assert.NoError(t, err)
+	assert.Same(t, roots, client.TLSConfig().RootCAs)
+	assert.NotNil(t, client.TLSConfig().GetClientCertificate)
+	assert.Nil(t, transport.TLSClientConfig.GetClientCertificate)
+	assert.NotSame(t, transport.TLSClientConfig, client.TLSConfig())
+
+	// WithBaseTransport 优先于基础客户端中的 Transport
+	other := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "example.com"}}
+	client, err = api.NewCertHTTPClient(certPath, keyPath, "mch_id", api.WithBaseHTTPClient(&http.Client{Transport: transport}), api.WithBaseTransport(other))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", client.TLSConfig().ServerName)
+
+	// 无法注入证书的 Transport
+	_, err = api.NewCertHTTPClient(certPath, keyPath, "mch_id", api.WithBaseHTTPClient(&http.Client{Transport: http.NewFileTransport(http.Dir("."))}))
+	assert.ErrorContains(t, err, "unsupported transport")
+}
+
+func TestClient_CertHTTPClient(t *testing.T) {
+	httpClient := &xmlHTTPClient{}
+
+	// 未配置证书路径时使用传入的HTTP客户端
+	client := NewClient("appid", "api_key", "mch_id", "", "", httpClient)
+	certClient, err := client.GetCertHTTPClient()
+	assert.NoError(t, err)
+	assert.Equal(t, httpClient, certClient)
+	assert.NoError(t, client.ReloadCert())
+
+	// 需要证书的接口在证书加载失败时不会发出请求
+	client = NewClient("appid", "api_key", "mch_id", "missing.pem", "missing.key", httpClient)
+	_, err = client.Refund.Refund(&api.RefundRequest{OutTradeNo: "order_001", OutRefundNo: "refund_001", TotalFee: 1, RefundFee: 1})
+	assert.ErrorContains(t, err, "certificate")
+	assert.Empty(t, httpClient.url)
+	assert.Error(t, client.ReloadCert())
+
+	// 普通接口不使用证书
+	httpClient.response = func(request map[string]string) map[string]string {
+		return signResponse(request, map[string]string{"return_code": "SUCCESS", "result_code": "SUCCESS"})
+	}
+	_, err = client.Order.CloseOrder(&api.CloseOrderRequest{OutTradeNo: "order_001"})
+	assert.NoError(t, err)
+
+	certPath, keyPath := writeCert(t, t.TempDir(), "mch_id")
+	client = NewClient("appid", "api_key", "mch_id", certPath, keyPath, httpClient)
+	certClient, err = client.GetCertHTTPClient()
+	assert.NoError(t, err)
+	assert.IsType(t, &api.CertHTTPClient{}, certClient)
+	again, err := client.GetCertHTTPClient()
+	assert.NoError(t, err)
+	assert.Same(t, certClient, again)
+	assert.NoError(t, client.ReloadCert())
+}
@@ -1,12 +1,12 @@
 package wechatgo
 
 import (
+	"crypto/rand"
 	"crypto/sha1"
 	"encoding/hex"
-	"math/rand"
+	"math/big"
 	"sort"
 	"strings"
-	"time"
 )
 
 // Signer 微信数据签名器
@@ -57,13 +57,17 @@ func CheckWxaSignature(sessionKey, rawData, clientSignature string) error {
 	return nil
 }
 
-// RandomString 生成随机字符串
+// RandomString 使用 crypto/rand 生成由字母和数字组成的随机字符串，可用作签名的 nonceStr
 func RandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	seededRand := rand.New(rand.NewSource(time.Now().UnixNano()))
+	max := big.NewInt(int64(len(charset)))
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic("wechatgo: crypto/rand unavailable: " + err.Error())
+		}
+		b[i] = charset[n.Int64()]
 	}
 	return string(b)
 }
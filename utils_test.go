@@ -0,0 +1,18 @@
+package wechatgo
+
+import "testing"
+
+func TestRandomString(t *testing.T) {
+	a, b := RandomString(16), RandomString(16)
+	if len(a) != 16 || len(b) != 16 {
+		t.Fatalf("Expected length 16, got %d and %d", len(a), len(b))
+	}
+	if a == b {
+		t.Fatalf("Expected different strings, got %q twice", a)
+	}
+	for _, c := range a {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			t.Fatalf("Unexpected character %q in %q", c, a)
+		}
+	}
+}
@@ -1,11 +1,7 @@
 package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"time"
-
+	"github.com/wechatpy/wechatgo"
 	"github.com/wechatpy/wechatgo/client"
 	"github.com/wechatpy/wechatgo/session"
 )
@@ -27,15 +23,16 @@ type WorkClient struct {
 }
 
 // NewWorkClient 创建企业微信客户端
-func NewWorkClient(corpID, corpSecret string, storage session.Storage) *WorkClient {
-	baseClient := client.NewBaseClient(corpID, storage, APIBaseURL)
-
+func NewWorkClient(corpID, corpSecret string, storage session.Storage, opts ...client.ClientOption) *WorkClient {
 	c := &WorkClient{
-		BaseClient: baseClient,
-		AppID:      corpID,
-		Secret:     corpSecret,
+		AppID:  corpID,
+		Secret: corpSecret,
 	}
 
+	// 默认通过凭证向微信获取 token，可被 WithTokenSource 覆盖
+	opts = append([]client.ClientOption{client.WithTokenSource(client.TokenSourceFunc(c.requestAccessToken))}, opts...)
+	c.BaseClient = client.NewBaseClient(c.AppID, storage, APIBaseURL, opts...)
+
 	// 初始化 API 模块
 	c.User = NewUserAPI(c)
 	c.Dept = NewDeptAPI(c)
@@ -56,29 +53,34 @@ const TokenURL = "https://qyapi.weixin.qq.com/cgi-bin/gettoken"
 
 // 错误定义
 var (
-	ErrAccessTokenNotFound    = fmt.Errorf("access_token not found in response")
-	ErrAccessTokenInvalidType = fmt.Errorf("access_token is not a string")
+	ErrAccessTokenNotFound    = wechatgo.ErrAccessTokenNotFound
+	ErrAccessTokenInvalidType = wechatgo.ErrAccessTokenInvalidType
 )
 
 // FetchAccessToken 获取 access token
 func (c *WorkClient) FetchAccessToken() error {
+	return c.RefreshAccessToken()
+}
+
+// requestAccessToken 使用凭证向微信请求 access token
+func (c *WorkClient) requestAccessToken() (string, int, error) {
 	params := map[string]string{
 		"corpid":     c.AppID,
 		"corpsecret": c.Secret,
 	}
 
-	result, err := c.fetchToken(TokenURL, params)
+	result, err := c.FetchToken(c.TokenURL(TokenURL, "/gettoken"), params)
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 
 	accessTokenIf, ok := result["access_token"]
 	if !ok {
-		return ErrAccessTokenNotFound
+		return "", 0, ErrAccessTokenNotFound
 	}
 	accessToken, ok := accessTokenIf.(string)
 	if !ok {
-		return ErrAccessTokenInvalidType
+		return "", 0, ErrAccessTokenInvalidType
 	}
 
 	expiresIn := 7200
@@ -86,71 +88,10 @@ func (c *WorkClient) FetchAccessToken() error {
 		expiresIn = int(exp)
 	}
 
-	return c.SetAccessToken(accessToken, expiresIn)
+	return accessToken, expiresIn, nil
 }
 
 // accessTokenKey 获取 access token 存储键
 func (c *WorkClient) accessTokenKey() string {
 	return c.AppID + "_" + c.Secret[:10] + "_access_token"
 }
-
-// GetAccessToken 获取 access token（重写以支持自动刷新）
-func (c *WorkClient) GetAccessToken() (string, error) {
-	token, err := c.BaseClient.GetAccessToken()
-	if err != nil {
-		// Token 不存在或已过期，刷新
-		if err := c.FetchAccessToken(); err != nil {
-			return "", err
-		}
-		return c.BaseClient.GetAccessToken()
-	}
-	return token, nil
-}
-
-// fetchToken 获取 token（内部方法，不自动添加 access_token 参数）
-func (c *WorkClient) fetchToken(url string, params map[string]string) (map[string]interface{}, error) {
-	// 构建 URL 参数
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	q := req.URL.Query()
-	for k, v := range params {
-		q.Add(k, v)
-	}
-	req.URL.RawQuery = q.Encode()
-
-	// 创建新的HTTP客户端
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if resp != nil && resp.Body != nil {
-			resp.Body.Close()
-		}
-	}()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	// 检查错误
-	if errcode, ok := result["errcode"]; ok {
-		errcodeInt := int(errcode.(float64))
-		if errcodeInt != 0 {
-			errmsg := ""
-			if msg, ok := result["errmsg"]; ok {
-				errmsg = msg.(string)
-			}
-			return nil, fmt.Errorf("fetch token error: %d - %s", errcodeInt, errmsg)
-		}
-	}
-
-	return result, nil
-}
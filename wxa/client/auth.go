@@ -0,0 +1,135 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/wechatpy/wechatgo/client/api"
+)
+
+// AuthAPI 小程序登录与用户信息 API
+type AuthAPI struct {
+	*api.BaseAPI
+	client *WxaClient
+}
+
+// NewAuthAPI 创建登录 API
+func NewAuthAPI(client *WxaClient) *AuthAPI {
+	return &AuthAPI{
+		BaseAPI: api.NewBaseAPI(client),
+		client:  client,
+	}
+}
+
+// Session 登录凭证校验结果
+type Session struct {
+	OpenID     string `json:"openid"`
+	SessionKey string `json:"session_key"`
+	UnionID    string `json:"unionid,omitempty"`
+}
+
+// PhoneInfo 用户手机号信息
+type PhoneInfo struct {
+	PhoneNumber     string    `json:"phoneNumber"`     // 用户绑定的手机号（国外手机号会有区号）
+	PurePhoneNumber string    `json:"purePhoneNumber"` // 没有区号的手机号
+	CountryCode     string    `json:"countryCode"`     // 区号
+	Watermark       Watermark `json:"watermark"`
+}
+
+// UserInfo 解密后的用户信息
+type UserInfo struct {
+	OpenID    string    `json:"openId"`
+	NickName  string    `json:"nickName"`
+	Gender    int       `json:"gender"`
+	City      string    `json:"city"`
+	Province  string    `json:"province"`
+	Country   string    `json:"country"`
+	AvatarURL string    `json:"avatarUrl"`
+	UnionID   string    `json:"unionId,omitempty"`
+	Watermark Watermark `json:"watermark"`
+}
+
+// Code2Session 登录凭证校验
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/user-login/code2Session.html
+func (api *AuthAPI) Code2Session(jsCode string) (*Session, error) {
+	params := map[string]string{
+		"appid":      api.client.AppID,
+		"secret":     api.client.Secret,
+		"js_code":    jsCode,
+		"grant_type": "authorization_code",
+	}
+
+	// jscode2session 不使用 access_token
+	result, err := api.client.FetchToken(api.client.BuildURL("/sns/jscode2session"), params)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionKey, _ := result["session_key"].(string)
+	if sessionKey == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	session := &Session{SessionKey: sessionKey}
+	session.OpenID, _ = result["openid"].(string)
+	session.UnionID, _ = result["unionid"].(string)
+	return session, nil
+}
+
+// CheckSession 检验登录态，session_key 失效时返回错误
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/user-login/checkSessionKey.html
+func (api *AuthAPI) CheckSession(openID, sessionKey string) error {
+	mac := hmac.New(sha256.New, []byte(sessionKey))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	_, err := api.Get("/wxa/checksession", map[string]string{
+		"openid":     openID,
+		"signature":  signature,
+		"sig_method": "hmac_sha256",
+	})
+	return err
+}
+
+// GetPhoneNumber 通过手机号快速验证组件返回的 code 获取用户手机号
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/user-info/phone-number/getPhoneNumber.html
+func (api *AuthAPI) GetPhoneNumber(code string) (*PhoneInfo, error) {
+	result, err := api.Post("/wxa/business/getuserphonenumber", map[string]string{"code": code})
+	if err != nil {
+		return nil, err
+	}
+
+	phoneInfo, ok := result["phone_info"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	info := &PhoneInfo{}
+	info.PhoneNumber, _ = phoneInfo["phoneNumber"].(string)
+	info.PurePhoneNumber, _ = phoneInfo["purePhoneNumber"].(string)
+	info.CountryCode, _ = phoneInfo["countryCode"].(string)
+	if watermark, ok := phoneInfo["watermark"].(map[string]interface{}); ok {
+		info.Watermark.AppID, _ = watermark["appid"].(string)
+		if ts, ok := watermark["timestamp"].(float64); ok {
+			info.Watermark.Timestamp = int64(ts)
+		}
+	}
+	return info, nil
+}
+
+// DecryptUserInfo 解密 wx.getUserInfo 返回的用户信息
+func (api *AuthAPI) DecryptUserInfo(sessionKey, encryptedData, iv string) (*UserInfo, error) {
+	var info UserInfo
+	if err := api.client.DecryptData(sessionKey, encryptedData, iv, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// DecryptPhoneNumber 解密旧版 getPhoneNumber 返回的手机号
+func (api *AuthAPI) DecryptPhoneNumber(sessionKey, encryptedData, iv string) (*PhoneInfo, error) {
+	var info PhoneInfo
+	if err := api.client.DecryptData(sessionKey, encryptedData, iv, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
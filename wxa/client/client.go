@@ -0,0 +1,97 @@
+// Package client 实现微信小程序服务端 API
+package client
+
+import (
+	"time"
+
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+// APIBaseURL 小程序 API 基础 URL
+const APIBaseURL = "https://api.weixin.qq.com/"
+
+// TokenURL 获取 access token 的 URL
+const TokenURL = "https://api.weixin.qq.com/cgi-bin/token"
+
+// DefaultWatermarkMaxAge 解密数据水印的默认最大有效期
+const DefaultWatermarkMaxAge = 10 * time.Minute
+
+// 错误定义
+var (
+	ErrAccessTokenNotFound    = wechatgo.ErrAccessTokenNotFound
+	ErrAccessTokenInvalidType = wechatgo.ErrAccessTokenInvalidType
+)
+
+// WxaClient 微信小程序客户端
+type WxaClient struct {
+	*client.BaseClient
+	Secret string
+	// WatermarkMaxAge 解密数据水印时间戳的最大有效期，为 0 时不校验时间戳
+	WatermarkMaxAge time.Duration
+
+	// API 模块
+	Auth *AuthAPI `json:"-"` // 登录与用户信息
+	Code *CodeAPI `json:"-"` // 小程序码
+	Link *LinkAPI `json:"-"` // URL Scheme/URL Link/Short Link
+}
+
+// NewWxaClient 创建小程序客户端
+func NewWxaClient(appID, secret string, storage session.Storage, opts ...client.ClientOption) *WxaClient {
+	c := &WxaClient{
+		Secret:          secret,
+		WatermarkMaxAge: DefaultWatermarkMaxAge,
+	}
+
+	// 默认通过凭证向微信获取 token，可被 WithTokenSource 覆盖
+	opts = append([]client.ClientOption{client.WithTokenSource(client.TokenSourceFunc(c.requestAccessToken))}, opts...)
+	c.BaseClient = client.NewBaseClient(appID, storage, APIBaseURL, opts...)
+
+	// 初始化 API 模块
+	c.Auth = NewAuthAPI(c)
+	c.Code = NewCodeAPI(c)
+	c.Link = NewLinkAPI(c)
+
+	return c
+}
+
+// FetchAccessToken 获取 access token
+func (c *WxaClient) FetchAccessToken() error {
+	return c.RefreshAccessToken()
+}
+
+// requestAccessToken 使用凭证向微信请求 access token
+func (c *WxaClient) requestAccessToken() (string, int, error) {
+	params := map[string]string{
+		"grant_type": "client_credential",
+		"appid":      c.AppID,
+		"secret":     c.Secret,
+	}
+
+	result, err := c.FetchToken(c.TokenURL(TokenURL, "/cgi-bin/token"), params)
+	if err != nil {
+		return "", 0, err
+	}
+
+	accessTokenIf, ok := result["access_token"]
+	if !ok {
+		return "", 0, ErrAccessTokenNotFound
+	}
+	accessToken, ok := accessTokenIf.(string)
+	if !ok {
+		return "", 0, ErrAccessTokenInvalidType
+	}
+
+	expiresIn := 7200
+	if exp, ok := result["expires_in"].(float64); ok {
+		expiresIn = int(exp)
+	}
+
+	return accessToken, expiresIn, nil
+}
+
+// DecryptData 解密 wx.getUserInfo 等接口返回的 encryptedData 到 v，并校验水印
+func (c *WxaClient) DecryptData(sessionKey, encryptedData, iv string, v interface{}) error {
+	return DecryptData(c.AppID, sessionKey, encryptedData, iv, c.WatermarkMaxAge, v)
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wechatpy/wechatgo"
+	"github.com/wechatpy/wechatgo/client"
+	"github.com/wechatpy/wechatgo/session"
+)
+
+// 官方文档中的解密示例数据
+const (
+	testAppID         = "wx4f4bc4dec97d474b"
+	testSessionKey    = "tiihtNczf5v6AKRyjwEUhQ=="
+	testIV            = "r7BXXKkLb8qrSNn05n0qiA=="
+	testEncryptedData = "CiyLU1Aw2KjvrjMdj8YKliAjtP4gsMZMQmRzooG2xrDcvSnxIMXFufNstNGTyaGS9uT5geRa0W4oTOb1WT7fJlAC+oNPdbB+3hVbJSRgv+4lGOETKUQz6OYStslQ142dNCuabNPGBzlooOmB231qMM85d2/fV6ChevvXvQP8Hkue1poOFtnEtpyxVLW1zAo6/1Xx1COxFvrc2d7UL/lmHInNlxuacJXwu0fjpXfz/YqYzBIBzD6WUfTIF9GRHpOn/Hz7saL8xz+W//FRAUid1OksQaQx4CMs8LOddcQhULW4ucetDf96JcR3g0gfRK4PC7E/r7Z6xNrXd2UIeorGj5Ef7b1pJAYB6Y5anaHqZ9J6nKEBvB4DnNLIVWSgARns/8wR2SiRS7MNACwTyrGvt9ts8p12PKFdlqYTopNHR1Vf7XjfhQlVsAJdNiKdYmYVoKlaRv85IfVunYzO0IKXsyl7JCUjCpoG20f0a04COwfneQAGGwd5oa+T8yO5hzuyDb/XcxxmK01EpqOyuxINew=="
+)
+
+func TestDecryptUserInfo(t *testing.T) {
+	c := NewWxaClient(testAppID, "secret", nil)
+	c.WatermarkMaxAge = 0
+
+	info, err := c.Auth.DecryptUserInfo(testSessionKey, testEncryptedData, testIV)
+	assert.NoError(t, err)
+	assert.Equal(t, "oGZUI0egBJY1zhBYw2KhdUfwVJJE", info.OpenID)
+	assert.Equal(t, "Band", info.NickName)
+	assert.Equal(t, "ocMvos6NjeKLIBqg5Mr9QjxrP1FA", info.UnionID)
+	assert.Equal(t, testAppID, info.Watermark.AppID)
+}
+
+func TestDecryptData_InvalidWatermark(t *testing.T) {
+	// 水印时间戳已过期
+	err := DecryptData(testAppID, testSessionKey, testEncryptedData, testIV, DefaultWatermarkMaxAge, nil)
+	assert.ErrorIs(t, err, ErrInvalidWatermark)
+
+	// appid 不匹配
+	err = DecryptData("wx_other", testSessionKey, testEncryptedData, testIV, 0, nil)
+	assert.ErrorIs(t, err, ErrInvalidWatermark)
+
+	_, err = Decrypt(testSessionKey, "aW52YWxpZA==", testIV)
+	assert.Error(t, err)
+}
+
+func TestCode2Session(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/sns/jscode2session":
+			assert.Empty(t, q.Get("access_token"))
+			assert.Equal(t, "JSCODE", q.Get("js_code"))
+			w.Write([]byte(`{"openid":"OPENID","session_key":"SESSIONKEY","unionid":"UNIONID"}`))
+		case "/wxa/checksession":
+			mac := hmac.New(sha256.New, []byte("SESSIONKEY"))
+			assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), q.Get("signature"))
+			w.Write([]byte(`{"errcode":87009,"errmsg":"invalid signature"}`))
+		case "/wxa/business/getuserphonenumber":
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","phone_info":{"phoneNumber":"+8613800000000","purePhoneNumber":"13800000000","countryCode":"86","watermark":{"timestamp":1637744274,"appid":"wx123"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewWxaClient("wx123", "secret", nil, client.WithBaseURL(server.URL))
+	c.SetAccessToken("token", 7200)
+
+	session, err := c.Auth.Code2Session("JSCODE")
+	assert.NoError(t, err)
+	assert.Equal(t, "OPENID", session.OpenID)
+	assert.Equal(t, "SESSIONKEY", session.SessionKey)
+
+	err = c.Auth.CheckSession(session.OpenID, session.SessionKey)
+	var clientErr *wechatgo.ClientError
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, 87009, clientErr.ErrCode)
+
+	phone, err := c.Auth.GetPhoneNumber("CODE")
+	assert.NoError(t, err)
+	assert.Equal(t, "13800000000", phone.PurePhoneNumber)
+	assert.Equal(t, "wx123", phone.Watermark.AppID)
+}
+
+func TestGetUnlimited_Cache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wxa/getwxacodeunlimit", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("access_token"))
+		var req UnlimitedCodeRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		atomic.AddInt32(&calls, 1)
+
+		if req.Scene == "bad" {
+			w.Header().Set("Content-Type", "application/json; encoding=utf-8")
+			w.Write([]byte(`{"errcode":41030,"errmsg":"invalid page"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("image:" + req.Scene))
+	}))
+	defer server.Close()
+
+	c := NewWxaClient("wx123", "secret", session.NewMemoryStorage(), client.WithBaseURL(server.URL))
+	c.SetAccessToken("token", 7200)
+
+	for i := 0; i < 2; i++ {
+		image, err := c.Code.GetUnlimited(context.Background(), &UnlimitedCodeRequest{Scene: "a=1"}, WithCache(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, "image:a=1", string(image))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	image, err := c.Code.GetUnlimited(context.Background(), &UnlimitedCodeRequest{Scene: "b=2"}, WithCache(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "image:b=2", string(image))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, err = c.Code.GetUnlimited(context.Background(), &UnlimitedCodeRequest{Scene: "bad"})
+	var clientErr *wechatgo.ClientError
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, 41030, clientErr.ErrCode)
+}
+
+type failingSetStorage struct {
+	session.Storage
+}
+
+func (s failingSetStorage) Set(key, value string, ttl time.Duration) error {
+	if strings.Contains(key, "_wxacode") {
+		return errors.New("storage unavailable")
+	}
+	return s.Storage.Set(key, value, ttl)
+}
+
+func TestGetUnlimited_CacheByRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req UnlimitedCodeRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "image/jpeg")
+		fmt.Fprintf(w, "image_%d_%s", req.Width, req.EnvVersion)
+	}))
+	defer server.Close()
+
+	storage := session.NewMemoryStorage()
+	c := NewWxaClient("wx123", "secret", storage, client.WithBaseURL(server.URL))
+	c.SetAccessToken("token", 7200)
+
+	get := func(req *UnlimitedCodeRequest) string {
+		image, err := c.Code.GetUnlimited(context.Background(), req, WithCache(time.Hour))
+		assert.NoError(t, err)
+		return string(image)
+	}
+
+	// scene 相同但尺寸或版本不同时不会命中彼此的缓存
+	assert.Equal(t, "image_280_", get(&UnlimitedCodeRequest{Scene: "a=1", Width: 280}))
+	assert.Equal(t, "image_430_", get(&UnlimitedCodeRequest{Scene: "a=1", Width: 430}))
+	assert.Equal(t, "image_280_trial", get(&UnlimitedCodeRequest{Scene: "a=1", Width: 280, EnvVersion: EnvVersionTrial}))
+	assert.Equal(t, "image_280_", get(&UnlimitedCodeRequest{Scene: "a=1", Width: 280}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// 共用存储的客户端使用不同前缀时各自缓存
+	other := NewWxaClient("wx123", "secret", storage, client.WithBaseURL(server.URL), client.WithKeyPrefix("other_"))
+	other.SetAccessToken("token", 7200)
+	_, err := other.Code.GetUnlimited(context.Background(), &UnlimitedCodeRequest{Scene: "a=1", Width: 280}, WithCache(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestGetUnlimited_CacheKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	storage := session.NewMemoryStorage()
+	c := NewWxaClient("wx123", "secret", storage, client.WithBaseURL(server.URL))
+	c.SetAccessToken("token", 7200)
+
+	req := &UnlimitedCodeRequest{Scene: "a=1", Page: "pages/index", Width: 280}
+	_, err := c.Code.GetUnlimited(context.Background(), req, WithCache(time.Hour))
+	assert.NoError(t, err)
+	body, _ := json.Marshal(req)
+	sum := sha256.Sum256(body)
+	cached, err := storage.Get("wx123_wxacode/wxa/getwxacodeunlimit_" + hex.EncodeToString(sum[:]))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cached)
+
+	// 缓存写入失败时仍返回图片
+	c = NewWxaClient("wx123", "secret", failingSetStorage{Storage: session.NewMemoryStorage()}, client.WithBaseURL(server.URL))
+	c.SetAccessToken("token", 7200)
+	image, err := c.Code.GetUnlimited(context.Background(), &UnlimitedCodeRequest{Scene: "a=1"}, WithCache(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "image", string(image))
+}
+
+func TestGenerateScheme(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wxa/generatescheme":
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","openlink":"weixin://dl/business/?t=XTSkBZlzqmn"}`))
+		case "/wxa/queryscheme":
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","scheme_info":{"appid":"wx123","path":"pages/index","query":"a=1","create_time":1611719516,"expire_time":1611892316,"env_version":"release"},"scheme_quota":{"long_time_used":100,"long_time_limit":100000}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewWxaClient("wx123", "secret", nil, client.WithBaseURL(server.URL))
+	c.SetAccessToken("token", 7200)
+
+	openlink, err := c.Link.GenerateScheme(&SchemeRequest{JumpWxa: &JumpWxa{Path: "pages/index", Query: "a=1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "weixin://dl/business/?t=XTSkBZlzqmn", openlink)
+
+	result, err := c.Link.QueryScheme(openlink)
+	assert.NoError(t, err)
+	assert.Equal(t, "pages/index", result.SchemeInfo.Path)
+	assert.Equal(t, 100000, result.SchemeQuota.LongTimeLimit)
+}
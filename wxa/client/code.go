@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wechatpy/wechatgo/client/api"
+	"github.com/wechatpy/wechatgo/logger"
+)
+
+// 小程序版本
+const (
+	EnvVersionRelease = "release" // 正式版
+	EnvVersionTrial   = "trial"   // 体验版
+	EnvVersionDevelop = "develop" // 开发版
+)
+
+// CodeAPI 小程序码与小程序二维码 API
+type CodeAPI struct {
+	*api.BaseAPI
+	client *WxaClient
+}
+
+// NewCodeAPI 创建小程序码 API
+func NewCodeAPI(client *WxaClient) *CodeAPI {
+	return &CodeAPI{
+		BaseAPI: api.NewBaseAPI(client),
+		client:  client,
+	}
+}
+
+// LineColor 小程序码线条颜色
+type LineColor struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// CodeRequest 获取小程序码请求（数量有限）
+type CodeRequest struct {
+	Path       string     `json:"path"`
+	Width      int        `json:"width,omitempty"`
+	AutoColor  bool       `json:"auto_color,omitempty"`
+	LineColor  *LineColor `json:"line_color,omitempty"`
+	IsHyaline  bool       `json:"is_hyaline,omitempty"`
+	EnvVersion string     `json:"env_version,omitempty"`
+}
+
+// UnlimitedCodeRequest 获取不限制的小程序码请求
+type UnlimitedCodeRequest struct {
+	Scene      string     `json:"scene"`
+	Page       string     `json:"page,omitempty"`
+	CheckPath  *bool      `json:"check_path,omitempty"`
+	Width      int        `json:"width,omitempty"`
+	AutoColor  bool       `json:"auto_color,omitempty"`
+	LineColor  *LineColor `json:"line_color,omitempty"`
+	IsHyaline  bool       `json:"is_hyaline,omitempty"`
+	EnvVersion string     `json:"env_version,omitempty"`
+}
+
+// CodeOption 小程序码选项
+type CodeOption func(*codeOptions)
+
+type codeOptions struct {
+	cacheTTL time.Duration
+}
+
+// WithCache 将生成的图片缓存到会话存储中，ttl 内不再请求微信
+// 缓存键由接口和完整的请求参数生成，scene、宽度、颜色、版本等任一参数不同都会分别缓存
+func WithCache(ttl time.Duration) CodeOption {
+	return func(o *codeOptions) {
+		o.cacheTTL = ttl
+	}
+}
+
+// Get 获取小程序码，适用于需要的码数量较少的业务场景
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/qr-code/getQRCode.html
+func (api *CodeAPI) Get(ctx context.Context, req *CodeRequest, opts ...CodeOption) ([]byte, error) {
+	return api.fetchImage(ctx, "/wxa/getwxacode", req, opts)
+}
+
+// GetUnlimited 获取不限制的小程序码，通过 scene 区分业务参数
+// 配合 WithCache 可以按请求参数缓存生成结果
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/qr-code/getUnlimitedQRCode.html
+func (api *CodeAPI) GetUnlimited(ctx context.Context, req *UnlimitedCodeRequest, opts ...CodeOption) ([]byte, error) {
+	return api.fetchImage(ctx, "/wxa/getwxacodeunlimit", req, opts)
+}
+
+// CreateQRCode 获取小程序二维码，适用于需要的码数量较少的业务场景
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/qr-code/createQRCode.html
+func (api *CodeAPI) CreateQRCode(ctx context.Context, path string, width int, opts ...CodeOption) ([]byte, error) {
+	data := map[string]interface{}{
+		"path": path,
+	}
+	if width > 0 {
+		data["width"] = width
+	}
+	return api.fetchImage(ctx, "/cgi-bin/wxaapp/createwxaqrcode", data, opts)
+}
+
+// fetchImage 请求返回图片的接口，出错时接口返回 JSON
+func (api *CodeAPI) fetchImage(ctx context.Context, endpoint string, data interface{}, opts []CodeOption) ([]byte, error) {
+	o := &codeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var cacheKey string
+	if o.cacheTTL > 0 {
+		cacheKey = api.cacheKey(endpoint, body)
+		if cached, err := api.client.Session().Get(cacheKey); err == nil && cached != "" {
+			if image, err := base64.StdEncoding.DecodeString(cached); err == nil {
+				return image, nil
+			}
+		}
+	}
+
+	token, err := api.GetAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{"access_token": token}
+	resp, err := api.client.RawRequest(ctx, http.MethodPost, endpoint, params, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	image, err := readImage(resp)
+	if err != nil {
+		return nil, err
+	}
+	if cacheKey != "" {
+		// 图片已生成，缓存写入失败不影响本次结果
+		if err := api.client.Session().Set(cacheKey, base64.StdEncoding.EncodeToString(image), o.cacheTTL); err != nil {
+			api.client.Logger().Error("缓存小程序码失败", err, logger.String("key", cacheKey))
+		}
+	}
+	return image, nil
+}
+
+// readImage 读取图片响应，JSON 响应中的 errcode 会被转换为错误
+func readImage(resp *http.Response) ([]byte, error) {
+	var buf bytes.Buffer
+	_, result, err := api.ReadDownload(resp, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+	return buf.Bytes(), nil
+}
+
+// cacheKey 按接口和请求体的 SHA-256 摘要生成缓存键，前缀与客户端的 WithKeyPrefix 一致
+func (api *CodeAPI) cacheKey(endpoint string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf("%s%s_wxacode%s_%s", api.client.KeyPrefix(), api.client.AppID, endpoint, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wechatpy/wechatgo/crypto"
+)
+
+// ErrInvalidWatermark 解密数据的水印校验失败
+var ErrInvalidWatermark = errors.New("invalid watermark")
+
+// Watermark 解密数据中的水印
+type Watermark struct {
+	AppID     string `json:"appid"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Check 校验水印的 appid 和时间戳，maxAge 为 0 时不校验时间戳
+func (w Watermark) Check(appID string, maxAge time.Duration) error {
+	if w.AppID != appID {
+		return fmt.Errorf("%w: appid %q mismatch", ErrInvalidWatermark, w.AppID)
+	}
+	if maxAge > 0 {
+		age := time.Since(time.Unix(w.Timestamp, 0))
+		// 允许与微信服务器存在少量时钟偏差
+		if age > maxAge || age < -time.Minute {
+			return fmt.Errorf("%w: timestamp %d expired", ErrInvalidWatermark, w.Timestamp)
+		}
+	}
+	return nil
+}
+
+// Decrypt 使用 session_key 和 iv 对 encryptedData 进行 AES-128-CBC 解密，返回明文 JSON
+// https://developers.weixin.qq.com/miniprogram/dev/framework/open-ability/signature.html
+func Decrypt(sessionKey, encryptedData, iv string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(sessionKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session_key: %w", err)
+	}
+	ivBytes, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryptedData: %w", err)
+	}
+	if len(ivBytes) != 16 {
+		return nil, errors.New("invalid iv length")
+	}
+	if len(ciphertext) == 0 || len(ciphertext)%16 != 0 {
+		return nil, errors.New("invalid encryptedData length")
+	}
+
+	cipher, err := crypto.NewCBCCipher(key, ivBytes)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := cipher.Decrypt(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.PKCS7Decode(plaintext), nil
+}
+
+// DecryptData 解密 encryptedData 到 v，并校验水印中的 appid 和时间戳
+func DecryptData(appID, sessionKey, encryptedData, iv string, maxAge time.Duration, v interface{}) error {
+	plaintext, err := Decrypt(sessionKey, encryptedData, iv)
+	if err != nil {
+		return err
+	}
+
+	var data struct {
+		Watermark Watermark `json:"watermark"`
+	}
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return fmt.Errorf("decrypt failed: %w", err)
+	}
+	if err := data.Watermark.Check(appID, maxAge); err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(plaintext, v)
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/wechatpy/wechatgo/client/api"
+)
+
+// 失效类型
+const (
+	ExpireTypeTime     = 0 // 指定失效时间
+	ExpireTypeInterval = 1 // 指定失效天数
+)
+
+// LinkAPI URL Scheme、URL Link 与 Short Link API
+type LinkAPI struct {
+	*api.BaseAPI
+}
+
+// NewLinkAPI 创建链接 API
+func NewLinkAPI(client *WxaClient) *LinkAPI {
+	return &LinkAPI{
+		BaseAPI: api.NewBaseAPI(client),
+	}
+}
+
+// JumpWxa 跳转到的目标小程序信息
+type JumpWxa struct {
+	Path       string `json:"path,omitempty"`
+	Query      string `json:"query,omitempty"`
+	EnvVersion string `json:"env_version,omitempty"`
+}
+
+// SchemeRequest 生成 URL Scheme 请求
+type SchemeRequest struct {
+	JumpWxa        *JumpWxa `json:"jump_wxa,omitempty"`
+	IsExpire       bool     `json:"is_expire,omitempty"`
+	ExpireType     int      `json:"expire_type,omitempty"`
+	ExpireTime     int64    `json:"expire_time,omitempty"`
+	ExpireInterval int      `json:"expire_interval,omitempty"`
+}
+
+// SchemeInfo URL Scheme 信息
+type SchemeInfo struct {
+	AppID      string `json:"appid"`
+	Path       string `json:"path"`
+	Query      string `json:"query"`
+	CreateTime int64  `json:"create_time"`
+	ExpireTime int64  `json:"expire_time"`
+	EnvVersion string `json:"env_version"`
+}
+
+// LinkQuota 长期有效链接的配额
+type LinkQuota struct {
+	LongTimeUsed  int `json:"long_time_used"`
+	LongTimeLimit int `json:"long_time_limit"`
+}
+
+// SchemeQueryResult 查询 URL Scheme 结果
+type SchemeQueryResult struct {
+	SchemeInfo  SchemeInfo `json:"scheme_info"`
+	SchemeQuota LinkQuota  `json:"scheme_quota"`
+}
+
+// URLLinkRequest 生成 URL Link 请求
+type URLLinkRequest struct {
+	Path           string `json:"path,omitempty"`
+	Query          string `json:"query,omitempty"`
+	EnvVersion     string `json:"env_version,omitempty"`
+	IsExpire       bool   `json:"is_expire,omitempty"`
+	ExpireType     int    `json:"expire_type,omitempty"`
+	ExpireTime     int64  `json:"expire_time,omitempty"`
+	ExpireInterval int    `json:"expire_interval,omitempty"`
+}
+
+// URLLinkInfo URL Link 信息
+type URLLinkInfo struct {
+	AppID      string `json:"appid"`
+	Path       string `json:"path"`
+	Query      string `json:"query"`
+	CreateTime int64  `json:"create_time"`
+	ExpireTime int64  `json:"expire_time"`
+	EnvVersion string `json:"env_version"`
+}
+
+// URLLinkQueryResult 查询 URL Link 结果
+type URLLinkQueryResult struct {
+	URLLinkInfo  URLLinkInfo `json:"url_link_info"`
+	URLLinkQuota LinkQuota   `json:"url_link_quota"`
+}
+
+// GenerateScheme 获取小程序 URL Scheme
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/url-scheme/generateScheme.html
+func (api *LinkAPI) GenerateScheme(req *SchemeRequest) (string, error) {
+	result, err := api.Post("/wxa/generatescheme", req)
+	if err != nil {
+		return "", err
+	}
+
+	if openlink, ok := result["openlink"].(string); ok {
+		return openlink, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// QueryScheme 查询小程序 URL Scheme
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/url-scheme/queryScheme.html
+func (api *LinkAPI) QueryScheme(scheme string) (*SchemeQueryResult, error) {
+	result, err := api.Post("/wxa/queryscheme", map[string]string{"scheme": scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	var query SchemeQueryResult
+	if err := decodeResult(result, &query); err != nil {
+		return nil, err
+	}
+	return &query, nil
+}
+
+// GenerateURLLink 获取小程序 URL Link
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/url-link/generateUrlLink.html
+func (api *LinkAPI) GenerateURLLink(req *URLLinkRequest) (string, error) {
+	result, err := api.Post("/wxa/generate_urllink", req)
+	if err != nil {
+		return "", err
+	}
+
+	if urlLink, ok := result["url_link"].(string); ok {
+		return urlLink, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// QueryURLLink 查询小程序 URL Link
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/url-link/queryUrlLink.html
+func (api *LinkAPI) QueryURLLink(urlLink string) (*URLLinkQueryResult, error) {
+	result, err := api.Post("/wxa/query_urllink", map[string]string{"url_link": urlLink})
+	if err != nil {
+		return nil, err
+	}
+
+	var query URLLinkQueryResult
+	if err := decodeResult(result, &query); err != nil {
+		return nil, err
+	}
+	return &query, nil
+}
+
+// GenerateShortLink 获取小程序 Short Link
+// pageURL 为小程序页面路径（可带参数），permanent 为 true 时生成永久有效的链接（数量有限）
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/qrcode-link/short-link/generateShortLink.html
+func (api *LinkAPI) GenerateShortLink(pageURL, pageTitle string, permanent bool) (string, error) {
+	result, err := api.Post("/wxa/genwxashortlink", map[string]interface{}{
+		"page_url":     pageURL,
+		"page_title":   pageTitle,
+		"is_permanent": permanent,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if link, ok := result["link"].(string); ok {
+		return link, nil
+	}
+	return "", fmt.Errorf("unexpected response format")
+}
+
+// decodeResult 将接口返回的 map 转换为结构体
+var decodeResult = api.DecodeResult